@@ -153,9 +153,58 @@ func (a *analyser) analyseAPP(path string) error {
 		}
 	}
 
+	if len(a.parameter.Platform) == 0 {
+		if executable, err := getLinuxExecutable(path); err == nil {
+			a.parameter.Platform = "linux"
+			a.parameter.Name = filepath.Base(path)
+			a.parameter.Identifier = a.parameter.Name
+			a.parameter.App.Executable = &ExecutableParams{
+				Executable: executable,
+			}
+		}
+	}
+
 	return nil
 }
 
+// getLinuxExecutable returns the relative path of the least nested ELF binary which is not a shared library
+func getLinuxExecutable(dirPath string) (string, error) {
+	var executable string
+	depth := -1
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || strings.Contains(info.Name(), ".so") {
+			return err
+		}
+		rel, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return err
+		}
+		level := strings.Count(rel, string(filepath.Separator))
+		if depth >= 0 && level >= depth {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		magic := make([]byte, 4)
+		if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, []byte("\x7fELF")) {
+			return nil
+		}
+		executable = filepath.ToSlash(rel)
+		depth = level
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if depth < 0 {
+		return "", fmt.Errorf("no executable found in %s", dirPath)
+	}
+	return executable, nil
+}
+
 func (a *analyser) analyseIPA() error {
 
 	return a.analyseAPP(a.appPath)
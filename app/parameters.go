@@ -5,7 +5,9 @@ type AndroidParams struct {
 }
 
 type ExecutableParams struct {
-	Executable string
+	Executable  string
+	Arguments   []string
+	Environment map[string]string
 }
 
 type AppParams struct {
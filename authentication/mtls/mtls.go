@@ -0,0 +1,109 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/fsuhrau/automationhub/config"
+	"net/http"
+	"os"
+	"time"
+)
+
+var (
+	enabled      bool
+	clientConfig *tls.Config
+)
+
+// Setup configures the connections to the master, nodes present their certificate
+// and only trust the configured ca certificate
+func Setup(cfg config.TLS) error {
+	enabled = cfg.Enabled
+	clientConfig = nil
+	if !cfg.Enabled {
+		return nil
+	}
+
+	clientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(cfg.CACert) > 0 {
+		caPEM, err := os.ReadFile(cfg.CACert)
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("no certificates found in %s", cfg.CACert)
+		}
+		clientConfig.RootCAs = pool
+	}
+
+	if len(cfg.Cert) > 0 {
+		cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+		if err != nil {
+			return err
+		}
+		clientConfig.Certificates = []tls.Certificate{cert}
+	}
+	return nil
+}
+
+func Enabled() bool {
+	return enabled
+}
+
+// ClientConfig returns the tls config for connections to the master or nil if tls is disabled
+func ClientConfig() *tls.Config {
+	return clientConfig
+}
+
+// HTTPScheme returns the scheme to reach the master
+func HTTPScheme() string {
+	if enabled {
+		return "https"
+	}
+	return "http"
+}
+
+// WebsocketScheme returns the scheme for the rpc connection to the master
+func WebsocketScheme() string {
+	if enabled {
+		return "wss"
+	}
+	return "ws"
+}
+
+// HTTPClient returns a client which authenticates with the node certificate
+func HTTPClient(timeout time.Duration) *http.Client {
+	client := &http.Client{Timeout: timeout}
+	if clientConfig != nil {
+		client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: clientConfig,
+		}
+	}
+	return client
+}
+
+// ServerConfig returns the tls config of the master, client certificates are optional for browsers
+// but verified against the ca whenever a client presents one
+func ServerConfig(ca *CA, hosts []string) (*tls.Config, error) {
+	cert, err := ca.IssueServerCertificate(hosts)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		ClientCAs:    ca.Pool(),
+	}, nil
+}
+
+// PeerIdentity returns the node identifier of a verified client certificate
+func PeerIdentity(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName
+}
@@ -12,14 +12,16 @@ import (
 
 type Client struct {
 	BaseURL    string
+	ProjectURL string
 	apiToken   string
 	HTTPClient *http.Client
 }
 
 func NewClient(url, apiToken, projectID string, appId uint) *Client {
 	return &Client{
-		BaseURL:  fmt.Sprintf("%s/api/%s/app/%d", url, projectID, appId),
-		apiToken: apiToken,
+		BaseURL:    fmt.Sprintf("%s/api/%s/app/%d", url, projectID, appId),
+		ProjectURL: fmt.Sprintf("%s/api/%s", url, projectID),
+		apiToken:   apiToken,
 		HTTPClient: &http.Client{
 			Timeout: time.Minute,
 		},
@@ -27,7 +29,9 @@ func NewClient(url, apiToken, projectID string, appId uint) *Client {
 }
 
 func (c *Client) sendRequest(req *http.Request, v interface{}) error {
-	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if len(req.Header.Get("Content-Type")) == 0 {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
 	req.Header.Set("Accept", "application/json; charset=utf-8")
 	req.Header.Set("X-Auth-Token", c.apiToken)
 
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"net/http"
+	"time"
+)
+
+func (c *Client) GetNode(ctx context.Context, nodeID uint) (*models.Node, error) {
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/settings/nodes/%d", c.ProjectURL, nodeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var node models.Node
+	if err := c.sendRequest(req, &node); err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
+
+// DrainNode puts the node into maintenance, running tests continue until they finished or the deadline is reached
+func (c *Client) DrainNode(ctx context.Context, nodeID uint, deadline time.Duration, reason string) (*models.Node, error) {
+	request := map[string]string{
+		"reason": reason,
+	}
+	if deadline > 0 {
+		request["deadline"] = deadline.String()
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/settings/nodes/%d/drain", c.ProjectURL, nodeID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var node models.Node
+	if err := c.sendRequest(req, &node); err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
+
+// EndNodeMaintenance makes the devices of the node available for test runs again
+func (c *Client) EndNodeMaintenance(ctx context.Context, nodeID uint) (*models.Node, error) {
+
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/settings/nodes/%d/drain", c.ProjectURL, nodeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var node models.Node
+	if err := c.sendRequest(req, &node); err != nil {
+		return nil, err
+	}
+
+	return &node, nil
+}
@@ -52,3 +52,49 @@ func (c *Client) ExecuteTest(ctx context.Context, testID uint, binaryID int, par
 
 	return &run, nil
 }
+
+// RerunFailedTests executes only the failed tests of the run again with its binary and parameters
+func (c *Client) RerunFailedTests(ctx context.Context, testID uint, runID uint) (*models.TestRun, error) {
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/test/%d/run/%d/rerun-failed", c.BaseURL, testID, runID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var run models.TestRun
+	if err := c.sendRequest(req, &run); err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// ExecuteTestMatrix runs the test once for every combination of the matrix e.g. graphics=[low,high];locale=[en,de,ja]
+func (c *Client) ExecuteTestMatrix(ctx context.Context, testID uint, binaryID int, params, matrix string) (*models.TestMatrixSummary, error) {
+	request := api.RunTestMatrixRequest{
+		AppBinaryID: binaryID,
+		Params:      params,
+		Matrix:      matrix,
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/test/%d/matrix", c.BaseURL, testID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var summary models.TestMatrixSummary
+	if err := c.sendRequest(req, &summary); err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
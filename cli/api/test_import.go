@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// ImportTestResults creates a test run from a NUnit, xUnit or JUnit result file, the fields are optional
+// e.g. format, appBinaryId, deviceId and params
+func (c *Client) ImportTestResults(ctx context.Context, testID uint, resultsPath string, fields map[string]string) (*models.TestRun, error) {
+	file, err := os.Open(resultsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	for key, value := range fields {
+		if len(value) == 0 {
+			continue
+		}
+		if err := w.WriteField(key, value); err != nil {
+			return nil, err
+		}
+	}
+	fw, err := w.CreateFormFile("results", filepath.Base(resultsPath))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(fw, file); err != nil {
+		return nil, err
+	}
+	w.Close()
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/test/%d/import", c.BaseURL, testID), &b)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	req = req.WithContext(ctx)
+
+	var run models.TestRun
+	if err := c.sendRequest(req, &run); err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
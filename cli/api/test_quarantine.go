@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fsuhrau/automationhub/endpoints/api"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"net/http"
+)
+
+func (c *Client) GetTestQuarantines(ctx context.Context, testID uint) ([]models.TestQuarantine, error) {
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/test/%d/quarantine", c.BaseURL, testID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var quarantines []models.TestQuarantine
+	if err := c.sendRequest(req, &quarantines); err != nil {
+		return nil, err
+	}
+
+	return quarantines, nil
+}
+
+func (c *Client) QuarantineTest(ctx context.Context, testID uint, request api.QuarantineRequest) (*models.TestQuarantine, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/test/%d/quarantine", c.BaseURL, testID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var quarantine models.TestQuarantine
+	if err := c.sendRequest(req, &quarantine); err != nil {
+		return nil, err
+	}
+
+	return &quarantine, nil
+}
+
+func (c *Client) ReleaseTestQuarantine(ctx context.Context, testID uint, quarantineID uint) (*models.TestQuarantine, error) {
+
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/test/%d/quarantine/%d", c.BaseURL, testID, quarantineID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	var quarantine models.TestQuarantine
+	if err := c.sendRequest(req, &quarantine); err != nil {
+		return nil, err
+	}
+
+	return &quarantine, nil
+}
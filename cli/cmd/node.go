@@ -0,0 +1,111 @@
+/*
+Copyright © 2021 Fabian Suhrau <fabian.suhrau@me.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/cli/api"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"strconv"
+	"time"
+)
+
+var (
+	drainDeadline time.Duration
+	drainReason   string
+	drainWait     bool
+)
+
+// nodeCmd represents the node command
+var nodeCmd = &cobra.Command{
+	Use:   "node",
+	Short: "manage the nodes of a running master",
+}
+
+// nodeClient creates the client from the url and project arguments and parses the node id
+func nodeClient(args []string) (*api.Client, uint, error) {
+	nodeID, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid node id: %v", err)
+	}
+	return api.NewClient(args[0], apiToken, args[1], 0), uint(nodeID), nil
+}
+
+var nodeDrainCmd = &cobra.Command{
+	Use:   "drain",
+	Short: "drain http://localhost:8002 projectID nodeID --deadline 1h --reason \"os update\" --wait",
+	Long: `Put a node into maintenance.
+a draining node accepts no new device locks, running tests continue until they are finished or the deadline is reached.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 3 {
+			return fmt.Errorf("missing parameter")
+		}
+		client, nodeID, err := nodeClient(args)
+		if err != nil {
+			return err
+		}
+
+		node, err := client.DrainNode(context.Background(), nodeID, drainDeadline, drainReason)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("node %s %s", node.Name, node.Maintenance)
+
+		for drainWait && node.Maintenance == models.NodeMaintenanceDraining {
+			time.Sleep(10 * time.Second)
+			if node, err = client.GetNode(context.Background(), nodeID); err != nil {
+				return err
+			}
+			logrus.Infof("node %s %s, %d running tests", node.Name, node.Maintenance, node.RunningTests)
+		}
+		return nil
+	},
+}
+
+var nodeResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "resume http://localhost:8002 projectID nodeID",
+	Long:  `end the maintenance of a node`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 3 {
+			return fmt.Errorf("missing parameter")
+		}
+		client, nodeID, err := nodeClient(args)
+		if err != nil {
+			return err
+		}
+
+		node, err := client.EndNodeMaintenance(context.Background(), nodeID)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("node %s available", node.Name)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(nodeCmd)
+	nodeCmd.AddCommand(nodeDrainCmd, nodeResumeCmd)
+
+	nodeDrainCmd.PersistentFlags().DurationVar(&drainDeadline, "deadline", 0, "maximum time to wait for running tests (default 1h)")
+	nodeDrainCmd.PersistentFlags().StringVar(&drainReason, "reason", "", "reason for the maintenance")
+	nodeDrainCmd.PersistentFlags().BoolVar(&drainWait, "wait", false, "wait until the node is drained")
+}
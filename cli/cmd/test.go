@@ -56,6 +56,20 @@ var testCmd = &cobra.Command{
 	},
 }
 
+// findTestID returns the id of the test with the name
+func findTestID(client *api.Client, testName string) (uint, error) {
+	tests, err := client.GetTests(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	for _, t := range tests {
+		if t.Name == testName {
+			return t.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("test %s could not be found", testName)
+}
+
 func init() {
 	rootCmd.AddCommand(testCmd)
 }
@@ -0,0 +1,89 @@
+/*
+Copyright © 2021 Fabian Suhrau <fabian.suhrau@me.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/cli/api"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+var (
+	importFormat   string
+	importDeviceID string
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import http://localhost:8002 projectID appID testName results.xml --format junit --binaryID 50 --device deviceIdentifier --params \"param1=1;param2=2\"",
+	Long: `Import the results of tests executed outside the hub.
+creates a test run from a NUnit 3, xUnit or JUnit result file, the format is detected from the file if not given.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 5 {
+			return fmt.Errorf("missing parameter")
+		}
+
+		apiURL := args[0]
+		projectID := args[1]
+		u, _ := strconv.ParseUint(args[2], 10, 64)
+		appID := uint(u)
+
+		client := api.NewClient(apiURL, apiToken, projectID, appID)
+
+		testID, err := findTestID(client, args[3])
+		if err != nil {
+			return err
+		}
+
+		fields := map[string]string{
+			"format":   importFormat,
+			"deviceId": importDeviceID,
+			"params":   params,
+		}
+		if binaryID > 0 {
+			fields["appBinaryId"] = strconv.Itoa(binaryID)
+		}
+
+		testRun, err := client.ImportTestResults(context.Background(), testID, args[4], fields)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, p := range testRun.Protocols {
+			if p.TestResult.Failed() {
+				failed++
+			}
+		}
+		logrus.Infof("imported run %d with %d tests, %d failed", testRun.ID, len(testRun.Protocols), failed)
+		logrus.Infof("Check your restults at: %s/project/%s/app/%d/test/%d/run/%d", apiURL, projectID, appID, testID, testRun.ID)
+		return nil
+	},
+}
+
+func init() {
+	testCmd.AddCommand(importCmd)
+
+	importCmd.PersistentFlags().StringVar(&importFormat, "format", "", "format of the results: nunit, xunit or junit (default is detected)")
+	importCmd.PersistentFlags().IntVar(&binaryID, "binaryID", 0, "id of the tested app binary")
+	importCmd.PersistentFlags().StringVar(&importDeviceID, "device", "", "identifier of the device the tests were executed on")
+	importCmd.PersistentFlags().StringVar(&params, "params", "", "params \"param1=1;param2=2\"")
+}
@@ -0,0 +1,152 @@
+/*
+Copyright © 2021 Fabian Suhrau <fabian.suhrau@me.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/cli/api"
+	endpoints "github.com/fsuhrau/automationhub/endpoints/api"
+	"github.com/spf13/cobra"
+	"strconv"
+	"time"
+)
+
+var (
+	quarantineDuration time.Duration
+	quarantineOwner    string
+	quarantineReason   string
+	quarantineFunction uint
+)
+
+// quarantineCmd represents the quarantine command
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "manage known broken tests whose failures don't fail a run",
+}
+
+// quarantineClient creates the client from the url, project and app arguments and looks up the test
+func quarantineClient(args []string) (*api.Client, uint, error) {
+	u, _ := strconv.ParseUint(args[2], 10, 64)
+	client := api.NewClient(args[0], apiToken, args[1], uint(u))
+
+	testID, err := findTestID(client, args[3])
+	if err != nil {
+		return nil, 0, err
+	}
+	return client, testID, nil
+}
+
+var quarantineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list http://localhost:8002 projectID appID testName",
+	Long:  `list the quarantined tests with id, name, owner, expiry and reason`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 4 {
+			return fmt.Errorf("missing parameter")
+		}
+		client, testID, err := quarantineClient(args)
+		if err != nil {
+			return err
+		}
+
+		quarantines, err := client.GetTestQuarantines(context.Background(), testID)
+		if err != nil {
+			return err
+		}
+
+		for _, q := range quarantines {
+			name := q.TestName
+			if len(name) == 0 {
+				name = "<all>"
+			}
+			fmt.Printf("ID: %d Name: %s Owner: %s Expires: %s Reason: %s\n", q.ID, name, q.Owner, q.ExpiresAt.Format(time.RFC3339), q.Reason)
+		}
+		return nil
+	},
+}
+
+var quarantineAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "add http://localhost:8002 projectID appID testName [Class/Method] --owner name --reason \"flaky\" --duration 168h",
+	Long: `Put a test into quarantine.
+without a Class/Method or --function the whole test is quarantined, e.g. a scenario test.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 4 {
+			return fmt.Errorf("missing parameter")
+		}
+		client, testID, err := quarantineClient(args)
+		if err != nil {
+			return err
+		}
+
+		request := endpoints.QuarantineRequest{
+			Duration: quarantineDuration.String(),
+			Owner:    quarantineOwner,
+			Reason:   quarantineReason,
+		}
+		if len(args) > 4 {
+			request.TestName = args[4]
+		}
+		if quarantineFunction > 0 {
+			request.UnityTestFunctionID = &quarantineFunction
+		}
+
+		quarantine, err := client.QuarantineTest(context.Background(), testID, request)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("quarantine %d until %s\n", quarantine.ID, quarantine.ExpiresAt.Format(time.RFC3339))
+		return nil
+	},
+}
+
+var quarantineReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "release http://localhost:8002 projectID appID testName quarantineID",
+	Long:  `remove a test from quarantine before it expires`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 5 {
+			return fmt.Errorf("missing parameter")
+		}
+		client, testID, err := quarantineClient(args)
+		if err != nil {
+			return err
+		}
+		quarantineID, err := strconv.ParseUint(args[4], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid quarantine id: %v", err)
+		}
+
+		quarantine, err := client.ReleaseTestQuarantine(context.Background(), testID, uint(quarantineID))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("quarantine %d released\n", quarantine.ID)
+		return nil
+	},
+}
+
+func init() {
+	testCmd.AddCommand(quarantineCmd)
+	quarantineCmd.AddCommand(quarantineListCmd, quarantineAddCmd, quarantineReleaseCmd)
+
+	quarantineAddCmd.PersistentFlags().DurationVar(&quarantineDuration, "duration", 7*24*time.Hour, "time until the test leaves the quarantine")
+	quarantineAddCmd.PersistentFlags().StringVar(&quarantineOwner, "owner", "", "who is fixing the test")
+	quarantineAddCmd.PersistentFlags().StringVar(&quarantineReason, "reason", "", "why the test is quarantined")
+	quarantineAddCmd.PersistentFlags().UintVar(&quarantineFunction, "function", 0, "id of the unity test function instead of the test name")
+}
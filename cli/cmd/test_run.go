@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/fsuhrau/automationhub/cli/api"
 	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/fsuhrau/automationhub/utils/sync"
 	sse "github.com/r3labs/sse/v2"
 	"github.com/sirupsen/logrus"
@@ -37,6 +38,9 @@ var (
 	async    *bool
 	success  bool
 	tags     string
+
+	rerunFailed uint
+	matrix      string
 )
 
 // runCmd represents the run command
@@ -44,6 +48,8 @@ var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "run http://localhost:8002 projectID appID testName --binaryID 50 --binary path_to_app --tags \"tag1,tag2,tag3\" --params \"param1=1;parameter2=2\" --async",
 	Long: `Run a new test.
+with --rerun-failed runID only the tests which failed in the given run are executed again with its binary and parameters.
+with --matrix the test runs once for every combination of the given values e.g. --matrix "graphics=[low,high];locale=[en,de,ja]".
 	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 4 {
@@ -98,10 +104,28 @@ var runCmd = &cobra.Command{
 			return fmt.Errorf("no test provided or test could not be found")
 		}
 
-		logrus.Infof("execute test %d with binaryId: %d\n%s", testID, binaryID, params)
-		parameter := strings.Split(params, ";")
-		logrus.Infof("execute test %d with binaryId: %d", testID, binaryID)
-		testRun, err := client.ExecuteTest(context.Background(), testID, binaryID, strings.Join(parameter, "\n"))
+		if len(matrix) > 0 {
+			logrus.Infof("execute test %d with binaryId: %d for matrix %s", testID, binaryID, matrix)
+			summary, err := client.ExecuteTestMatrix(context.Background(), testID, binaryID, params, matrix)
+			if err != nil {
+				return err
+			}
+			count, _ := summary.Dimensions.Count()
+			logrus.Infof("started matrix %d with %d combinations of %s", summary.Matrix.ID, count, summary.Dimensions)
+			return nil
+		}
+
+		var testRun *models.TestRun
+		var err error
+		if rerunFailed > 0 {
+			logrus.Infof("rerun the failed tests of run %d", rerunFailed)
+			testRun, err = client.RerunFailedTests(context.Background(), testID, rerunFailed)
+		} else {
+			logrus.Infof("execute test %d with binaryId: %d\n%s", testID, binaryID, params)
+			parameter := strings.Split(params, ";")
+			logrus.Infof("execute test %d with binaryId: %d", testID, binaryID)
+			testRun, err = client.ExecuteTest(context.Background(), testID, binaryID, strings.Join(parameter, "\n"))
+		}
 		if err != nil {
 			return err
 		}
@@ -171,5 +195,7 @@ func init() {
 	runCmd.PersistentFlags().IntVar(&binaryID, "binaryID", 0, "binaryID 123")
 	runCmd.PersistentFlags().StringVar(&params, "params", "", "params \"param1=1;param2=2\"")
 	runCmd.PersistentFlags().StringVar(&tags, "tags", "", "tag \"tag1,tag2,tag3\"")
+	runCmd.PersistentFlags().UintVar(&rerunFailed, "rerun-failed", 0, "runID of a previous run whose failed tests are executed again")
+	runCmd.PersistentFlags().StringVar(&matrix, "matrix", "", "matrix \"name=[value1,value2];name2=[value3]\"")
 	async = testCmd.PersistentFlags().BoolP("async", "a", false, "run command async observe status manually")
 }
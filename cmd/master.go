@@ -15,6 +15,7 @@
 package cmd
 
 import (
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/config"
 	"github.com/fsuhrau/automationhub/endpoints/api"
 	"github.com/fsuhrau/automationhub/endpoints/manager"
@@ -37,7 +38,7 @@ var masterCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		var serviceConfig config.Service
-		if err := viper.Unmarshal(&serviceConfig); err != nil {
+		if err := viper.Unmarshal(&serviceConfig, viper.DecodeHook(config.DecodeHook())); err != nil {
 			return err
 		}
 
@@ -48,27 +49,46 @@ var masterCmd = &cobra.Command{
 			return err
 		}
 
+		if interrupted, err := storage.FinishInterruptedRuns(db); err != nil {
+			return err
+		} else if interrupted > 0 {
+			logrus.Warnf("finished %d test runs which were interrupted by the last shutdown", interrupted)
+		}
+
 		deviceStore := storage.NewDeviceStore(db)
 
+		if err := mtls.Setup(serviceConfig.TLS); err != nil {
+			return err
+		}
+
+		var ca *mtls.CA
+		if serviceConfig.TLS.Enabled {
+			ca, err = mtls.LoadOrCreateCA(serviceConfig.TLS.GetCADir())
+			if err != nil {
+				return err
+			}
+		}
+
 		logger := logrus.New()
 
-		deviceManager := hub.NewDeviceManager(logger, serviceConfig)
+		deviceManager := hub.NewDeviceManager(logger, serviceConfig, db, deviceStore)
+		deviceManager.TrackStartupFailures()
 		sessionManager := hub.NewSessionManager(logger, deviceManager)
-		nodeManager := hub.NewNodeManager(logger, db)
+		nodeManager := hub.NewNodeManager(logger, db, serviceConfig.NodeHeartbeat)
 
 		server := hub.NewService(logger, hostIP, deviceManager, serviceConfig, deviceStore, db)
 
-		server.AddEndpoint(api.New(logger, db, serviceConfig.NodeUrl, deviceManager, sessionManager, serviceConfig, nodeManager))
+		server.AddEndpoint(api.New(logger, db, serviceConfig.NodeUrl, deviceManager, sessionManager, serviceConfig, nodeManager, ca))
 		server.AddEndpoint(manager.New(logger, deviceManager, serviceConfig))
 		server.AddEndpoint(web.New(db, serviceConfig))
-		server.AddEndpoint(node_master.New(serviceConfig, deviceManager, nodeManager, nil))
+		server.AddEndpoint(node_master.New(serviceConfig, deviceManager, nodeManager, nil, db))
 
 		// endpoint for websocket connection
 		server.AddEndpoint(deviceManager)
 
 		server.RegisterHooks(serviceConfig.Hooks)
 
-		return server.RunMaster(nodeManager, sessionManager)
+		return server.RunMaster(nodeManager, sessionManager, ca)
 	},
 }
 
@@ -15,6 +15,8 @@
 package cmd
 
 import (
+	"context"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/config"
 	"github.com/fsuhrau/automationhub/device/node"
 	"github.com/fsuhrau/automationhub/endpoints/manager"
@@ -35,7 +37,7 @@ var nodeCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var serviceConfig config.Service
 
-		if err := viper.Unmarshal(&serviceConfig); err != nil {
+		if err := viper.Unmarshal(&serviceConfig, viper.DecodeHook(config.DecodeHook())); err != nil {
 			return err
 		}
 
@@ -48,8 +50,12 @@ var nodeCmd = &cobra.Command{
 
 		logger := logrus.New()
 
+		if err := mtls.Setup(serviceConfig.TLS); err != nil {
+			return err
+		}
+
 		deviceStore := node.NewMemoryDeviceStore(serviceConfig.DeviceManager)
-		deviceManager := hub.NewDeviceManager(logger, serviceConfig)
+		deviceManager := hub.NewDeviceManager(logger, serviceConfig, db, deviceStore)
 
 		reconnectHandler := node_hub.ReconnectHandler{}
 		rpcNode := node_hub.NewRPCNode(serviceConfig, deviceManager, &reconnectHandler)
@@ -74,6 +80,8 @@ var nodeCmd = &cobra.Command{
 
 		reconnectHandler.HandleConnect()
 
+		go rpcNode.RunBundleJanitor(context.Background())
+
 		return server.RunNode(&reconnectHandler)
 	},
 }
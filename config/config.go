@@ -1,5 +1,11 @@
 package config
 
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
 type WebDriver struct {
 	BundleID string `yaml:"bundleId,omitempty" mapstructure:"bundleId"`
 }
@@ -10,13 +16,182 @@ type Device struct {
 }
 
 type Manager struct {
-	Enabled          bool              `yaml:"enabled,omitempty" mapstructure:"enabled"`
-	UseOSScreenshot  bool              `yaml:"use_os_screenshot,omitempty" mapstructure:"use_os_screenshot"`
-	WebDriver        *WebDriver        `yaml:"webdriver,omitempty" mapstructure:"webdriver"`
-	UnityPath        string            `yaml:"unity_path,omitempty" mapstructure:"unity_path"`
-	UnityBuildTarget string            `yaml:"unity_build_target,omitempty" mapstructure:"unity_build_target"`
-	Devices          []Device          `yaml:"devices,omitempty" mapstructure:"devices"`
-	Browser          map[string]string `yaml:"browser,omitempty" mapstructure:"browser"`
+	Enabled          bool                      `yaml:"enabled,omitempty" mapstructure:"enabled"`
+	UseOSScreenshot  bool                      `yaml:"use_os_screenshot,omitempty" mapstructure:"use_os_screenshot"`
+	WebDriver        *WebDriver                `yaml:"webdriver,omitempty" mapstructure:"webdriver"`
+	UnityPath        string                    `yaml:"unity_path,omitempty" mapstructure:"unity_path"`
+	UnityBuildTarget string                    `yaml:"unity_build_target,omitempty" mapstructure:"unity_build_target"`
+	UnityBatchmode   *UnityBatchmode           `yaml:"unity_batchmode,omitempty" mapstructure:"unity_batchmode"`
+	Devices          []Device                  `yaml:"devices,omitempty" mapstructure:"devices"`
+	Browser          map[string]BrowserProfile `yaml:"browser,omitempty" mapstructure:"browser"`
+	Fake             *Fake                     `yaml:"fake,omitempty" mapstructure:"fake"`
+	Linux            *Linux                    `yaml:"linux,omitempty" mapstructure:"linux"`
+	Docker           *Docker                   `yaml:"docker,omitempty" mapstructure:"docker"`
+	Web              *Web                      `yaml:"web,omitempty" mapstructure:"web"`
+}
+
+// Fake configures the simulated devices and the scripted game client of the fake manager
+type Fake struct {
+	Devices      int           `yaml:"devices,omitempty" mapstructure:"devices"`
+	Platform     string        `yaml:"platform,omitempty" mapstructure:"platform"`
+	OSVersion    string        `yaml:"os_version,omitempty" mapstructure:"os_version"`
+	Latency      time.Duration `yaml:"latency,omitempty" mapstructure:"latency"`
+	ConnectDelay time.Duration `yaml:"connect_delay,omitempty" mapstructure:"connect_delay"`
+	SceneGraph   string        `yaml:"scene_graph,omitempty" mapstructure:"scene_graph"`
+	Tests        []FakeTest    `yaml:"tests,omitempty" mapstructure:"tests"`
+}
+
+type FakeTest struct {
+	Assembly   string        `yaml:"assembly,omitempty" mapstructure:"assembly"`
+	Class      string        `yaml:"class,omitempty" mapstructure:"class"`
+	Method     string        `yaml:"method,omitempty" mapstructure:"method"`
+	Categories []string      `yaml:"categories,omitempty" mapstructure:"categories"`
+	Result     string        `yaml:"result,omitempty" mapstructure:"result"`
+	Duration   time.Duration `yaml:"duration,omitempty" mapstructure:"duration"`
+}
+
+// UnityBatchmode lets the unity editor manager run the tests of projects with -batchmode -runTests,
+// no editor has to connect back so it works on headless build machines
+type UnityBatchmode struct {
+	Projects []UnityProject `yaml:"projects,omitempty" mapstructure:"projects"`
+	Timeout  time.Duration  `yaml:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+// UnityProject is a project checkout on the node, every project is a separate device
+type UnityProject struct {
+	Name string `yaml:"name,omitempty" mapstructure:"name"`
+	Path string `yaml:"path,omitempty" mapstructure:"path"`
+}
+
+// Linux configures the hosts the linux manager registers as desktop devices
+type Linux struct {
+	Display    string      `yaml:"display,omitempty" mapstructure:"display"`
+	Xvfb       bool        `yaml:"xvfb,omitempty" mapstructure:"xvfb"`
+	Resolution string      `yaml:"resolution,omitempty" mapstructure:"resolution"`
+	Hosts      []LinuxHost `yaml:"hosts,omitempty" mapstructure:"hosts"`
+}
+
+// LinuxHost is a remote linux machine reachable via ssh, an empty address is the local host
+type LinuxHost struct {
+	Name    string `yaml:"name,omitempty" mapstructure:"name"`
+	Address string `yaml:"address,omitempty" mapstructure:"address"`
+	Display string `yaml:"display,omitempty" mapstructure:"display"`
+	Xvfb    bool   `yaml:"xvfb,omitempty" mapstructure:"xvfb"`
+}
+
+// BrowserProfile is a browser device of the web manager, several profiles can use the same browser
+type BrowserProfile struct {
+	Browser    string `yaml:"browser,omitempty" mapstructure:"browser"`
+	Path       string `yaml:"path,omitempty" mapstructure:"path"`
+	Viewport   string `yaml:"viewport,omitempty" mapstructure:"viewport"`
+	UserAgent  string `yaml:"user_agent,omitempty" mapstructure:"user_agent"`
+	Locale     string `yaml:"locale,omitempty" mapstructure:"locale"`
+	ProfileDir string `yaml:"profile_dir,omitempty" mapstructure:"profile_dir"`
+}
+
+// Web configures how the web manager controls chromium based browsers via the devtools protocol
+type Web struct {
+	Headless  bool   `yaml:"headless,omitempty" mapstructure:"headless"`
+	DebugPort int    `yaml:"debug_port,omitempty" mapstructure:"debug_port"`
+	Viewport  string `yaml:"viewport,omitempty" mapstructure:"viewport"`
+	Emulation string `yaml:"emulation,omitempty" mapstructure:"emulation"`
+	Network   string `yaml:"network,omitempty" mapstructure:"network"`
+}
+
+// Docker configures the images the docker manager runs as devices
+type Docker struct {
+	Containers []DockerContainer `yaml:"containers,omitempty" mapstructure:"containers"`
+}
+
+type DockerContainer struct {
+	Name       string            `yaml:"name,omitempty" mapstructure:"name"`
+	Image      string            `yaml:"image,omitempty" mapstructure:"image"`
+	Replicas   int               `yaml:"replicas,omitempty" mapstructure:"replicas"`
+	Platform   string            `yaml:"platform,omitempty" mapstructure:"platform"`
+	AppDir     string            `yaml:"app_dir,omitempty" mapstructure:"app_dir"`
+	Network    string            `yaml:"network,omitempty" mapstructure:"network"`
+	CPUs       string            `yaml:"cpus,omitempty" mapstructure:"cpus"`
+	Memory     string            `yaml:"memory,omitempty" mapstructure:"memory"`
+	Privileged bool              `yaml:"privileged,omitempty" mapstructure:"privileged"`
+	Env        map[string]string `yaml:"env,omitempty" mapstructure:"env"`
+	Volumes    []string          `yaml:"volumes,omitempty" mapstructure:"volumes"`
+	Command    []string          `yaml:"command,omitempty" mapstructure:"command"`
+}
+
+type HealthCheck struct {
+	Enabled               bool          `yaml:"enabled,omitempty" mapstructure:"enabled"`
+	Interval              time.Duration `yaml:"interval,omitempty" mapstructure:"interval"`
+	MinBatteryLevel       int           `yaml:"min_battery_level,omitempty" mapstructure:"min_battery_level"`
+	MaxBatteryTemperature float64       `yaml:"max_battery_temperature,omitempty" mapstructure:"max_battery_temperature"`
+	MinFreeStorageMB      int64         `yaml:"min_free_storage_mb,omitempty" mapstructure:"min_free_storage_mb"`
+	StartupFailures       int           `yaml:"startup_failures,omitempty" mapstructure:"startup_failures"`
+}
+
+type Recovery struct {
+	Enabled bool          `yaml:"enabled,omitempty" mapstructure:"enabled"`
+	Steps   []string      `yaml:"steps,omitempty" mapstructure:"steps"`
+	Timeout time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+type NodeHeartbeat struct {
+	Interval   time.Duration `yaml:"interval,omitempty" mapstructure:"interval"`
+	Timeout    time.Duration `yaml:"timeout,omitempty" mapstructure:"timeout"`
+	MaxMissed  int           `yaml:"max_missed,omitempty" mapstructure:"max_missed"`
+	Reschedule bool          `yaml:"reschedule,omitempty" mapstructure:"reschedule"`
+}
+
+// WithDefaults fills in the defaults for values which are not configured
+func (h NodeHeartbeat) WithDefaults() NodeHeartbeat {
+	if h.Interval <= 0 {
+		h.Interval = 5 * time.Second
+	}
+	if h.Timeout <= 0 {
+		h.Timeout = 10 * time.Second
+	}
+	if h.MaxMissed <= 0 {
+		h.MaxMissed = 3
+	}
+	return h
+}
+
+// LostAfter is the time without a heartbeat after which a node is considered lost
+func (h NodeHeartbeat) LostAfter() time.Duration {
+	h = h.WithDefaults()
+	return time.Duration(h.MaxMissed) * h.Interval
+}
+
+type BundleCache struct {
+	MaxSizeMB       int64         `yaml:"max_size_mb,omitempty" mapstructure:"max_size_mb"`
+	MaxAge          time.Duration `yaml:"max_age,omitempty" mapstructure:"max_age"`
+	KeepVersions    int           `yaml:"keep_versions,omitempty" mapstructure:"keep_versions"`
+	JanitorInterval time.Duration `yaml:"janitor_interval,omitempty" mapstructure:"janitor_interval"`
+	ChunkSizeMB     int64         `yaml:"chunk_size_mb,omitempty" mapstructure:"chunk_size_mb"`
+	ParallelChunks  int           `yaml:"parallel_chunks,omitempty" mapstructure:"parallel_chunks"`
+}
+
+// GetJanitorInterval returns how often the node enforces the cache policy
+func (b BundleCache) GetJanitorInterval() time.Duration {
+	if b.JanitorInterval <= 0 {
+		return time.Hour
+	}
+	return b.JanitorInterval
+}
+
+type TLS struct {
+	Enabled bool     `yaml:"enabled,omitempty" mapstructure:"enabled"`
+	CADir   string   `yaml:"ca_dir,omitempty" mapstructure:"ca_dir"`
+	Hosts   []string `yaml:"hosts,omitempty" mapstructure:"hosts"`
+	CACert  string   `yaml:"ca_cert,omitempty" mapstructure:"ca_cert"`
+	Cert    string   `yaml:"cert,omitempty" mapstructure:"cert"`
+	Key     string   `yaml:"key,omitempty" mapstructure:"key"`
+}
+
+// GetCADir returns the directory of the built-in ca of the master
+func (t TLS) GetCADir() string {
+	if len(t.CADir) == 0 {
+		return filepath.Join(os.Getenv("HOME"), ".automationhub", "ca")
+	}
+	return t.CADir
 }
 
 type Hook struct {
@@ -91,6 +266,11 @@ type Service struct {
 	Cors          []string           `yaml:"cors,omitempty" mapstructure:"cors"`
 	DeviceManager map[string]Manager `yaml:"managers,omitempty" mapstructure:"managers"`
 	Hooks         []Hook             `yaml:"hooks,omitempty" mapstructure:"hooks"`
+	HealthCheck   HealthCheck        `yaml:"health_check,omitempty" mapstructure:"health_check"`
+	Recovery      Recovery           `yaml:"recovery,omitempty" mapstructure:"recovery"`
+	NodeHeartbeat NodeHeartbeat      `yaml:"node_heartbeat,omitempty" mapstructure:"node_heartbeat"`
 	Auth          Auth               `yaml:"auth,omitempty" mapstructure:"auth"`
+	TLS           TLS                `yaml:"tls,omitempty" mapstructure:"tls"`
+	BundleCache   BundleCache        `yaml:"bundle_cache,omitempty" mapstructure:"bundle_cache"`
 	Database      Database           `yaml:"database,omitempty" mapstructure:"database"`
 }
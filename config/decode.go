@@ -0,0 +1,22 @@
+package config
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"reflect"
+)
+
+// DecodeHook extends the default viper hooks to accept the short form "name: /path/to/browser" for browser profiles
+func DecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		browserPathHook,
+	)
+}
+
+func browserPathHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() == reflect.String && to == reflect.TypeOf(BrowserProfile{}) {
+		return map[string]interface{}{"path": data}, nil
+	}
+	return data, nil
+}
@@ -0,0 +1,98 @@
+package androiddevice
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/device"
+	exec2 "github.com/fsuhrau/automationhub/tools/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	BatteryLevelRegex       = regexp.MustCompile(`(?m)^\s*level:\s*(\d+)`)
+	BatteryTemperatureRegex = regexp.MustCompile(`(?m)^\s*temperature:\s*(\d+)`)
+)
+
+func (d *Device) CheckHealth(thresholds device.HealthThresholds) error {
+	cmd := exec2.NewCommand("adb", "-s", d.DeviceID(), "shell", "echo", "ok")
+	out, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(out)) != "ok" {
+		return fmt.Errorf("adb not responding: %v", err)
+	}
+
+	if awake, err := d.IsAwake(); err != nil {
+		return fmt.Errorf("unable to check if device is awake: %v", err)
+	} else if !awake {
+		return fmt.Errorf("device is not awake")
+	}
+
+	if locked, err := d.IsPinLocked(); err != nil {
+		return fmt.Errorf("unable to check if device is locked: %v", err)
+	} else if locked {
+		return fmt.Errorf("device is pin locked")
+	}
+
+	if thresholds.MinFreeStorageMB > 0 {
+		free, err := d.freeStorageMB()
+		if err != nil {
+			return fmt.Errorf("unable to check free storage: %v", err)
+		}
+		if free < thresholds.MinFreeStorageMB {
+			return fmt.Errorf("free storage %d MB below %d MB", free, thresholds.MinFreeStorageMB)
+		}
+	}
+
+	if thresholds.MinBatteryLevel > 0 || thresholds.MaxBatteryTemperature > 0 {
+		level, temperature, err := d.batteryState()
+		if err != nil {
+			return fmt.Errorf("unable to check battery: %v", err)
+		}
+		if thresholds.MinBatteryLevel > 0 && level < thresholds.MinBatteryLevel {
+			return fmt.Errorf("battery level %d%% below %d%%", level, thresholds.MinBatteryLevel)
+		}
+		if thresholds.MaxBatteryTemperature > 0 && temperature > thresholds.MaxBatteryTemperature {
+			return fmt.Errorf("battery temperature %.1f°C above %.1f°C", temperature, thresholds.MaxBatteryTemperature)
+		}
+	}
+
+	return nil
+}
+
+func (d *Device) freeStorageMB() (int64, error) {
+	cmd := exec2.NewCommand("adb", "-s", d.DeviceID(), "shell", "df", "-k", "/data")
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected df output")
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("unexpected df output")
+	}
+	available, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return available / 1024, nil
+}
+
+func (d *Device) batteryState() (int, float64, error) {
+	cmd := exec2.NewCommand("adb", "-s", d.DeviceID(), "shell", "dumpsys", "battery")
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, 0, err
+	}
+	levelMatch := BatteryLevelRegex.FindStringSubmatch(string(out))
+	temperatureMatch := BatteryTemperatureRegex.FindStringSubmatch(string(out))
+	if len(levelMatch) < 2 || len(temperatureMatch) < 2 {
+		return 0, 0, fmt.Errorf("battery regex cant be evaluated")
+	}
+	level, _ := strconv.Atoi(levelMatch[1])
+	// temperature is reported in tenths of a degree celsius
+	temperature, _ := strconv.Atoi(temperatureMatch[1])
+	return level, float64(temperature) / 10, nil
+}
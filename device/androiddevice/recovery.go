@@ -0,0 +1,37 @@
+package androiddevice
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/app"
+	"github.com/fsuhrau/automationhub/storage/models"
+	exec2 "github.com/fsuhrau/automationhub/tools/exec"
+)
+
+func (d *Device) ForceStopApp(ctx context.Context, params *app.Parameter) error {
+	cmd := exec2.NewCommandContext(ctx, "adb", "-s", d.DeviceID(), "shell", "am", "force-stop", params.Identifier)
+	return cmd.Run()
+}
+
+func (d *Device) ClearAppData(ctx context.Context, params *app.Parameter) error {
+	cmd := exec2.NewCommandContext(ctx, "adb", "-s", d.DeviceID(), "shell", "pm", "clear", params.Identifier)
+	return cmd.Run()
+}
+
+func (d *Device) Reconnect(ctx context.Context) error {
+	config := d.GetConfig()
+	if config == nil || config.ConnectionParameter == nil || config.ConnectionParameter.ConnectionType != models.ConnectionTypeRemote {
+		return fmt.Errorf("reconnect is only supported for remote devices")
+	}
+	connection := GetConnectionString(config.ConnectionParameter)
+	_ = exec2.NewCommandContext(ctx, "adb", "disconnect", connection).Run()
+	return exec2.NewCommandContext(ctx, "adb", "connect", connection).Run()
+}
+
+// Reboot waits until the device is back, the wait is aborted when the context is done
+func (d *Device) Reboot(ctx context.Context) error {
+	if err := exec2.NewCommandContext(ctx, "adb", "-s", d.DeviceID(), "reboot").Run(); err != nil {
+		return err
+	}
+	return exec2.NewCommandContext(ctx, "adb", "-s", d.DeviceID(), "wait-for-device").Run()
+}
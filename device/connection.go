@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 	"io"
+	"sync"
 	"time"
 )
 
@@ -31,6 +32,39 @@ type Connection struct {
 	ResponseChannel     chan ResponseData
 	ActionChannel       chan action.Response
 	ConnectionParameter *action.Connect
+
+	// the read loop sends to the response channel while close closes it
+	mutex       sync.RWMutex
+	closed      bool
+	closing     chan struct{}
+	closingOnce sync.Once
+	closeOnce   sync.Once
+}
+
+func (c *Connection) closingChannel() chan struct{} {
+	c.closingOnce.Do(func() {
+		c.closing = make(chan struct{})
+	})
+	return c.closing
+}
+
+func (c *Connection) socket() *websocket.Conn {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.Connection
+}
+
+// respond passes data to the response channel unless the connection is closed
+func (c *Connection) respond(data ResponseData) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if c.closed || c.ResponseChannel == nil {
+		return
+	}
+	select {
+	case c.ResponseChannel <- data:
+	case <-c.closingChannel():
+	}
 }
 
 func (c *Connection) HandleMessages(ctx context.Context) {
@@ -42,22 +76,26 @@ func (c *Connection) HandleMessages(ctx context.Context) {
 	}()
 
 	for {
-		if err := c.Connection.SetReadDeadline(time.Now().Add(DefaultSocketTimeout)); err != nil {
+		conn := c.socket()
+		if conn == nil {
+			return
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(DefaultSocketTimeout)); err != nil {
 			c.Logger.Errorf("SocketAccept SetDeadline: %v", err)
 			return
 		}
 		time.Sleep(50 * time.Millisecond)
-		if c.Connection == nil {
+		if conn = c.socket(); conn == nil {
 			return
 		}
-		_, data, err := c.Connection.ReadMessage()
+		_, data, err := conn.ReadMessage()
 		//fmt.Printf("data = %d - %v - %v\n", t, data, err)
 		if err != nil {
 			c.handleReadError(err)
 			return
 		}
 
-		c.ResponseChannel <- ResponseData{Data: data, Err: nil}
+		c.respond(ResponseData{Data: data, Err: nil})
 	}
 }
 
@@ -67,9 +105,7 @@ func (c *Connection) handleReadError(err error) {
 	} else {
 		c.Logger.Info("Device disconnected")
 	}
-	if c.ResponseChannel != nil {
-		c.ResponseChannel <- ResponseData{Data: nil, Err: DeviceDisconnectedError}
-	}
+	c.respond(ResponseData{Data: nil, Err: DeviceDisconnectedError})
 }
 
 func (c *Connection) Close() {
@@ -79,6 +115,19 @@ func (c *Connection) Close() {
 		}
 	}()
 
+	// wake up a blocked read loop before the channels get closed
+	closing := c.closingChannel()
+	c.closeOnce.Do(func() {
+		close(closing)
+	})
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+
 	if c.Connection != nil {
 		_ = c.Connection.Close()
 	}
@@ -93,10 +142,14 @@ func (c *Connection) Close() {
 }
 
 func (c *Connection) Send(content []byte) error {
-	if c == nil || c.Connection == nil {
+	if c == nil {
+		return fmt.Errorf("device not connected")
+	}
+	conn := c.socket()
+	if conn == nil {
 		return fmt.Errorf("device not connected")
 	}
-	if err := c.Connection.WriteMessage(websocket.TextMessage, content); err != nil {
+	if err := conn.WriteMessage(websocket.TextMessage, content); err != nil {
 		return err
 	}
 	return nil
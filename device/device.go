@@ -59,6 +59,10 @@ type Device interface {
 	Lock() error
 	Unlock() error
 	IsLocked() bool
+	Quarantine(reason string)
+	ReleaseQuarantine()
+	IsQuarantined() bool
+	QuarantineReason() string
 
 	SetLogWriter(LogWriter)
 	GetLogWriter() LogWriter
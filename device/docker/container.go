@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const Docker = "docker"
+
+// container runs scripts inside a running container
+type container struct {
+	name string
+}
+
+func (c container) String() string {
+	return c.name
+}
+
+func (c container) Command(script string) *exec.Cmd {
+	return exec.Command(Docker, "exec", "-i", c.name, "sh", "-c", script)
+}
+
+// docker runs a docker cli command and returns its output
+func docker(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(Docker, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %v %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// containerStatus returns the status of the container or an empty string if it does not exist
+func containerStatus(name string) string {
+	status, err := docker("inspect", "--format", "{{.State.Status}}", name)
+	if err != nil {
+		return ""
+	}
+	return status
+}
+
+// containerStats returns the cpu in percent and the memory in MB used by the container
+func containerStats(name string) (float64, float64, error) {
+	out, err := docker("stats", "--no-stream", "--format", "{{.CPUPerc}}|{{.MemUsage}}", name)
+	if err != nil {
+		return 0, 0, err
+	}
+	cpuPerc, memUsage, ok := strings.Cut(out, "|")
+	if !ok {
+		return 0, 0, fmt.Errorf("unexpected stats output: %s", out)
+	}
+	cpu, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(cpuPerc), "%"), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	used, _, _ := strings.Cut(memUsage, "/")
+	mem, err := parseSize(strings.TrimSpace(used))
+	if err != nil {
+		return 0, 0, err
+	}
+	return cpu, mem / (1024 * 1024), nil
+}
+
+var sizeUnits = []struct {
+	suffix string
+	factor float64
+}{
+	{"KiB", 1024},
+	{"MiB", 1024 * 1024},
+	{"GiB", 1024 * 1024 * 1024},
+	{"TiB", 1024 * 1024 * 1024 * 1024},
+	{"kB", 1000},
+	{"KB", 1000},
+	{"MB", 1000 * 1000},
+	{"GB", 1000 * 1000 * 1000},
+	{"TB", 1000 * 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// parseSize converts docker sizes like 12.5MiB into bytes
+func parseSize(value string) (float64, error) {
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			size, err := strconv.ParseFloat(strings.TrimSuffix(value, unit.suffix), 64)
+			if err != nil {
+				return 0, err
+			}
+			return size * unit.factor, nil
+		}
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,397 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/app"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/generic"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/fsuhrau/automationhub/tools/shell"
+	"github.com/sirupsen/logrus"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ConnectionTimeout = 30 * time.Second
+	MetricsInterval   = 5 * time.Second
+
+	DefaultAppDir = "/opt/automationhub/apps"
+
+	// HostGateway is the name containers use to reach the node running on the docker host
+	HostGateway = "host.docker.internal"
+)
+
+type Device struct {
+	generic.Device
+	cfg         config.DockerContainer
+	container   container
+	deviceID    string
+	deviceName  string
+	deviceState device.State
+	label       string
+
+	mutex         sync.Mutex
+	app           *shell.Process
+	logs          *exec.Cmd
+	cancelMonitor context.CancelFunc
+}
+
+func newDevice(deviceID, name, label string, cfg config.DockerContainer) *Device {
+	if len(cfg.AppDir) == 0 {
+		cfg.AppDir = DefaultAppDir
+	}
+	return &Device{
+		cfg:         cfg,
+		container:   container{name: deviceID},
+		deviceID:    deviceID,
+		deviceName:  name,
+		deviceState: device.StateShutdown,
+		label:       label,
+	}
+}
+
+func (d *Device) DeviceParameter() map[string]string {
+	params := map[string]string{
+		"Image":     d.cfg.Image,
+		"Container": d.container.name,
+	}
+	if len(d.cfg.CPUs) > 0 {
+		params["CPUs"] = d.cfg.CPUs
+	}
+	if len(d.cfg.Memory) > 0 {
+		params["Memory"] = d.cfg.Memory
+	}
+	return params
+}
+
+func (d *Device) DeviceType() int {
+	if d.PlatformType() == int(models.PlatformTypeAndroid) {
+		return int(models.DeviceTypePhone)
+	}
+	return int(models.DeviceTypeDesktop)
+}
+
+func (d *Device) PlatformType() int {
+	if strings.ToLower(d.cfg.Platform) == "android" {
+		return int(models.PlatformTypeAndroid)
+	}
+	return int(models.PlatformTypeLinux)
+}
+
+func (d *Device) DeviceOSName() string {
+	if len(d.cfg.Platform) > 0 {
+		return d.cfg.Platform
+	}
+	return "linux"
+}
+
+func (d *Device) DeviceOSVersion() string {
+	return d.cfg.Image
+}
+
+func (d *Device) TargetVersion() string {
+	return ""
+}
+
+func (d *Device) DeviceName() string {
+	return d.deviceName
+}
+
+func (d *Device) DeviceID() string {
+	return d.deviceID
+}
+
+func (d *Device) DeviceIP() net.IP {
+	return nil
+}
+
+func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
+	return d.deviceState
+}
+
+func (d *Device) updateDeviceState() {
+	if containerStatus(d.container.name) == "running" {
+		d.deviceState = device.StateBooted
+	} else {
+		d.deviceState = device.StateShutdown
+	}
+}
+
+// start runs a new container or restarts the stopped one of this device
+func (d *Device) start() error {
+	switch containerStatus(d.container.name) {
+	case "running":
+		return nil
+	case "":
+		args := []string{"run", "-d", "--name", d.container.name, "--hostname", d.container.name,
+			"--label", d.label, "--add-host", HostGateway + ":host-gateway"}
+		if len(d.cfg.Network) > 0 {
+			args = append(args, "--network", d.cfg.Network)
+		}
+		if len(d.cfg.CPUs) > 0 {
+			args = append(args, "--cpus", d.cfg.CPUs)
+		}
+		if len(d.cfg.Memory) > 0 {
+			args = append(args, "--memory", d.cfg.Memory)
+		}
+		if d.cfg.Privileged {
+			args = append(args, "--privileged")
+		}
+		keys := make([]string, 0, len(d.cfg.Env))
+		for key := range d.cfg.Env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			args = append(args, "-e", key+"="+d.cfg.Env[key])
+		}
+		for _, volume := range d.cfg.Volumes {
+			args = append(args, "-v", volume)
+		}
+		args = append(args, d.cfg.Image)
+		args = append(args, d.cfg.Command...)
+		if _, err := docker(args...); err != nil {
+			return err
+		}
+	default:
+		if _, err := docker("start", d.container.name); err != nil {
+			return err
+		}
+	}
+	d.deviceState = device.StateBooted
+	return nil
+}
+
+// remove stops the app and deletes the container including all installed apps
+func (d *Device) remove() error {
+	d.StopApp(nil)
+	d.deviceState = device.StateShutdown
+	if containerStatus(d.container.name) == "" {
+		return nil
+	}
+	_, err := docker("rm", "-f", d.container.name)
+	return err
+}
+
+func (d *Device) appDir(params *app.Parameter) string {
+	return path.Join(d.cfg.AppDir, params.App.Hash)
+}
+
+func (d *Device) executable(params *app.Parameter) (string, error) {
+	if params.App == nil || params.App.Executable == nil || len(params.App.Executable.Executable) == 0 {
+		return "", fmt.Errorf("app %s has no executable", params.Identifier)
+	}
+	return path.Join(d.appDir(params), params.Name, params.App.Executable.Executable), nil
+}
+
+func (d *Device) IsAppInstalled(params *app.Parameter) (bool, error) {
+	return shell.Test(d.container, "test -d "+shell.Quote(d.appDir(params)))
+}
+
+func (d *Device) InstallApp(params *app.Parameter) error {
+	appDir := d.appDir(params)
+	if _, err := shell.Run(d.container, "mkdir -p "+shell.Quote(appDir)); err != nil {
+		return err
+	}
+
+	if filepath.Ext(params.App.AppPath) != ".zip" {
+		_, err := docker("cp", params.App.AppPath, d.container.name+":"+path.Join(appDir, filepath.Base(params.App.AppPath)))
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "automationhub_docker")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := app.Unzip(params.App.AppPath, tmpDir); err != nil {
+		return err
+	}
+	if _, err := docker("cp", tmpDir+string(filepath.Separator)+".", d.container.name+":"+appDir); err != nil {
+		return err
+	}
+
+	if executable, err := d.executable(params); err == nil {
+		_, err = shell.Run(d.container, "chmod 755 "+shell.Quote(executable))
+		return err
+	}
+	return nil
+}
+
+func (d *Device) UninstallApp(params *app.Parameter) error {
+	_, err := shell.Run(d.container, "rm -rf "+shell.Quote(d.appDir(params)))
+	return err
+}
+
+// containerNodeURL makes a node listening on the docker host reachable from inside the container
+func containerNodeURL(nodeUrl string) string {
+	raw := nodeUrl
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nodeUrl
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "0.0.0.0", "":
+	default:
+		return nodeUrl
+	}
+	host := HostGateway
+	if len(u.Port()) > 0 {
+		host = net.JoinHostPort(HostGateway, u.Port())
+	}
+	if !strings.Contains(nodeUrl, "://") {
+		return host
+	}
+	u.Host = host
+	return u.String()
+}
+
+func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sessionId string, nodeUrl string) error {
+	executable, err := d.executable(appParams)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.stopApp()
+
+	args := append([]string{"--sessionId=" + sessionId, "--nodeURL=" + containerNodeURL(nodeUrl), "--deviceId=" + d.deviceID}, appParams.App.Executable.Arguments...)
+
+	p, err := shell.Start(d.container, path.Join(d.appDir(appParams), appParams.Name), appParams.App.Executable.Environment, executable, args, func(line string) {
+		d.Log("stdout", "%s", line)
+	}, func(line string) {
+		d.Log("stderr", "%s", line)
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.app = p
+	d.cancelMonitor = cancel
+	d.followLogs()
+	go d.monitor(ctx, p)
+
+	return nil
+}
+
+// followLogs forwards the output of the container main process while the app is running
+func (d *Device) followLogs() {
+	cmd := exec.Command(Docker, "logs", "--follow", "--since", time.Now().UTC().Format(time.RFC3339), d.container.name)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		logrus.Warnf("docker device %s: unable to follow logs: %v", d.deviceID, err)
+		return
+	}
+	d.logs = cmd
+	go func() {
+		scanner := bufio.NewScanner(out)
+		for scanner.Scan() {
+			d.Log("container", "%s", scanner.Text())
+		}
+		cmd.Wait()
+	}()
+}
+
+// monitor reports the cpu and memory usage of the container until the app stops
+func (d *Device) monitor(ctx context.Context, p *shell.Process) {
+	ticker := time.NewTicker(MetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.Done():
+			d.Log("app", "process %d exited: %v", p.Pid(), p.Err())
+			return
+		case <-ticker.C:
+			cpu, mem, err := containerStats(d.container.name)
+			if err != nil {
+				logrus.Debugf("docker device %s: %v", d.deviceID, err)
+				continue
+			}
+			d.LogPerformance("container", cpu, 0, mem, 0, 0, "")
+		}
+	}
+}
+
+func (d *Device) stopApp() error {
+	if d.cancelMonitor != nil {
+		d.cancelMonitor()
+		d.cancelMonitor = nil
+	}
+	if d.logs != nil {
+		d.logs.Process.Kill()
+		d.logs = nil
+	}
+	if d.app == nil {
+		return nil
+	}
+	p := d.app
+	d.app = nil
+	return p.Stop()
+}
+
+func (d *Device) StopApp(params *app.Parameter) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.stopApp()
+}
+
+func (d *Device) IsAppConnected() bool {
+	return d.Connection() != nil
+}
+
+func (d *Device) StartRecording(path string) error {
+	return nil
+}
+
+func (d *Device) StopRecording() error {
+	return nil
+}
+
+func (d *Device) GetScreenshot() ([]byte, int, int, error) {
+	return nil, 0, 0, fmt.Errorf("screenshots are not supported by docker devices")
+}
+
+func (d *Device) HasFeature(string) bool {
+	return false
+}
+
+func (d *Device) Execute(string) {
+
+}
+
+func (d *Device) ConnectionTimeout() time.Duration {
+	return ConnectionTimeout
+}
+
+func (d *Device) RunNativeScript(script []byte) {
+
+}
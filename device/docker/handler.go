@@ -0,0 +1,144 @@
+package docker
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/generic"
+	"github.com/fsuhrau/automationhub/hub/node"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/sirupsen/logrus"
+	"os"
+	"regexp"
+)
+
+const (
+	Manager = "docker"
+
+	DefaultReplicas = 1
+
+	// Label marks the containers started by the docker manager
+	Label = "automationhub.device"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// Handler runs every device as a docker container, containers are created when a device starts
+// and removed when it stops so tests can scale horizontally on CI hosts
+type Handler struct {
+	devices       map[string]*Device
+	deviceStorage storage.Device
+	cfg           config.Docker
+}
+
+func NewHandler(cfg config.Manager, ds storage.Device) *Handler {
+	h := &Handler{devices: make(map[string]*Device), deviceStorage: ds}
+	if cfg.Docker != nil {
+		h.cfg = *cfg.Docker
+	}
+	return h
+}
+
+func (m *Handler) Name() string {
+	return Manager
+}
+
+func (m *Handler) Init(masterUrl, nodeIdentifier string, authToken *string) error {
+	if _, err := docker("version", "--format", "{{.Server.Version}}"); err != nil {
+		return err
+	}
+
+	prefix := nodeIdentifier
+	if len(prefix) == 0 {
+		prefix, _ = os.Hostname()
+	}
+
+	for _, c := range m.cfg.Containers {
+		if len(c.Image) == 0 {
+			logrus.Errorf("docker: container %s has no image", c.Name)
+			continue
+		}
+		replicas := c.Replicas
+		if replicas <= 0 {
+			replicas = DefaultReplicas
+		}
+		for i := 0; i < replicas; i++ {
+			deviceID := invalidNameChars.ReplaceAllString(fmt.Sprintf("docker-%s-%s-%d", prefix, c.Name, i), "-")
+			dev := newDevice(deviceID, fmt.Sprintf("%s %d", c.Name, i), Label+"="+deviceID, c)
+			dev.updateDeviceState()
+			if len(masterUrl) > 0 {
+				dev.SetLogWriter(generic.NewRemoteLogWriter(masterUrl, nodeIdentifier, dev.DeviceID(), authToken))
+				dev.AddActionHandler(node.NewRemoteActionHandler(masterUrl, nodeIdentifier, dev.DeviceID(), authToken))
+			}
+			m.devices[dev.DeviceID()] = dev
+			m.deviceStorage.Update(m.Name(), dev)
+		}
+	}
+
+	return m.RefreshDevices(true)
+}
+
+func (m *Handler) Start() error {
+	return nil
+}
+
+func (m *Handler) Stop() error {
+	for _, d := range m.devices {
+		if err := d.remove(); err != nil {
+			logrus.Errorf("docker: unable to remove %s: %v", d.DeviceID(), err)
+		}
+	}
+	return nil
+}
+
+func (m *Handler) StartDevice(deviceID string) error {
+	dev, ok := m.devices[deviceID]
+	if !ok {
+		return device.DeviceNotFoundError
+	}
+	err := dev.start()
+	m.deviceStorage.Update(m.Name(), dev)
+	return err
+}
+
+func (m *Handler) StopDevice(deviceID string) error {
+	dev, ok := m.devices[deviceID]
+	if !ok {
+		return device.DeviceNotFoundError
+	}
+	err := dev.remove()
+	m.deviceStorage.Update(m.Name(), dev)
+	return err
+}
+
+func (m *Handler) GetDevices() ([]device.Device, error) {
+	devices := make([]device.Device, 0, len(m.devices))
+	for _, d := range m.devices {
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+func (m *Handler) RefreshDevices(force bool) error {
+	for _, d := range m.devices {
+		state := d.deviceState
+		d.updateDeviceState()
+		if force || state != d.deviceState {
+			m.deviceStorage.Update(m.Name(), d)
+		}
+	}
+	return nil
+}
+
+func (m *Handler) HasDevice(dev device.Device) bool {
+	for _, d := range m.devices {
+		if d == dev {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Handler) RegisterDevice(data device.RegisterData) (device.Device, error) {
+	return nil, fmt.Errorf("register device not implemented")
+}
@@ -0,0 +1,301 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/config/protocol"
+	"github.com/fsuhrau/automationhub/hub/action"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ResultPassed    = "passed"
+	ResultFailed    = "failed"
+	ResultException = "exception"
+	ResultTimeout   = "timeout"
+)
+
+var defaultTests = []config.FakeTest{
+	{Assembly: "Fake.Tests", Class: "SmokeTests", Method: "Startup", Categories: []string{"smoke"}, Duration: time.Second},
+	{Assembly: "Fake.Tests", Class: "SmokeTests", Method: "MainMenu", Categories: []string{"smoke"}, Duration: time.Second},
+	{Assembly: "Fake.Tests", Class: "GameplayTests", Method: "FirstLevel", Categories: []string{"gameplay"}, Duration: 2 * time.Second},
+}
+
+const defaultSceneGraph = `<Root Class="Root" ID="0" Name="Root" X="0" Y="0" RectangleX="360" RectangleY="640" isVisible="1"><Button Class="Button" ID="1" Name="StartButton" X="180" Y="320" RectangleX="120" RectangleY="40" isVisible="1" LabelText="Start"></Button></Root>`
+
+// client is a scripted game client, it connects to the node like the automation sdk of a game
+// and answers actions with the configured results and latencies
+type client struct {
+	dev       *Device
+	url       string
+	sessionID string
+	authToken *string
+	cfg       config.Fake
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+}
+
+func newClient(dev *Device, nodeUrl, sessionID string, authToken *string) *client {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &client{
+		dev:       dev,
+		url:       socketURL(nodeUrl),
+		sessionID: sessionID,
+		authToken: authToken,
+		cfg:       dev.cfg,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// socketURL converts the node url which is either host:port or a http url into the device socket url
+func socketURL(nodeUrl string) string {
+	u, err := url.Parse(nodeUrl)
+	if err != nil || len(u.Host) == 0 {
+		u = &url.URL{Scheme: "ws", Host: nodeUrl}
+	}
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = "/device/connect"
+	return u.String()
+}
+
+func (c *client) run() {
+	if !c.wait(c.cfg.ConnectDelay) {
+		return
+	}
+
+	headers := http.Header{}
+	if c.authToken != nil {
+		headers.Set("X-Auth-Token", *c.authToken)
+	}
+
+	dialer := websocket.Dialer{
+		HandshakeTimeout: ConnectionTimeout,
+		ReadBufferSize:   protocol.SocketFrameSize,
+		WriteBufferSize:  protocol.SocketFrameSize,
+	}
+	conn, _, err := dialer.DialContext(c.ctx, c.url, headers)
+	if err != nil {
+		logrus.Errorf("fake client of %s unable to connect to %s: %v", c.dev.DeviceID(), c.url, err)
+		return
+	}
+	c.conn = conn
+	defer conn.Close()
+
+	if err := c.send(action.Response{
+		Success: true,
+		Payload: action.ResponseData{Connect: &action.Connect{
+			AppType:   action.AppType_Unity,
+			DeviceID:  c.dev.DeviceID(),
+			SessionID: c.sessionID,
+			Version:   "fake",
+		}},
+	}); err != nil {
+		logrus.Errorf("fake client of %s handshake failed: %v", c.dev.DeviceID(), err)
+		return
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req action.Request
+		if err := json.Unmarshal(data, &req); err != nil {
+			logrus.Errorf("fake client of %s received invalid request: %v", c.dev.DeviceID(), err)
+			continue
+		}
+		go c.handle(req)
+	}
+}
+
+func (c *client) stop() {
+	c.cancel()
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+}
+
+// wait sleeps for the given duration and returns false if the client was stopped in the meantime
+func (c *client) wait(d time.Duration) bool {
+	if d <= 0 {
+		return c.ctx.Err() == nil
+	}
+	select {
+	case <-c.ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+func (c *client) send(resp action.Response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *client) respond(req action.Request, success bool, payload action.ResponseData) {
+	if err := c.send(action.Response{
+		ActionID:   req.ActionID,
+		ActionType: req.ActionType,
+		Success:    success,
+		Payload:    payload,
+	}); err != nil {
+		logrus.Errorf("fake client of %s unable to answer %d: %v", c.dev.DeviceID(), req.ActionType, err)
+	}
+}
+
+func (c *client) handle(req action.Request) {
+	if !c.wait(c.cfg.Latency) {
+		return
+	}
+
+	switch req.ActionType {
+	case action.ActionType_GetTests:
+		tests := make([]action.Test, 0, len(c.cfg.Tests))
+		for _, t := range c.cfg.Tests {
+			tests = append(tests, action.Test{Assembly: t.Assembly, Class: t.Class, Method: t.Method, Categories: t.Categories})
+		}
+		c.respond(req, true, action.ResponseData{Tests: &action.Tests{Tests: tests}})
+	case action.ActionType_ExecuteTest:
+		c.executeTest(req)
+	case action.ActionType_GetSceneGraph:
+		c.respond(req, true, action.ResponseData{Screenshot: &action.Screenshot{
+			Sceengraph:  []byte(c.sceneGraph()),
+			ContentType: action.ContentType_Xml,
+		}})
+	case action.ActionType_GetScreenshot:
+		data, _, _, err := screenshot()
+		c.respond(req, err == nil, action.ResponseData{Screenshot: &action.Screenshot{
+			Sceengraph:  []byte(c.sceneGraph()),
+			Screenshot:  data,
+			ContentType: action.ContentType_Xml,
+		}})
+	case action.ActionType_ElementIsDisplayed:
+		visible := true
+		c.respond(req, true, action.ResponseData{Visible: &visible})
+	case action.ActionType_ElementGetValue:
+		value := ""
+		c.respond(req, true, action.ResponseData{Value: &value})
+	default:
+		c.respond(req, true, action.ResponseData{})
+	}
+}
+
+func (c *client) sceneGraph() string {
+	if len(c.cfg.SceneGraph) > 0 {
+		return c.cfg.SceneGraph
+	}
+	return defaultSceneGraph
+}
+
+// matchingTests returns the tests selected by the request, empty fields select everything
+func (c *client) matchingTests(test *action.Test) []config.FakeTest {
+	if test == nil {
+		return c.cfg.Tests
+	}
+	var tests []config.FakeTest
+	for _, t := range c.cfg.Tests {
+		if len(test.Assembly) > 0 && test.Assembly != t.Assembly {
+			continue
+		}
+		if len(test.Class) > 0 && test.Class != t.Class {
+			continue
+		}
+		if len(test.Method) > 0 && test.Method != t.Method {
+			continue
+		}
+		tests = append(tests, t)
+	}
+	return tests
+}
+
+func (c *client) log(level action.LogLevel, format string, params ...interface{}) {
+	_ = c.send(action.Response{
+		ActionType: action.ActionType_Log,
+		Success:    true,
+		Payload: action.ResponseData{LogData: &action.LogData{
+			Type:    action.LogType_StepLog,
+			Level:   level,
+			Message: fmt.Sprintf(format, params...),
+		}},
+	})
+}
+
+func (c *client) executeTest(req action.Request) {
+	tests := c.matchingTests(req.Payload.Test)
+	if len(tests) == 0 {
+		c.respond(req, false, action.ResponseData{})
+		return
+	}
+
+	var timeout time.Duration
+	for _, t := range tests {
+		timeout += t.Duration + time.Minute
+	}
+	c.respond(req, true, action.ResponseData{TestDetails: &action.TestDetails{
+		Timeout: timeout.Milliseconds(),
+	}})
+
+	passed := true
+	for _, t := range tests {
+		details := &action.TestDetails{
+			Test:       strings.Join([]string{t.Class, t.Method}, "."),
+			Timeout:    (t.Duration + time.Minute).Milliseconds(),
+			Categories: t.Categories,
+		}
+		c.respond(action.Request{ActionType: action.ActionType_ExecuteMethodStart}, true, action.ResponseData{TestDetails: details})
+		c.log(action.LogLevel_Info, "running %s", details.Test)
+
+		if !c.wait(t.Duration) {
+			return
+		}
+
+		switch strings.ToLower(t.Result) {
+		case ResultFailed:
+			c.log(action.LogLevel_Error, "%s failed: expected result not reached", details.Test)
+			c.respond(action.Request{ActionType: action.ActionType_ExecuteMethodFinished}, false, action.ResponseData{TestDetails: details})
+			passed = false
+		case ResultException:
+			c.log(action.LogLevel_Exception, "NullReferenceException: Object reference not set to an instance of an object in %s", details.Test)
+			return
+		case ResultTimeout:
+			// never answer, the runner has to run into its timeout
+			<-c.ctx.Done()
+			return
+		default:
+			c.respond(action.Request{ActionType: action.ActionType_Performance}, true, action.ResponseData{PerformanceData: &action.PerformanceData{
+				Checkpoint: details.Test,
+				CPU:        25,
+				Memory:     512,
+				FPS:        60,
+			}})
+			c.respond(action.Request{ActionType: action.ActionType_ExecuteMethodFinished}, true, action.ResponseData{TestDetails: details})
+		}
+	}
+
+	c.respond(action.Request{ActionType: action.ActionType_ExecutionResult}, passed, action.ResponseData{})
+}
@@ -0,0 +1,213 @@
+package fake
+
+import (
+	"bytes"
+	"github.com/fsuhrau/automationhub/app"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/generic"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"image"
+	"image/color"
+	"image/png"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const ConnectionTimeout = 10 * time.Second
+
+// Device is a virtual device which keeps installed apps in memory and starts a scripted game client instead of an app
+type Device struct {
+	generic.Device
+	deviceID        string
+	deviceName      string
+	deviceState     device.State
+	deviceParameter map[string]string
+	cfg             config.Fake
+	authToken       *string
+
+	mutex     sync.Mutex
+	installed map[string]bool
+	client    *client
+}
+
+func newDevice(deviceID, name string, cfg config.Fake, authToken *string) *Device {
+	return &Device{
+		deviceID:    deviceID,
+		deviceName:  name,
+		deviceState: device.StateBooted,
+		deviceParameter: map[string]string{
+			"Device Model": "Fake",
+			"RAM":          "4096",
+		},
+		cfg:       cfg,
+		authToken: authToken,
+		installed: make(map[string]bool),
+	}
+}
+
+func (d *Device) DeviceParameter() map[string]string {
+	return d.deviceParameter
+}
+
+func (d *Device) DeviceType() int {
+	return int(models.DeviceTypePhone)
+}
+
+func (d *Device) PlatformType() int {
+	switch strings.ToLower(d.cfg.Platform) {
+	case "ios":
+		return int(models.PlatformTypeiOS)
+	case "mac", "macos":
+		return int(models.PlatformTypeMac)
+	case "windows":
+		return int(models.PlatformTypeWindows)
+	case "linux":
+		return int(models.PlatformTypeLinux)
+	}
+	return int(models.PlatformTypeAndroid)
+}
+
+func (d *Device) DeviceOSName() string {
+	if len(d.cfg.Platform) > 0 {
+		return d.cfg.Platform
+	}
+	return "android"
+}
+
+func (d *Device) DeviceOSVersion() string {
+	if len(d.cfg.OSVersion) > 0 {
+		return d.cfg.OSVersion
+	}
+	return "1.0"
+}
+
+func (d *Device) TargetVersion() string {
+	return ""
+}
+
+func (d *Device) DeviceName() string {
+	return d.deviceName
+}
+
+func (d *Device) DeviceID() string {
+	return d.deviceID
+}
+
+func (d *Device) DeviceIP() net.IP {
+	return net.IPv4(127, 0, 0, 1)
+}
+
+func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
+	return d.deviceState
+}
+
+func (d *Device) IsAppInstalled(params *app.Parameter) (bool, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.installed[appKey(params)], nil
+}
+
+func (d *Device) InstallApp(params *app.Parameter) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.installed[appKey(params)] = true
+	return nil
+}
+
+func (d *Device) UninstallApp(params *app.Parameter) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.installed, appKey(params))
+	return nil
+}
+
+func appKey(params *app.Parameter) string {
+	if params.App != nil && len(params.App.Hash) > 0 {
+		return params.App.Hash
+	}
+	return params.Identifier
+}
+
+// StartApp starts the scripted game client which connects to the node like a real app would
+func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sessionId string, nodeUrl string) error {
+	d.disconnectClient()
+
+	c := newClient(d, nodeUrl, sessionId, d.authToken)
+
+	d.mutex.Lock()
+	d.client = c
+	d.mutex.Unlock()
+
+	go c.run()
+	return nil
+}
+
+func (d *Device) StopApp(params *app.Parameter) error {
+	d.disconnectClient()
+	return nil
+}
+
+func (d *Device) disconnectClient() {
+	d.mutex.Lock()
+	c := d.client
+	d.client = nil
+	d.mutex.Unlock()
+
+	if c != nil {
+		c.stop()
+	}
+}
+
+func (d *Device) IsAppConnected() bool {
+	return d.Connection() != nil
+}
+
+func (d *Device) StartRecording(path string) error {
+	return nil
+}
+
+func (d *Device) StopRecording() error {
+	return nil
+}
+
+func (d *Device) GetScreenshot() ([]byte, int, int, error) {
+	return screenshot()
+}
+
+// screenshot renders a plain image in the size of a small phone screen
+func screenshot() ([]byte, int, int, error) {
+	const width, height = 360, 640
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 40, G: 40, B: uint8(y * 255 / height), A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, 0, 0, err
+	}
+	return buffer.Bytes(), width, height, nil
+}
+
+func (d *Device) HasFeature(string) bool {
+	return false
+}
+
+func (d *Device) Execute(string) {
+
+}
+
+func (d *Device) ConnectionTimeout() time.Duration {
+	return ConnectionTimeout
+}
+
+func (d *Device) RunNativeScript(script []byte) {
+
+}
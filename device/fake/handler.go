@@ -0,0 +1,126 @@
+package fake
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/generic"
+	"github.com/fsuhrau/automationhub/hub/node"
+	"github.com/fsuhrau/automationhub/storage"
+	"os"
+)
+
+const (
+	Manager = "fake"
+
+	DefaultDevices = 2
+)
+
+// Handler simulates devices in memory, it allows to run master, node and test runner flows without real devices
+type Handler struct {
+	devices        map[string]*Device
+	deviceStorage  storage.Device
+	masterURL      string
+	nodeIdentifier string
+	authToken      *string
+	cfg            config.Fake
+}
+
+func NewHandler(cfg config.Manager, ds storage.Device) *Handler {
+	h := &Handler{devices: make(map[string]*Device), deviceStorage: ds}
+	if cfg.Fake != nil {
+		h.cfg = *cfg.Fake
+	}
+	if h.cfg.Devices <= 0 {
+		h.cfg.Devices = DefaultDevices
+	}
+	if len(h.cfg.Tests) == 0 {
+		h.cfg.Tests = defaultTests
+	}
+	return h
+}
+
+func (m *Handler) Name() string {
+	return Manager
+}
+
+func (m *Handler) Init(masterUrl, nodeIdentifier string, authToken *string) error {
+	m.masterURL = masterUrl
+	m.nodeIdentifier = nodeIdentifier
+	m.authToken = authToken
+
+	prefix := nodeIdentifier
+	if len(prefix) == 0 {
+		prefix, _ = os.Hostname()
+	}
+
+	for i := 0; i < m.cfg.Devices; i++ {
+		dev := newDevice(fmt.Sprintf("fake-%s-%d", prefix, i), fmt.Sprintf("Fake Device %d", i), m.cfg, authToken)
+		if len(masterUrl) > 0 {
+			dev.SetLogWriter(generic.NewRemoteLogWriter(masterUrl, nodeIdentifier, dev.DeviceID(), authToken))
+			dev.AddActionHandler(node.NewRemoteActionHandler(masterUrl, nodeIdentifier, dev.DeviceID(), authToken))
+		}
+		m.devices[dev.DeviceID()] = dev
+		m.deviceStorage.Update(m.Name(), dev)
+	}
+
+	return m.RefreshDevices(true)
+}
+
+func (m *Handler) Start() error {
+	return nil
+}
+
+func (m *Handler) Stop() error {
+	for _, d := range m.devices {
+		d.disconnectClient()
+	}
+	return nil
+}
+
+func (m *Handler) StartDevice(deviceID string) error {
+	dev, ok := m.devices[deviceID]
+	if !ok {
+		return device.DeviceNotFoundError
+	}
+	dev.deviceState = device.StateBooted
+	return nil
+}
+
+func (m *Handler) StopDevice(deviceID string) error {
+	dev, ok := m.devices[deviceID]
+	if !ok {
+		return device.DeviceNotFoundError
+	}
+	dev.disconnectClient()
+	dev.deviceState = device.StateShutdown
+	return nil
+}
+
+func (m *Handler) GetDevices() ([]device.Device, error) {
+	devices := make([]device.Device, 0, len(m.devices))
+	for _, d := range m.devices {
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+func (m *Handler) RefreshDevices(force bool) error {
+	for _, d := range m.devices {
+		m.deviceStorage.Update(m.Name(), d)
+	}
+	return nil
+}
+
+func (m *Handler) HasDevice(dev device.Device) bool {
+	for _, d := range m.devices {
+		if d == dev {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Handler) RegisterDevice(data device.RegisterData) (device.Device, error) {
+	return nil, fmt.Errorf("register device not implemented")
+}
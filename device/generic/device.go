@@ -7,6 +7,7 @@ import (
 	"github.com/fsuhrau/automationhub/hub/action"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/sirupsen/logrus"
+	"sync"
 )
 
 const (
@@ -14,15 +15,19 @@ const (
 )
 
 var (
-	DeviceLockedError   = fmt.Errorf("can't lock device, device is already locked")
-	DeviceUnlockedError = fmt.Errorf("can't unlock device, device was not locked")
+	DeviceLockedError      = fmt.Errorf("can't lock device, device is already locked")
+	DeviceUnlockedError    = fmt.Errorf("can't unlock device, device was not locked")
+	DeviceQuarantinedError = fmt.Errorf("can't lock device, device is quarantined")
 )
 
 type Device struct {
 	con           *device.Connection
+	conMtx        sync.RWMutex
 	writer        device.LogWriter
 	actionHandler []action.ActionHandler
 	locked        bool
+	quarantine    string
+	quarantineMtx sync.RWMutex
 	Config        *models.Device `json:"config"`
 	ctx           context.Context
 	cancel        context.CancelFunc
@@ -47,6 +52,8 @@ func (d *Device) SetConnection(connection *device.Connection) {
 	} else {
 		d.Log("device", "Device Disconnected")
 	}
+	d.conMtx.Lock()
+	defer d.conMtx.Unlock()
 	d.con = connection
 }
 
@@ -58,12 +65,15 @@ func (d *Device) Cancel() {
 	}
 }
 
+// the connection is set by the socket handler while the test runners wait for it
 func (d *Device) Connection() *device.Connection {
+	d.conMtx.RLock()
+	defer d.conMtx.RUnlock()
 	return d.con
 }
 
 func (d *Device) Send(data []byte) error {
-	if err := d.con.Send(data); err != nil {
+	if err := d.Connection().Send(data); err != nil {
 		return err
 	}
 	return nil
@@ -73,6 +83,9 @@ func (d *Device) Lock() error {
 	if d.locked {
 		return DeviceLockedError
 	}
+	if d.IsQuarantined() {
+		return DeviceQuarantinedError
+	}
 	d.locked = true
 	return nil
 }
@@ -90,6 +103,31 @@ func (d *Device) IsLocked() bool {
 	return d.locked
 }
 
+// the quarantine is changed by the health checks and the api while tests lock the device
+func (d *Device) Quarantine(reason string) {
+	d.quarantineMtx.Lock()
+	defer d.quarantineMtx.Unlock()
+	d.quarantine = reason
+}
+
+func (d *Device) ReleaseQuarantine() {
+	d.quarantineMtx.Lock()
+	defer d.quarantineMtx.Unlock()
+	d.quarantine = ""
+}
+
+func (d *Device) IsQuarantined() bool {
+	d.quarantineMtx.RLock()
+	defer d.quarantineMtx.RUnlock()
+	return len(d.quarantine) > 0
+}
+
+func (d *Device) QuarantineReason() string {
+	d.quarantineMtx.RLock()
+	defer d.quarantineMtx.RUnlock()
+	return d.quarantine
+}
+
 func (d *Device) SetLogWriter(writer device.LogWriter) {
 	d.writer = writer
 }
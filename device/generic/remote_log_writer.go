@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/device"
 	"net/http"
 	"time"
@@ -67,7 +68,7 @@ func (w *RemoteLogWriter) sendLog(log *request) error {
 		return fmt.Errorf("failed to marshal log data: %v", err)
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/node/log", w.masterURL), bytes.NewBuffer(logData))
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s://%s/node/log", mtls.HTTPScheme(), w.masterURL), bytes.NewBuffer(logData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %v", err)
 	}
@@ -76,7 +77,7 @@ func (w *RemoteLogWriter) sendLog(log *request) error {
 		req.Header.Set("X-Auth-Token", *w.authToken)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := mtls.HTTPClient(10 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send log data: %v", err)
@@ -0,0 +1,14 @@
+package device
+
+// HealthThresholds defines the limits a device has to meet to be considered healthy
+type HealthThresholds struct {
+	MinBatteryLevel       int
+	MaxBatteryTemperature float64
+	MinFreeStorageMB      int64
+}
+
+// HealthChecker is implemented by devices which can probe their hardware state,
+// CheckHealth returns an error describing the first problem found
+type HealthChecker interface {
+	CheckHealth(thresholds HealthThresholds) error
+}
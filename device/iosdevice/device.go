@@ -78,6 +78,9 @@ func (d *Device) DeviceIP() net.IP {
 }
 
 func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
 	return d.deviceState
 }
 
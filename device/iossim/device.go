@@ -63,6 +63,9 @@ func (d *Device) DeviceID() string {
 }
 
 func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
 	return d.deviceState
 }
 
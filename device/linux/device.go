@@ -0,0 +1,342 @@
+package linux
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/app"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/generic"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/fsuhrau/automationhub/tools/shell"
+	"github.com/sirupsen/logrus"
+	"image"
+	_ "image/png"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	AppInstallPath string
+)
+
+func init() {
+	AppInstallPath = filepath.Join(os.Getenv("HOME"), ".automationhub", "installs")
+	os.MkdirAll(AppInstallPath, os.ModePerm)
+}
+
+const (
+	ConnectionTimeout = 10 * time.Second
+	MetricsInterval   = 5 * time.Second
+
+	// RemoteInstallPath is relative to the home directory of the ssh user
+	RemoteInstallPath = ".automationhub/installs"
+)
+
+// deviceInfoScript prints the host properties as key=value lines
+const deviceInfoScript = `echo "id=$(cat /etc/machine-id 2>/dev/null)"
+echo "name=$(hostname)"
+. /etc/os-release 2>/dev/null
+echo "os=$NAME"
+echo "version=$VERSION_ID"
+echo "kernel=$(uname -r)"
+echo "cpu=$(grep -m1 'model name' /proc/cpuinfo | cut -d: -f2)"
+echo "ram=$(grep MemTotal /proc/meminfo | awk '{print $2}')"`
+
+type Device struct {
+	generic.Device
+	host            *host
+	display         string
+	installPath     string
+	deviceOSName    string
+	deviceOSVersion string
+	deviceName      string
+	deviceID        string
+	deviceState     device.State
+	deviceParameter map[string]string
+
+	mutex         sync.Mutex
+	app           *shell.Process
+	cancelMonitor context.CancelFunc
+}
+
+func newDevice(h *host, name, display string) *Device {
+	d := &Device{
+		host:            h,
+		display:         display,
+		deviceName:      name,
+		deviceState:     device.StateUnknown,
+		deviceParameter: make(map[string]string),
+		installPath:     AppInstallPath,
+	}
+	if !h.isLocal() {
+		d.installPath = RemoteInstallPath
+	}
+	return d
+}
+
+func (d *Device) DeviceParameter() map[string]string {
+	return d.deviceParameter
+}
+
+func (d *Device) DeviceType() int {
+	return int(models.DeviceTypeDesktop)
+}
+
+func (d *Device) PlatformType() int {
+	return int(models.PlatformTypeLinux)
+}
+
+func (d *Device) DeviceOSName() string {
+	return d.deviceOSName
+}
+
+func (d *Device) DeviceOSVersion() string {
+	return d.deviceOSVersion
+}
+
+func (d *Device) TargetVersion() string {
+	return ""
+}
+
+func (d *Device) DeviceName() string {
+	return d.deviceName
+}
+
+func (d *Device) DeviceID() string {
+	return d.deviceID
+}
+
+func (d *Device) DeviceIP() net.IP {
+	return nil
+}
+
+func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
+	return d.deviceState
+}
+
+func (d *Device) UpdateDeviceInfos() error {
+	out, err := shell.Run(d.host, deviceInfoScript)
+	if err != nil {
+		return err
+	}
+
+	infos := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			infos[key] = strings.TrimSpace(value)
+		}
+	}
+
+	if len(d.deviceName) == 0 {
+		d.deviceName = infos["name"]
+	}
+	d.deviceID = infos["id"]
+	if len(d.deviceID) == 0 {
+		d.deviceID = "linux-" + infos["name"]
+	}
+	d.deviceOSName = infos["os"]
+	if len(d.deviceOSName) == 0 {
+		d.deviceOSName = "Linux"
+	}
+	d.deviceOSVersion = infos["version"]
+
+	d.deviceParameter = make(map[string]string)
+	if len(infos["kernel"]) > 0 {
+		d.deviceParameter["Kernel"] = infos["kernel"]
+	}
+	if len(infos["cpu"]) > 0 {
+		d.deviceParameter["CPU"] = infos["cpu"]
+	}
+	if len(infos["ram"]) > 0 {
+		d.deviceParameter["RAM"] = infos["ram"] + " kB"
+	}
+	if len(d.display) > 0 {
+		d.deviceParameter["Display"] = d.display
+	}
+	if !d.host.isLocal() {
+		d.deviceParameter["Host"] = d.host.address
+	}
+	return nil
+}
+
+func (d *Device) appDir(params *app.Parameter) string {
+	return path.Join(d.installPath, params.App.Hash)
+}
+
+func (d *Device) executable(params *app.Parameter) (string, error) {
+	if params.App == nil || params.App.Executable == nil || len(params.App.Executable.Executable) == 0 {
+		return "", fmt.Errorf("app %s has no executable", params.Identifier)
+	}
+	return path.Join(d.appDir(params), params.Name, params.App.Executable.Executable), nil
+}
+
+func (d *Device) IsAppInstalled(params *app.Parameter) (bool, error) {
+	return shell.Test(d.host, "test -d "+shell.Quote(path.Join(d.appDir(params), params.Name)))
+}
+
+func (d *Device) InstallApp(params *app.Parameter) error {
+	executable, err := d.executable(params)
+	if err != nil {
+		return err
+	}
+	appDir := d.appDir(params)
+
+	if d.host.isLocal() {
+		if _, err := app.Unzip(params.App.AppPath, appDir); err != nil {
+			return err
+		}
+		return os.Chmod(executable, 0755)
+	}
+
+	archive := appDir + ".zip"
+	if _, err := shell.Run(d.host, "mkdir -p "+shell.Quote(d.installPath)); err != nil {
+		return err
+	}
+	if err := d.host.copy(params.App.AppPath, archive); err != nil {
+		return err
+	}
+	_, err = shell.Run(d.host, fmt.Sprintf("unzip -o -q %s -d %s && rm -f %s && chmod 755 %s", shell.Quote(archive), shell.Quote(appDir), shell.Quote(archive), shell.Quote(executable)))
+	return err
+}
+
+func (d *Device) UninstallApp(params *app.Parameter) error {
+	_, err := shell.Run(d.host, "rm -rf "+shell.Quote(d.appDir(params)))
+	return err
+}
+
+func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sessionId string, nodeUrl string) error {
+	executable, err := d.executable(appParams)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.stopApp()
+
+	env := make(map[string]string)
+	for key, value := range appParams.App.Executable.Environment {
+		env[key] = value
+	}
+	if len(d.display) > 0 {
+		env["DISPLAY"] = d.display
+	}
+
+	args := append([]string{"--sessionId=" + sessionId, "--nodeURL=" + nodeUrl, "--deviceId=" + d.deviceID}, appParams.App.Executable.Arguments...)
+
+	p, err := shell.Start(d.host, path.Join(d.appDir(appParams), appParams.Name), env, executable, args, func(line string) {
+		d.Log("stdout", "%s", line)
+	}, func(line string) {
+		d.Log("stderr", "%s", line)
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.app = p
+	d.cancelMonitor = cancel
+	go d.monitor(ctx, p)
+
+	return nil
+}
+
+// monitor reports the cpu and memory usage of the process tree until the app stops
+func (d *Device) monitor(ctx context.Context, p *shell.Process) {
+	ticker := time.NewTicker(MetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.Done():
+			d.Log("app", "process %d exited: %v", p.Pid(), p.Err())
+			return
+		case <-ticker.C:
+			cpu, mem, err := p.Usage()
+			if err != nil {
+				logrus.Debugf("linux device %s: %v", d.deviceID, err)
+				continue
+			}
+			d.LogPerformance("process", cpu, 0, mem, 0, 0, "")
+		}
+	}
+}
+
+func (d *Device) stopApp() error {
+	if d.cancelMonitor != nil {
+		d.cancelMonitor()
+		d.cancelMonitor = nil
+	}
+	if d.app == nil {
+		return nil
+	}
+	p := d.app
+	d.app = nil
+	return p.Stop()
+}
+
+func (d *Device) StopApp(params *app.Parameter) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.stopApp()
+}
+
+func (d *Device) IsAppConnected() bool {
+	return d.Connection() != nil
+}
+
+func (d *Device) StartRecording(path string) error {
+	return nil
+}
+
+func (d *Device) StopRecording() error {
+	return nil
+}
+
+func (d *Device) GetScreenshot() ([]byte, int, int, error) {
+	if len(d.display) == 0 {
+		return nil, 0, 0, fmt.Errorf("no display configured for %s", d.deviceName)
+	}
+
+	var stderr bytes.Buffer
+	cmd := d.host.Command("DISPLAY=" + shell.Quote(d.display) + " import -window root png:-")
+	cmd.Stderr = &stderr
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("screenshot failed: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return data, cfg.Width, cfg.Height, nil
+}
+
+func (d *Device) HasFeature(string) bool {
+	return false
+}
+
+func (d *Device) Execute(string) {
+
+}
+
+func (d *Device) ConnectionTimeout() time.Duration {
+	return ConnectionTimeout
+}
+
+func (d *Device) RunNativeScript(script []byte) {
+
+}
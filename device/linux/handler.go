@@ -0,0 +1,156 @@
+package linux
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/generic"
+	"github.com/fsuhrau/automationhub/hub/node"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/fsuhrau/automationhub/tools/shell"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	Manager = "linux"
+
+	DefaultDisplay     = ":0"
+	DefaultXvfbDisplay = ":99"
+	DefaultResolution  = "1920x1080x24"
+)
+
+// Handler registers the local linux host and the configured remote hosts as desktop devices
+type Handler struct {
+	devices       map[string]*Device
+	deviceStorage storage.Device
+	cfg           config.Linux
+	xvfb          []*shell.Process
+}
+
+func NewHandler(cfg config.Manager, ds storage.Device) *Handler {
+	h := &Handler{devices: make(map[string]*Device), deviceStorage: ds}
+	if cfg.Linux != nil {
+		h.cfg = *cfg.Linux
+	}
+	if len(h.cfg.Resolution) == 0 {
+		h.cfg.Resolution = DefaultResolution
+	}
+	if len(h.cfg.Hosts) == 0 {
+		h.cfg.Hosts = []config.LinuxHost{{Display: h.cfg.Display, Xvfb: h.cfg.Xvfb}}
+	}
+	return h
+}
+
+func (m *Handler) Name() string {
+	return Manager
+}
+
+func (m *Handler) Init(masterUrl, nodeIdentifier string, authToken *string) error {
+	for _, hc := range m.cfg.Hosts {
+		h := newHost(hc.Address)
+
+		display := hc.Display
+		if len(display) == 0 {
+			display = m.cfg.Display
+		}
+		if hc.Xvfb || m.cfg.Xvfb {
+			if len(display) == 0 {
+				display = DefaultXvfbDisplay
+			}
+			if err := m.startXvfb(h, display); err != nil {
+				logrus.Errorf("linux: unable to start Xvfb on %s: %v", h, err)
+			}
+		}
+		if len(display) == 0 {
+			display = DefaultDisplay
+		}
+
+		dev := newDevice(h, hc.Name, display)
+		if err := dev.UpdateDeviceInfos(); err != nil {
+			logrus.Errorf("linux: unable to reach %s: %v", h, err)
+			continue
+		}
+		dev.deviceState = device.StateBooted
+		if len(masterUrl) > 0 {
+			dev.SetLogWriter(generic.NewRemoteLogWriter(masterUrl, nodeIdentifier, dev.deviceID, authToken))
+			dev.AddActionHandler(node.NewRemoteActionHandler(masterUrl, nodeIdentifier, dev.deviceID, authToken))
+		}
+		m.devices[dev.deviceID] = dev
+		m.deviceStorage.Update(m.Name(), dev)
+	}
+
+	return m.RefreshDevices(true)
+}
+
+func (m *Handler) startXvfb(h *host, display string) error {
+	p, err := shell.Start(h, "", nil, "Xvfb", []string{display, "-screen", "0", m.cfg.Resolution, "-nolisten", "tcp"}, nil, func(line string) {
+		logrus.Debugf("Xvfb %s: %s", h, line)
+	})
+	if err != nil {
+		return err
+	}
+	m.xvfb = append(m.xvfb, p)
+	return nil
+}
+
+func (m *Handler) Start() error {
+	return nil
+}
+
+func (m *Handler) Stop() error {
+	for _, d := range m.devices {
+		d.StopApp(nil)
+	}
+	for _, p := range m.xvfb {
+		p.Stop()
+	}
+	m.xvfb = nil
+	return nil
+}
+
+func (m *Handler) StartDevice(deviceID string) error {
+	return nil
+}
+
+func (m *Handler) StopDevice(deviceID string) error {
+	dev, ok := m.devices[deviceID]
+	if !ok {
+		return device.DeviceNotFoundError
+	}
+	return dev.StopApp(nil)
+}
+
+func (m *Handler) GetDevices() ([]device.Device, error) {
+	devices := make([]device.Device, 0, len(m.devices))
+	for _, d := range m.devices {
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+func (m *Handler) RefreshDevices(force bool) error {
+	for _, d := range m.devices {
+		state := device.StateBooted
+		if ok, _ := shell.Test(d.host, "true"); !ok {
+			state = device.StateShutdown
+		}
+		if force || state != d.deviceState {
+			d.deviceState = state
+			m.deviceStorage.Update(m.Name(), d)
+		}
+	}
+	return nil
+}
+
+func (m *Handler) HasDevice(dev device.Device) bool {
+	for _, d := range m.devices {
+		if d == dev {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Handler) RegisterDevice(data device.RegisterData) (device.Device, error) {
+	return nil, fmt.Errorf("register device not implemented")
+}
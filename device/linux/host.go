@@ -0,0 +1,34 @@
+package linux
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/tools/shell"
+	"os/exec"
+	"strings"
+)
+
+// host is the local machine or a remote one reachable via ssh
+type host struct {
+	shell.Shell
+	address string
+}
+
+func newHost(address string) *host {
+	if len(address) == 0 {
+		return &host{Shell: shell.Local{}}
+	}
+	return &host{Shell: shell.SSH{Address: address}, address: address}
+}
+
+func (h *host) isLocal() bool {
+	return len(h.address) == 0
+}
+
+// copy transfers a local file to the remote host
+func (h *host) copy(src, dst string) error {
+	out, err := exec.Command("scp", "-q", "-o", "BatchMode=yes", src, h.address+":"+dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: copy %s failed: %v %s", h, src, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -82,6 +82,9 @@ func (d *Device) DeviceIP() net.IP {
 }
 
 func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
 	return d.deviceState
 }
 
@@ -160,7 +163,12 @@ func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sess
 	appDir := filepath.Join(AppInstallPath, appParams.App.Hash, appParams.Name)
 	executable := filepath.Join(appDir, appParams.App.Executable.Executable)
 	d.runningExecutable = filepath.Base(executable)
-	cmd := exec2.NewCommand(executable, "--sessionId="+sessionId, "NODE_URL", "--nodeURL="+nodeUrl, "--deviceId="+d.deviceID)
+	args := append([]string{"--sessionId=" + sessionId, "NODE_URL", "--nodeURL=" + nodeUrl, "--deviceId=" + d.deviceID}, appParams.App.Executable.Arguments...)
+	cmd := exec2.NewCommand(executable, args...)
+	cmd.Env = os.Environ()
+	for key, value := range appParams.App.Executable.Environment {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
 	if err := cmd.Start(); err != nil {
 		return err
 	}
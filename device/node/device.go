@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"github.com/fsuhrau/automationhub/app"
 	"github.com/fsuhrau/automationhub/device"
 	"github.com/fsuhrau/automationhub/device/generic"
@@ -23,6 +24,7 @@ type NodeDevice struct {
 	targetVersion    string
 	platformType     models.PlatformType
 	deviceState      device.State
+	quarantineReason string
 	recordingSession *exec.Cmd
 	lastUpdateAt     time.Time
 
@@ -72,9 +74,20 @@ func (d *NodeDevice) DeviceID() string {
 }
 
 func (d *NodeDevice) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
 	return d.deviceState
 }
 
+func (d *NodeDevice) QuarantineReason() string {
+	// quarantined by the master or by the node itself
+	if d.IsQuarantined() {
+		return d.Device.QuarantineReason()
+	}
+	return d.quarantineReason
+}
+
 func (d *NodeDevice) SetDeviceState(state string) {
 	switch state {
 	case "StateBooted":
@@ -143,6 +156,23 @@ func (d *NodeDevice) StopApp(params *app.Parameter) error {
 	return d.nodeManager.StopApp(d.nodeId, d.deviceID, params)
 }
 
+func (d *NodeDevice) ForceStopApp(ctx context.Context, params *app.Parameter) error {
+	return d.nodeManager.RecoverDevice(ctx, d.nodeId, d.deviceID, device.RecoveryForceStop, params)
+}
+
+func (d *NodeDevice) ClearAppData(ctx context.Context, params *app.Parameter) error {
+	return d.nodeManager.RecoverDevice(ctx, d.nodeId, d.deviceID, device.RecoveryClearData, params)
+}
+
+func (d *NodeDevice) Reconnect(ctx context.Context) error {
+	return d.nodeManager.RecoverDevice(ctx, d.nodeId, d.deviceID, device.RecoveryReconnect, nil)
+}
+
+// Reboot reboots and restarts the device on the node
+func (d *NodeDevice) Reboot(ctx context.Context) error {
+	return d.nodeManager.RecoverDevice(ctx, d.nodeId, d.deviceID, device.RecoveryReboot, nil)
+}
+
 func (d *NodeDevice) IsAppConnected() bool {
 	// return d.Connection() != nil && d.Connection().Connection != nil
 	return d.nodeManager.IsConnected(d.nodeId, d.deviceID)
@@ -204,6 +204,7 @@ func (h *Handler) RefreshDevices(force bool) error {
 					}
 					nd.UpdateDeviceInfos(d.DeviceParameter())
 					nd.deviceState = d.DeviceState()
+					nd.quarantineReason = d.QuarantineReason()
 					dev := models.Device{
 						NodeID:           no.ID,
 						DeviceIdentifier: deviceID,
@@ -228,6 +229,7 @@ func (h *Handler) RefreshDevices(force bool) error {
 					nd.deviceID = deviceID
 					nd.lastUpdateAt = lastUpdate
 					nd.deviceState = d.DeviceState()
+					nd.quarantineReason = d.QuarantineReason()
 					nd.platformType = models.PlatformType(d.PlatformType())
 					nd.UpdateDeviceInfos(d.DeviceParameter())
 					if nd.GetConfig() == nil {
@@ -0,0 +1,56 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/app"
+	"time"
+)
+
+type RecoveryStep string
+
+const (
+	RecoveryForceStop  RecoveryStep = "force_stop"
+	RecoveryClearData  RecoveryStep = "clear_data"
+	RecoveryReconnect  RecoveryStep = "reconnect"
+	RecoveryReboot     RecoveryStep = "reboot"
+	RecoveryQuarantine RecoveryStep = "quarantine"
+)
+
+// DefaultRecoveryTimeout limits the duration of a single recovery step
+const DefaultRecoveryTimeout = 3 * time.Minute
+
+var DefaultRecoverySteps = []RecoveryStep{
+	RecoveryForceStop,
+	RecoveryClearData,
+	RecoveryReconnect,
+	RecoveryReboot,
+	RecoveryQuarantine,
+}
+
+// ParseRecoverySteps validates the configured steps, no steps configured returns the default steps
+func ParseRecoverySteps(names []string) ([]RecoveryStep, error) {
+	if len(names) == 0 {
+		return DefaultRecoverySteps, nil
+	}
+	var steps []RecoveryStep
+	for _, name := range names {
+		step := RecoveryStep(name)
+		switch step {
+		case RecoveryForceStop, RecoveryClearData, RecoveryReconnect, RecoveryReboot, RecoveryQuarantine:
+			steps = append(steps, step)
+		default:
+			return nil, fmt.Errorf("unknown recovery step: %s", name)
+		}
+	}
+	return steps, nil
+}
+
+// Recoverable is implemented by devices supporting fine grained recovery actions,
+// the actions have to return when the context is done
+type Recoverable interface {
+	ForceStopApp(context.Context, *app.Parameter) error
+	ClearAppData(context.Context, *app.Parameter) error
+	Reconnect(context.Context) error
+	Reboot(context.Context) error
+}
@@ -0,0 +1,22 @@
+package device
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRecoverySteps(t *testing.T) {
+	steps, err := ParseRecoverySteps(nil)
+	if err != nil || !reflect.DeepEqual(steps, DefaultRecoverySteps) {
+		t.Errorf("expected default steps got %v %v", steps, err)
+	}
+
+	steps, err = ParseRecoverySteps([]string{"force_stop", "reboot", "quarantine"})
+	if err != nil || !reflect.DeepEqual(steps, []RecoveryStep{RecoveryForceStop, RecoveryReboot, RecoveryQuarantine}) {
+		t.Errorf("unexpected steps %v %v", steps, err)
+	}
+
+	if _, err := ParseRecoverySteps([]string{"force_stop", "restart"}); err == nil {
+		t.Errorf("expected error for unknown step")
+	}
+}
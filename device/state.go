@@ -10,6 +10,7 @@ const (
 	StateBooted
 	StateLocked
 	StateNodeDisconnected
+	StateQuarantined
 )
 
 func StateToString(state State) string {
@@ -26,6 +27,8 @@ func StateToString(state State) string {
 		return "locked"
 	case StateNodeDisconnected:
 		return "node disconnected"
+	case StateQuarantined:
+		return "quarantined"
 	}
 	return ""
 }
@@ -0,0 +1,233 @@
+package unityeditor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/fsuhrau/automationhub/hub/action"
+	exec2 "github.com/fsuhrau/automationhub/tools/exec"
+	"github.com/fsuhrau/automationhub/tools/testresults"
+	"io"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// BatchmodeFeature is supported by editor devices which run their tests with -batchmode -runTests
+	BatchmodeFeature = "batchmode"
+
+	DefaultBatchmodeTimeout = 60 * time.Minute
+
+	TestPlatformEditMode = "EditMode"
+	TestPlatformPlayMode = "PlayMode"
+)
+
+// batchmode runs the tests of a project with the unity test runner instead of waiting for an editor to connect,
+// the results are reported as action responses like a connected app would do
+type batchmode struct {
+	editorPath  string
+	buildTarget string
+	timeout     time.Duration
+
+	mutex     sync.Mutex
+	sessionID string
+	process   *exec.Cmd
+}
+
+func (b *batchmode) setSession(sessionID string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.sessionID = sessionID
+}
+
+func (b *batchmode) connected() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return len(b.sessionID) > 0
+}
+
+func (b *batchmode) setProcess(cmd *exec.Cmd) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.process = cmd
+}
+
+// kill stops a running test run
+func (b *batchmode) kill() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.process != nil && b.process.Process != nil {
+		_ = b.process.Process.Kill()
+	}
+}
+
+func (d *Device) respond(actionType action.ActionType, actionID string, success bool, payload action.ResponseData) {
+	resp := &action.Response{
+		ActionID:   actionID,
+		ActionType: actionType,
+		Success:    success,
+		Payload:    payload,
+	}
+	for _, handler := range d.ActionHandlers() {
+		handler.OnActionResponse(d, resp)
+	}
+}
+
+func (d *Device) handleBatchmodeRequest(data []byte) error {
+	var req action.Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+
+	switch req.ActionType {
+	case action.ActionType_ExecuteTest:
+		if req.Payload.Test == nil {
+			return fmt.Errorf("test missing in request")
+		}
+		go d.runBatchmode(req.ActionID, *req.Payload.Test)
+	default:
+		// the unity test runner can't list tests or interact with a running game
+		go d.respond(req.ActionType, req.ActionID, false, action.ResponseData{})
+	}
+	return nil
+}
+
+// testFilter converts the requested test into a filter of the unity test runner, a method without class
+// is passed as it is so a semicolon separated list of full test names can be requested
+func testFilter(test action.Test) string {
+	switch {
+	case len(test.Class) > 0 && len(test.Method) > 0:
+		return test.Class + "." + test.Method
+	case len(test.Class) > 0:
+		return test.Class
+	default:
+		return test.Method
+	}
+}
+
+func (d *Device) batchmodeArgs(test action.Test, resultsFile string) []string {
+	platform := test.Platform
+	if len(platform) == 0 {
+		platform = TestPlatformEditMode
+	}
+	args := []string{
+		"-batchmode",
+		"-projectPath", d.projectDir,
+		"-runTests",
+		"-testPlatform", platform,
+		"-testResults", resultsFile,
+		"-logFile", "-",
+	}
+	// play mode tests need a graphics device, edit mode tests run without one on headless machines
+	if platform == TestPlatformEditMode {
+		args = append(args, "-nographics")
+	}
+	if len(d.batch.buildTarget) > 0 {
+		args = append(args, "-buildTarget", d.batch.buildTarget)
+	}
+	if len(test.Categories) > 0 {
+		args = append(args, "-testCategory", strings.Join(test.Categories, ";"))
+	}
+	if filter := testFilter(test); len(filter) > 0 {
+		args = append(args, "-testFilter", filter)
+	}
+	return args
+}
+
+func (d *Device) runBatchmode(actionID string, test action.Test) {
+	resultsFile := filepath.Join(os.TempDir(), fmt.Sprintf("automation_hub_unity_%d.xml", rand.Int()))
+	defer os.Remove(resultsFile)
+
+	d.logMu.Lock()
+	d.compileErrors = nil
+	d.logMu.Unlock()
+
+	args := d.batchmodeArgs(test, resultsFile)
+	cmd := exec2.NewCommand(d.batch.editorPath, args...)
+	cmd.Env = os.Environ()
+	for key, value := range test.Parameter {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	d.Log("testrunner", "Run unity %s", strings.Join(args, " "))
+	if err := cmd.Start(); err != nil {
+		d.Error("testrunner", "unable to start unity: %v", err)
+		d.respond(action.ActionType_ExecuteTest, actionID, false, action.ResponseData{})
+		return
+	}
+	d.batch.setProcess(cmd)
+
+	d.respond(action.ActionType_ExecuteTest, actionID, true, action.ResponseData{TestDetails: &action.TestDetails{
+		Timeout:    d.batch.timeout.Milliseconds(),
+		Categories: test.Categories,
+	}})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		parser := NewLogParser(d.handleLogEntry)
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+		for scanner.Scan() {
+			parser.Feed(scanner.Text())
+		}
+		parser.Flush()
+		// keep draining so the editor never blocks on a full pipe
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	exitErr := cmd.Wait()
+	_ = writer.Close()
+	<-done
+	d.batch.setProcess(nil)
+
+	report, err := testresults.ParseNUnitFile(resultsFile)
+	if err != nil {
+		if compileErr := d.CompileError(); compileErr != nil {
+			d.Error("compile", "%v", compileErr)
+		} else {
+			d.Error("testrunner", "no test results, unity exited with %v: %v", exitErr, err)
+		}
+		d.respond(action.ActionType_ExecutionResult, "", false, action.ResponseData{})
+		return
+	}
+	d.reportTestResults(report)
+}
+
+// reportTestResults replays the test cases of the results file so every test gets its own protocol
+func (d *Device) reportTestResults(report *testresults.Report) {
+	for _, c := range report.Cases {
+		details := &action.TestDetails{
+			Test:       c.ClassName + "." + c.Name,
+			Timeout:    time.Minute.Milliseconds(),
+			Categories: c.Categories,
+		}
+		d.respond(action.ActionType_ExecuteMethodStart, "", true, action.ResponseData{TestDetails: details})
+		if len(c.Output) > 0 {
+			d.Log("test", "%s", c.Output)
+		}
+		switch c.Result {
+		case testresults.ResultFailed:
+			if len(c.StackTrace) > 0 {
+				d.Error("test", "%s\n%s", c.Message, c.StackTrace)
+			} else {
+				d.Error("test", "%s", c.Message)
+			}
+		case testresults.ResultSkipped, testresults.ResultInconclusive:
+			d.Log("test", "%s: %s", strings.ToLower(c.Result), c.Message)
+		}
+		d.Log("test", "%s %s in %s", details.Test, c.Result, c.Duration)
+		d.respond(action.ActionType_ExecuteMethodFinished, "", c.Passed(), action.ResponseData{TestDetails: details})
+	}
+
+	d.Log("testrunner", "%d tests, %d passed, %d failed, %d skipped in %s", len(report.Cases), report.Count(testresults.ResultPassed), report.Count(testresults.ResultFailed), report.Count(testresults.ResultSkipped), report.Duration)
+	d.respond(action.ActionType_ExecutionResult, "", report.Passed(), action.ResponseData{})
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/fsuhrau/automationhub/device/generic"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/gorilla/websocket"
@@ -12,6 +13,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"sync"
 	"time"
 
 	"github.com/fsuhrau/automationhub/app"
@@ -46,6 +48,13 @@ type Device struct {
 	process         *exec.Cmd
 	instanceLogFile string
 	deviceParameter map[string]string
+
+	logMu         sync.Mutex
+	compileErrors []LogEntry
+
+	// projectDir and batch are set for projects configured to run their tests in batchmode
+	projectDir string
+	batch      *batchmode
 }
 
 func (d *Device) DeviceType() int {
@@ -85,6 +94,9 @@ func (d *Device) DeviceIP() net.IP {
 }
 
 func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
 	return d.deviceState
 }
 
@@ -121,6 +133,10 @@ func (d *Device) getHTTPClient() *http.Client {
 }
 
 func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sessionId string, nodeUrl string) error {
+	if d.batch != nil {
+		d.batch.setSession(sessionId)
+		return nil
+	}
 
 	type request struct {
 		Action    string
@@ -138,6 +154,11 @@ func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sess
 }
 
 func (d *Device) StopApp(_ *app.Parameter) error {
+	if d.batch != nil {
+		d.batch.setSession("")
+		d.batch.kill()
+		return nil
+	}
 	type request struct {
 		Action string
 	}
@@ -150,9 +171,19 @@ func (d *Device) StopApp(_ *app.Parameter) error {
 }
 
 func (d *Device) IsAppConnected() bool {
+	if d.batch != nil {
+		return d.batch.connected()
+	}
 	return d.Connection() != nil
 }
 
+func (d *Device) Send(data []byte) error {
+	if d.batch != nil {
+		return d.handleBatchmodeRequest(data)
+	}
+	return d.Device.Send(data)
+}
+
 func (d *Device) StartRecording(path string) error {
 	return nil
 }
@@ -166,8 +197,8 @@ func (d *Device) GetScreenshot() ([]byte, int, int, error) {
 	return nil, 0, 0, nil
 }
 
-func (d *Device) HasFeature(string) bool {
-	return false
+func (d *Device) HasFeature(feature string) bool {
+	return d.batch != nil && feature == BatchmodeFeature
 }
 
 func (d *Device) Execute(string) {
@@ -222,6 +253,9 @@ func (d *Device) HandleManagerConnection() {
 }
 
 func (d *Device) UnityLogStartListening() {
+	d.logMu.Lock()
+	d.compileErrors = nil
+	d.logMu.Unlock()
 	d.ctx, d.cancel = context.WithCancel(context.Background())
 	go d.processUnityLog()
 }
@@ -237,7 +271,11 @@ func (d *Device) processUnityLog() {
 		file.Close()
 	}()
 
+	parser := NewLogParser(d.handleLogEntry)
+	defer parser.Flush()
+
 	reader := bufio.NewReader(file)
+	var partial string
 	for {
 		select {
 		case <-d.ctx.Done():
@@ -247,14 +285,49 @@ func (d *Device) processUnityLog() {
 			if err != nil && err != io.EOF {
 				return
 			}
-			if len(line) == 0 {
-				time.Sleep(100 * time.Millisecond)
+			partial += line
+			if err == io.EOF {
+				// the editor is still writing, entries without a trailing empty line are complete once the log is idle
+				if len(line) == 0 {
+					parser.Flush()
+					time.Sleep(100 * time.Millisecond)
+				}
 				continue
 			}
+			parser.Feed(partial)
+			partial = ""
 		}
 	}
 }
 
+// handleLogEntry attaches a parsed log entry to the active test protocol
+func (d *Device) handleLogEntry(entry LogEntry) {
+	switch entry.Level {
+	case LogLevelCompileError:
+		d.logMu.Lock()
+		d.compileErrors = append(d.compileErrors, entry)
+		d.logMu.Unlock()
+		d.Error("compile", "%s(%d): %s %s", entry.File, entry.Line, entry.Code, entry.Message)
+	case LogLevelError, LogLevelException:
+		d.Error("unity", "%s", entry.String())
+	case LogLevelWarning:
+		d.Log("unity", "warning: %s", entry.String())
+	default:
+		d.Log("unity", "%s", entry.String())
+	}
+}
+
+// CompileError returns an error describing the compile errors reported since the editor started
+func (d *Device) CompileError() error {
+	d.logMu.Lock()
+	defer d.logMu.Unlock()
+	if len(d.compileErrors) == 0 {
+		return nil
+	}
+	first := d.compileErrors[0]
+	return fmt.Errorf("project has %d compile error(s), first: %s(%d): %s %s", len(d.compileErrors), first.File, first.Line, first.Code, first.Message)
+}
+
 func (d *Device) UnityLogStopListening() {
 	if d.cancel != nil {
 		d.cancel()
@@ -10,6 +10,7 @@ import (
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/fsuhrau/automationhub/tools/exec"
 	sync2 "github.com/fsuhrau/automationhub/utils/sync"
+	"github.com/sirupsen/logrus"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -28,6 +29,8 @@ const (
 
 var (
 	ErrorGetProjectVersion = fmt.Errorf("Could not determinate project project / unity version")
+
+	invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
 )
 
 type Handler struct {
@@ -73,12 +76,113 @@ func (m *Handler) Init(masterUrl, nodeIdentifier string, authToken *string) erro
 		m.mu.Unlock()
 	}
 
+	if m.managerCfg.UnityBatchmode != nil {
+		m.initBatchmode(*m.managerCfg.UnityBatchmode, masterUrl, nodeIdentifier, authToken)
+	}
+
 	if err := m.RefreshDevices(true); err != nil {
 		return err
 	}
 	return nil
 }
 
+// initBatchmode registers a device for every configured project, these devices run their tests
+// with the unity test runner and don't need an editor connecting back
+func (m *Handler) initBatchmode(cfg config.UnityBatchmode, masterUrl, nodeIdentifier string, authToken *string) {
+	prefix := nodeIdentifier
+	if len(prefix) == 0 {
+		prefix, _ = os.Hostname()
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultBatchmodeTimeout
+	}
+
+	for _, project := range cfg.Projects {
+		name := project.Name
+		if len(name) == 0 {
+			name = filepath.Base(project.Path)
+		}
+		projectVersion, err := GetUsedUnityVersion(project.Path)
+		if err != nil {
+			logrus.Errorf("unity editor: project %s: %v", project.Path, err)
+			continue
+		}
+
+		deviceID := invalidNameChars.ReplaceAllString(fmt.Sprintf("unity-%s-%s", prefix, name), "-")
+		deviceName := fmt.Sprintf("Unity (%s) @ %s", projectVersion, name)
+
+		m.mu.Lock()
+		dev, ok := m.devices[deviceID]
+		if !ok {
+			dev = &Device{deviceID: deviceID}
+			m.devices[deviceID] = dev
+		}
+		dev.deviceName = deviceName
+		dev.deviceOSName = runtime.GOOS
+		dev.unityVersion = projectVersion
+		dev.deviceState = device.StateShutdown
+		dev.projectDir = project.Path
+		dev.deviceParameter = map[string]string{"projectDir": project.Path}
+		dev.batch = &batchmode{
+			editorPath:  GetUnityEditorPath(m.managerCfg.UnityPath, projectVersion),
+			buildTarget: m.managerCfg.UnityBuildTarget,
+			timeout:     timeout,
+		}
+		m.mu.Unlock()
+
+		if !ok {
+			dev.SetLogWriter(generic.NewRemoteLogWriter(masterUrl, nodeIdentifier, deviceID, authToken))
+			dev.AddActionHandler(node.NewRemoteActionHandler(masterUrl, nodeIdentifier, deviceID, authToken))
+		}
+
+		if _, err := m.deviceStorage.GetDevice(Manager, deviceID); err != nil {
+			model := models.Device{
+				DeviceIdentifier: deviceID,
+				DeviceType:       models.DeviceTypeUnityEditor,
+				Name:             deviceName,
+				Manager:          Manager,
+				OS:               runtime.GOOS,
+				TargetVersion:    projectVersion,
+				ConnectionParameter: &models.ConnectionParameter{
+					ConnectionType: models.ConnectionTypeUSB,
+				},
+				CustomParameter: []models.CustomParameter{
+					{
+						Key:   "projectDir",
+						Value: project.Path,
+					},
+				},
+			}
+			dev.SetConfig(&model)
+			if err := m.deviceStorage.NewDevice(m.Name(), model); err != nil {
+				logrus.Errorf("unity editor: unable to store device %s: %v", deviceID, err)
+			}
+		}
+		dev.updated = true
+	}
+}
+
+// startBatchmode checks that the editor of the project is installed, the device is ready as soon as
+// a test run can be started
+func (m *Handler) startBatchmode(dev *Device) error {
+	if len(dev.batch.editorPath) == 0 {
+		return fmt.Errorf("unity path not configured")
+	}
+	if _, err := os.Stat(dev.batch.editorPath); err != nil {
+		return fmt.Errorf("unity %s not installed: %v", dev.unityVersion, err)
+	}
+	if _, err := GetUsedUnityVersion(dev.projectDir); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	dev.deviceState = device.StateBooted
+	dev.lastUpdateAt = time.Now().UTC()
+	m.mu.Unlock()
+	return m.deviceStorage.Update(m.Name(), dev)
+}
+
 func (m *Handler) Start() error {
 	return nil
 }
@@ -98,6 +202,10 @@ func (m *Handler) StartDevice(deviceID string) error {
 	dev, ok := m.devices[deviceID]
 	m.mu.Unlock()
 
+	if ok && dev.batch != nil {
+		return m.startBatchmode(dev)
+	}
+
 	if ok {
 		config := dev.GetConfig()
 		var projectDir string
@@ -152,6 +260,10 @@ func (m *Handler) StartDevice(deviceID string) error {
 				case <-ctx.Done():
 					return
 				case <-timer.C:
+					if err := dev.CompileError(); err != nil {
+						functionError = err
+						return
+					}
 					if _, err := os.Stat(instanceFile); os.IsNotExist(err) {
 						if time.Now().After(waitUntil) {
 							functionError = fmt.Errorf("device didn't start in time")
@@ -190,6 +302,10 @@ func (m *Handler) StartDevice(deviceID string) error {
 		err = wg.WaitUntil(waitUntil)
 
 		if functionError != nil {
+			if dev.CompileError() != nil {
+				dev.Error("compile", "%v", functionError)
+				m.StopDevice(deviceID)
+			}
 			return functionError
 		}
 
@@ -200,8 +316,13 @@ func (m *Handler) StartDevice(deviceID string) error {
 }
 
 func GetUnityEditorPath(editorPath string, projectVersion string) string {
-	if runtime.GOOS == "darwin" {
+	switch runtime.GOOS {
+	case "darwin":
 		return filepath.Join(editorPath, projectVersion, "Unity.app/Contents/MacOS/Unity")
+	case "linux":
+		return filepath.Join(editorPath, projectVersion, "Editor/Unity")
+	case "windows":
+		return filepath.Join(editorPath, projectVersion, "Editor/Unity.exe")
 	}
 	return ""
 }
@@ -228,6 +349,13 @@ func (m *Handler) StopDevice(deviceID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if dev, ok := m.devices[deviceID]; ok {
+		if dev.batch != nil {
+			dev.batch.setSession("")
+			dev.batch.kill()
+			dev.deviceState = device.StateShutdown
+			dev.updated = true
+			return nil
+		}
 		if dev.process != nil && dev.process.Process != nil {
 			if err := dev.process.Process.Kill(); err != nil {
 				return err
@@ -256,7 +384,8 @@ func (m *Handler) RefreshDevices(force bool) error {
 	defer m.mu.Unlock()
 
 	for i := range m.devices {
-		if now.Sub(m.devices[i].lastUpdateAt) > 1*time.Minute {
+		// batchmode devices have no editor connection which keeps them alive
+		if m.devices[i].batch == nil && now.Sub(m.devices[i].lastUpdateAt) > 1*time.Minute {
 			if m.devices[i].deviceState != device.StateShutdown {
 				m.devices[i].deviceState = device.StateShutdown
 				m.devices[i].updated = true
@@ -0,0 +1,129 @@
+package unityeditor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type LogLevel string
+
+const (
+	LogLevelInfo         LogLevel = "info"
+	LogLevelWarning      LogLevel = "warning"
+	LogLevelError        LogLevel = "error"
+	LogLevelException    LogLevel = "exception"
+	LogLevelCompileError LogLevel = "compile_error"
+)
+
+var (
+	compileMessageRegex = regexp.MustCompile(`^(.+\.cs)\((\d+),(\d+)\):\s*(error|warning)\s+(\w+):\s*(.*)$`)
+	exceptionRegex      = regexp.MustCompile(`^[A-Za-z_][\w.]*Exception\b(:.*)?$`)
+	errorRegex          = regexp.MustCompile(`(?i)^(error|fatal|assertion failed)\b`)
+	warningRegex        = regexp.MustCompile(`(?i)^warning\b`)
+	stackTraceRegexes   = []*regexp.Regexp{
+		regexp.MustCompile(`^\s+at\s`),
+		regexp.MustCompile(`^[\w.<>$+\[\],/` + "`" + `]+:[\w.<>$` + "`" + `]+\s?\(.*\)`),
+		regexp.MustCompile(`^\(Filename: .* Line: -?\d+\)$`),
+		regexp.MustCompile(`^Rethrow as \w+`),
+	}
+	debugLevels = map[string]LogLevel{
+		"UnityEngine.Debug:LogError":     LogLevelError,
+		"UnityEngine.Debug:LogAssertion": LogLevelError,
+		"UnityEngine.Debug:LogException": LogLevelException,
+		"UnityEngine.Debug:LogWarning":   LogLevelWarning,
+	}
+)
+
+// LogEntry is a message of the unity log including its stack trace
+type LogEntry struct {
+	Level      LogLevel
+	Message    string
+	File       string
+	Line       int
+	Code       string
+	StackTrace []string
+}
+
+func (e LogEntry) String() string {
+	if len(e.StackTrace) == 0 {
+		return e.Message
+	}
+	return e.Message + "\n" + strings.Join(e.StackTrace, "\n")
+}
+
+// LogParser groups the lines of a unity log into entries, an entry ends with an empty line
+// or the next line which is not part of a stack trace
+type LogParser struct {
+	current *LogEntry
+	handler func(LogEntry)
+}
+
+func NewLogParser(handler func(LogEntry)) *LogParser {
+	return &LogParser{handler: handler}
+}
+
+func isStackTrace(line string) bool {
+	for _, re := range stackTraceRegexes {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// Feed parses the next line of the log
+func (p *LogParser) Feed(line string) {
+	line = strings.TrimRight(line, "\r\n")
+	if len(strings.TrimSpace(line)) == 0 {
+		p.Flush()
+		return
+	}
+
+	if match := compileMessageRegex.FindStringSubmatch(line); match != nil {
+		p.Flush()
+		entry := LogEntry{
+			Level:   LogLevelCompileError,
+			Message: match[6],
+			File:    match[1],
+			Code:    match[5],
+		}
+		entry.Line, _ = strconv.Atoi(match[2])
+		if match[4] == "warning" {
+			entry.Level = LogLevelWarning
+		}
+		p.handler(entry)
+		return
+	}
+
+	if p.current != nil && isStackTrace(line) {
+		p.current.StackTrace = append(p.current.StackTrace, strings.TrimSpace(line))
+		for marker, level := range debugLevels {
+			if strings.HasPrefix(strings.TrimSpace(line), marker) {
+				p.current.Level = level
+			}
+		}
+		return
+	}
+
+	p.Flush()
+	entry := &LogEntry{Level: LogLevelInfo, Message: line}
+	switch {
+	case exceptionRegex.MatchString(line):
+		entry.Level = LogLevelException
+	case errorRegex.MatchString(line):
+		entry.Level = LogLevelError
+	case warningRegex.MatchString(line):
+		entry.Level = LogLevelWarning
+	}
+	p.current = entry
+}
+
+// Flush emits the pending entry
+func (p *LogParser) Flush() {
+	if p.current != nil {
+		entry := *p.current
+		p.current = nil
+		p.handler(entry)
+	}
+}
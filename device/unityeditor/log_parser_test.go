@@ -0,0 +1,50 @@
+package unityeditor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLogParser(t *testing.T) {
+	log := `Refreshing native plugins compatible for Editor in 2.10 ms, found 3 plugins.
+Assets/Scripts/Player.cs(12,5): error CS0246: The type or namespace name 'Foo' could not be found
+Assets/Scripts/Enemy.cs(3,1): warning CS0168: The variable 'e' is declared but never used
+Player health below zero
+UnityEngine.Debug:LogError (object)
+Player:Update () (at Assets/Scripts/Player.cs:42)
+
+NullReferenceException: Object reference not set to an instance of an object
+  at Game.Player.Update () [0x00000] in <abc>:0
+(Filename: Assets/Scripts/Player.cs Line: 42)
+
+Loading scene Main`
+
+	var entries []LogEntry
+	parser := NewLogParser(func(e LogEntry) {
+		entries = append(entries, e)
+	})
+	for _, line := range strings.Split(log, "\n") {
+		parser.Feed(line + "\n")
+	}
+	parser.Flush()
+
+	expected := []LogLevel{LogLevelInfo, LogLevelCompileError, LogLevelWarning, LogLevelError, LogLevelException, LogLevelInfo}
+	if len(entries) != len(expected) {
+		t.Fatalf("entries mismatch expected %d got %d: %v", len(expected), len(entries), entries)
+	}
+	for i := range expected {
+		if entries[i].Level != expected[i] {
+			t.Errorf("entry %d level mismatch expected %s got %s", i, expected[i], entries[i].Level)
+		}
+	}
+
+	if entries[1].File != "Assets/Scripts/Player.cs" || entries[1].Line != 12 || entries[1].Code != "CS0246" {
+		t.Errorf("unexpected compile error %+v", entries[1])
+	}
+	if len(entries[3].StackTrace) != 2 {
+		t.Errorf("stack trace mismatch expected 2 lines got %d", len(entries[3].StackTrace))
+	}
+	if len(entries[4].StackTrace) != 2 {
+		t.Errorf("stack trace mismatch expected 2 lines got %d", len(entries[4].StackTrace))
+	}
+}
@@ -0,0 +1,187 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	CDPConnectTimeout = 15 * time.Second
+	CDPCallTimeout    = 30 * time.Second
+)
+
+type cdpTarget struct {
+	ID                   string `json:"id"`
+	Type                 string `json:"type"`
+	URL                  string `json:"url"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+type cdpMessage struct {
+	ID     int64           `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// cdpClient is a minimal Chrome DevTools Protocol client attached to a single page target
+type cdpClient struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+	mutex   sync.Mutex
+	nextID  int64
+	pending map[int64]chan cdpMessage
+	onEvent func(method string, params json.RawMessage)
+	closed  chan struct{}
+}
+
+// connectCDP waits until the browser listening on the debugging port exposes a page and attaches to it
+func connectCDP(ctx context.Context, port int, onEvent func(string, json.RawMessage)) (*cdpClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, CDPConnectTimeout)
+	defer cancel()
+
+	var target *cdpTarget
+	for target == nil {
+		targets, err := listTargets(port)
+		if err == nil {
+			for i := range targets {
+				if targets[i].Type == "page" && len(targets[i].WebSocketDebuggerURL) > 0 {
+					target = &targets[i]
+					break
+				}
+			}
+		}
+		if target != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no debuggable page on port %d: %v", port, ctx.Err())
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, target.WebSocketDebuggerURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadLimit(64 * 1024 * 1024)
+
+	c := &cdpClient{
+		conn:    conn,
+		pending: make(map[int64]chan cdpMessage),
+		onEvent: onEvent,
+		closed:  make(chan struct{}),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+func listTargets(port int) ([]cdpTarget, error) {
+	client := http.Client{Timeout: time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d/json/list", port))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var targets []cdpTarget
+	if err := json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+func (c *cdpClient) readLoop() {
+	defer func() {
+		c.mutex.Lock()
+		for id, ch := range c.pending {
+			close(ch)
+			delete(c.pending, id)
+		}
+		c.mutex.Unlock()
+		close(c.closed)
+	}()
+
+	for {
+		var msg cdpMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		if msg.ID == 0 {
+			if c.onEvent != nil {
+				c.onEvent(msg.Method, msg.Params)
+			}
+			continue
+		}
+		c.mutex.Lock()
+		ch, ok := c.pending[msg.ID]
+		delete(c.pending, msg.ID)
+		c.mutex.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+}
+
+// Call sends a command and decodes its result into result if it is not nil
+func (c *cdpClient) Call(method string, params interface{}, result interface{}) error {
+	c.mutex.Lock()
+	c.nextID++
+	id := c.nextID
+	ch := make(chan cdpMessage, 1)
+	c.pending[id] = ch
+	c.mutex.Unlock()
+
+	request := struct {
+		ID     int64       `json:"id"`
+		Method string      `json:"method"`
+		Params interface{} `json:"params,omitempty"`
+	}{ID: id, Method: method, Params: params}
+
+	c.writeMu.Lock()
+	err := c.conn.WriteJSON(request)
+	c.writeMu.Unlock()
+	if err != nil {
+		c.mutex.Lock()
+		delete(c.pending, id)
+		c.mutex.Unlock()
+		return err
+	}
+
+	select {
+	case msg, ok := <-ch:
+		if !ok {
+			return fmt.Errorf("%s: devtools connection closed", method)
+		}
+		if msg.Error != nil {
+			return fmt.Errorf("%s: %s (%d)", method, msg.Error.Message, msg.Error.Code)
+		}
+		if result != nil && len(msg.Result) > 0 {
+			return json.Unmarshal(msg.Result, result)
+		}
+		return nil
+	case <-time.After(CDPCallTimeout):
+		c.mutex.Lock()
+		delete(c.pending, id)
+		c.mutex.Unlock()
+		return fmt.Errorf("%s: timeout", method)
+	}
+}
+
+// Closed is closed when the devtools connection is lost
+func (c *cdpClient) Closed() <-chan struct{} {
+	return c.closed
+}
+
+func (c *cdpClient) Close() error {
+	return c.conn.Close()
+}
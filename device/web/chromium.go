@@ -0,0 +1,379 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultDebugPort = 9222
+
+	BrowserCloseTimeout = 5 * time.Second
+)
+
+// IsChromium reports if the browser can be controlled via the chrome devtools protocol
+func IsChromium(browser, browserPath string) bool {
+	name := strings.ToLower(browser + " " + filepath.Base(browserPath))
+	for _, n := range []string{"chrom", "edge", "brave"} {
+		if strings.Contains(name, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// chromium is a browser process started with a remote debugging port and the devtools connection to its page
+type chromium struct {
+	cmd         *exec.Cmd
+	cdp         *cdpClient
+	userDataDir string
+	exited      chan struct{}
+
+	mutex    sync.Mutex
+	stopping bool
+}
+
+type remoteObject struct {
+	Type        string          `json:"type"`
+	Value       json.RawMessage `json:"value"`
+	Description string          `json:"description"`
+}
+
+func (o remoteObject) String() string {
+	if len(o.Value) > 0 {
+		var s string
+		if err := json.Unmarshal(o.Value, &s); err == nil {
+			return s
+		}
+		return string(o.Value)
+	}
+	if len(o.Description) > 0 {
+		return o.Description
+	}
+	return o.Type
+}
+
+func (d *Device) startChromium(applicationURL string) error {
+	userDataDir, temporary, err := d.userDataDir()
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		fmt.Sprintf("--remote-debugging-port=%d", d.debugPort),
+		"--user-data-dir=" + userDataDir,
+		"--no-first-run",
+		"--no-default-browser-check",
+		"--disable-background-timer-throttling",
+		"--disable-renderer-backgrounding",
+		"--autoplay-policy=no-user-gesture-required",
+		"--enable-webgl",
+		"--ignore-gpu-blocklist",
+	}
+	if d.cfg.Headless {
+		args = append(args, "--headless=new", "--hide-scrollbars", "--mute-audio")
+	}
+	if width, height, ok := d.windowSize(); ok {
+		args = append(args, fmt.Sprintf("--window-size=%d,%d", width, height))
+	}
+	if len(d.profile.Locale) > 0 {
+		args = append(args, "--lang="+d.profile.Locale)
+	}
+	if len(d.profile.UserAgent) > 0 {
+		args = append(args, "--user-agent="+d.profile.UserAgent)
+	}
+	args = append(args, "about:blank")
+
+	c := &chromium{
+		cmd:    exec.Command(d.browserPath, args...),
+		exited: make(chan struct{}),
+	}
+	if temporary {
+		c.userDataDir = userDataDir
+	}
+	if err := c.cmd.Start(); err != nil {
+		c.cleanup()
+		return err
+	}
+	go func() {
+		c.cmd.Wait()
+		close(c.exited)
+	}()
+
+	cdp, err := connectCDP(context.Background(), d.debugPort, d.handleCDPEvent)
+	if err != nil {
+		c.kill()
+		return err
+	}
+	c.cdp = cdp
+
+	for _, domain := range []string{"Page", "Runtime", "Log", "Inspector", "Network"} {
+		if err := cdp.Call(domain+".enable", nil, nil); err != nil {
+			c.kill()
+			return err
+		}
+	}
+
+	d.chromium = c
+
+	if len(d.cfg.Emulation) > 0 {
+		if err := d.emulate(d.cfg.Emulation); err != nil {
+			d.Error("browser", "emulation %s failed: %v", d.cfg.Emulation, err)
+		}
+	} else if viewport := d.viewport(); len(viewport) > 0 {
+		if err := d.setViewport(viewport); err != nil {
+			d.Error("browser", "viewport %s failed: %v", viewport, err)
+		}
+	}
+	if err := d.applyProfile(); err != nil {
+		d.Error("browser", "browser profile %s failed: %v", d.profileName, err)
+	}
+	if len(d.cfg.Network) > 0 {
+		if err := d.throttle(d.cfg.Network); err != nil {
+			d.Error("browser", "network profile %s failed: %v", d.cfg.Network, err)
+		}
+	}
+
+	if err := cdp.Call("Page.navigate", map[string]interface{}{"url": applicationURL}, nil); err != nil {
+		d.chromium = nil
+		c.kill()
+		return err
+	}
+
+	go d.watchChromium(c)
+	return nil
+}
+
+// watchChromium reports a browser which exits or drops the devtools connection while the app is running
+func (d *Device) watchChromium(c *chromium) {
+	select {
+	case <-c.exited:
+	case <-c.cdp.Closed():
+	}
+	c.mutex.Lock()
+	stopping := c.stopping
+	c.mutex.Unlock()
+	if !stopping {
+		d.Error("browser", "browser exited unexpectedly")
+		d.Cancel()
+	}
+}
+
+func (d *Device) handleCDPEvent(method string, params json.RawMessage) {
+	switch method {
+	case "Runtime.consoleAPICalled":
+		var event struct {
+			Type string         `json:"type"`
+			Args []remoteObject `json:"args"`
+		}
+		if err := json.Unmarshal(params, &event); err != nil {
+			return
+		}
+		parts := make([]string, 0, len(event.Args))
+		for _, arg := range event.Args {
+			parts = append(parts, arg.String())
+		}
+		if event.Type == "error" || event.Type == "assert" {
+			d.Error("console", "%s", strings.Join(parts, " "))
+		} else {
+			d.Log("console", "%s", strings.Join(parts, " "))
+		}
+	case "Runtime.exceptionThrown":
+		var event struct {
+			ExceptionDetails struct {
+				Text      string        `json:"text"`
+				URL       string        `json:"url"`
+				Line      int           `json:"lineNumber"`
+				Exception *remoteObject `json:"exception"`
+			} `json:"exceptionDetails"`
+		}
+		if err := json.Unmarshal(params, &event); err != nil {
+			return
+		}
+		details := event.ExceptionDetails
+		message := details.Text
+		if details.Exception != nil {
+			message = details.Exception.String()
+		}
+		d.Error("console", "%s (%s:%d)", message, details.URL, details.Line)
+	case "Log.entryAdded":
+		var event struct {
+			Entry struct {
+				Source string `json:"source"`
+				Level  string `json:"level"`
+				Text   string `json:"text"`
+				URL    string `json:"url"`
+			} `json:"entry"`
+		}
+		if err := json.Unmarshal(params, &event); err != nil {
+			return
+		}
+		if event.Entry.Level == "error" {
+			d.Error("browser", "%s: %s %s", event.Entry.Source, event.Entry.Text, event.Entry.URL)
+		} else {
+			d.Log("browser", "%s: %s %s", event.Entry.Source, event.Entry.Text, event.Entry.URL)
+		}
+	case "Inspector.targetCrashed":
+		d.Error("browser", "page crashed")
+		d.Cancel()
+	}
+}
+
+func (d *Device) windowSize() (int, int, bool) {
+	if profile, ok := EmulationProfiles[d.cfg.Emulation]; ok {
+		return profile.Width, profile.Height, true
+	}
+	if width, height, err := parseViewport(d.viewport()); err == nil {
+		return width, height, true
+	}
+	return 0, 0, false
+}
+
+func (d *Device) devtools() (*cdpClient, error) {
+	if d.chromium == nil || d.chromium.cdp == nil {
+		return nil, fmt.Errorf("browser not running")
+	}
+	return d.chromium.cdp, nil
+}
+
+func (d *Device) setViewport(viewport string) error {
+	width, height, err := parseViewport(viewport)
+	if err != nil {
+		return err
+	}
+	return d.applyEmulation(EmulationProfile{Width: width, Height: height, Scale: 1})
+}
+
+func (d *Device) emulate(name string) error {
+	profile, ok := EmulationProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown emulation profile %s", name)
+	}
+	return d.applyEmulation(profile)
+}
+
+func (d *Device) applyEmulation(profile EmulationProfile) error {
+	cdp, err := d.devtools()
+	if err != nil {
+		return err
+	}
+	if err := cdp.Call("Emulation.setDeviceMetricsOverride", map[string]interface{}{
+		"width":             profile.Width,
+		"height":            profile.Height,
+		"deviceScaleFactor": profile.Scale,
+		"mobile":            profile.Mobile,
+	}, nil); err != nil {
+		return err
+	}
+	if err := cdp.Call("Emulation.setTouchEmulationEnabled", map[string]interface{}{"enabled": profile.Touch}, nil); err != nil {
+		return err
+	}
+	if len(profile.UserAgent) > 0 {
+		return cdp.Call("Emulation.setUserAgentOverride", map[string]interface{}{"userAgent": profile.UserAgent}, nil)
+	}
+	return nil
+}
+
+// applyProfile overrides the user agent and locale of the page, the profile wins over emulated devices
+func (d *Device) applyProfile() error {
+	cdp, err := d.devtools()
+	if err != nil {
+		return err
+	}
+	if len(d.profile.UserAgent) > 0 {
+		if err := cdp.Call("Emulation.setUserAgentOverride", map[string]interface{}{"userAgent": d.profile.UserAgent, "acceptLanguage": d.profile.Locale}, nil); err != nil {
+			return err
+		}
+	}
+	if len(d.profile.Locale) > 0 {
+		return cdp.Call("Emulation.setLocaleOverride", map[string]interface{}{"locale": d.profile.Locale}, nil)
+	}
+	return nil
+}
+
+func (d *Device) throttle(name string) error {
+	profile, ok := NetworkProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown network profile %s", name)
+	}
+	cdp, err := d.devtools()
+	if err != nil {
+		return err
+	}
+	return cdp.Call("Network.emulateNetworkConditions", profile, nil)
+}
+
+func (d *Device) chromiumScreenshot() ([]byte, int, int, error) {
+	cdp, err := d.devtools()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var result struct {
+		Data string `json:"data"`
+	}
+	if err := cdp.Call("Page.captureScreenshot", map[string]interface{}{"format": "png"}, &result); err != nil {
+		return nil, 0, 0, err
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Data)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return data, cfg.Width, cfg.Height, nil
+}
+
+// stop closes the browser gracefully and kills it if it does not exit in time
+func (c *chromium) stop() {
+	c.mutex.Lock()
+	c.stopping = true
+	c.mutex.Unlock()
+
+	if c.cdp != nil {
+		go c.cdp.Call("Browser.close", nil, nil)
+	}
+	select {
+	case <-c.exited:
+	case <-time.After(BrowserCloseTimeout):
+		c.cmd.Process.Kill()
+		<-c.exited
+	}
+	if c.cdp != nil {
+		c.cdp.Close()
+	}
+	c.cleanup()
+}
+
+func (c *chromium) kill() {
+	c.mutex.Lock()
+	c.stopping = true
+	c.mutex.Unlock()
+
+	if c.cmd.Process != nil {
+		c.cmd.Process.Kill()
+		<-c.exited
+	}
+	if c.cdp != nil {
+		c.cdp.Close()
+	}
+	c.cleanup()
+}
+
+func (c *chromium) cleanup() {
+	if len(c.userDataDir) > 0 {
+		os.RemoveAll(c.userDataDir)
+	}
+}
@@ -2,6 +2,7 @@ package web
 
 import (
 	"fmt"
+	"github.com/fsuhrau/automationhub/config"
 	"github.com/fsuhrau/automationhub/device/generic"
 	"github.com/fsuhrau/automationhub/storage/models"
 	exec2 "github.com/fsuhrau/automationhub/tools/exec"
@@ -11,6 +12,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fsuhrau/automationhub/app"
@@ -46,6 +48,14 @@ type Device struct {
 	runningExecutable  string
 	recordingSession   *exec.Cmd
 	applicationProcess *exec.Cmd
+
+	profileName string
+	profile     config.BrowserProfile
+	cfg         config.Web
+	debugPort   int
+	mutex       sync.Mutex
+	chromium    *chromium
+	firefox     *firefox
 }
 
 func (d *Device) DeviceParameter() map[string]string {
@@ -69,14 +79,18 @@ func (d *Device) DeviceOSVersion() string {
 }
 
 func (d *Device) DeviceName() string {
+	name := d.browser
+	if d.profileName != d.browser {
+		name = fmt.Sprintf("%s %s", d.profileName, d.browser)
+	}
 	if len(d.browserVersion) > 1 {
-		return fmt.Sprintf("%s (%s) @ %s", d.browser, d.browserVersion, d.deviceName)
+		return fmt.Sprintf("%s (%s) @ %s", name, d.browserVersion, d.deviceName)
 	}
-	return fmt.Sprintf("%s @ %s", d.browser, d.deviceName)
+	return fmt.Sprintf("%s @ %s", name, d.deviceName)
 }
 
 func (d *Device) DeviceID() string {
-	return d.deviceID + "/" + d.browser
+	return d.deviceID + "/" + d.profileName
 }
 
 func (d *Device) TargetVersion() string {
@@ -88,6 +102,9 @@ func (d *Device) DeviceIP() net.IP {
 }
 
 func (d *Device) DeviceState() device.State {
+	if d.IsQuarantined() {
+		return device.StateQuarantined
+	}
 	return d.deviceState
 }
 
@@ -116,6 +133,16 @@ func (d *Device) UpdateDeviceInfos() {
 		d.deviceParameter["Serial Number"] = serialNumber
 	}
 
+	if viewport := d.viewport(); len(viewport) > 0 {
+		d.deviceParameter["Viewport"] = viewport
+	}
+	if len(d.profile.Locale) > 0 {
+		d.deviceParameter["Locale"] = d.profile.Locale
+	}
+	if len(d.profile.UserAgent) > 0 {
+		d.deviceParameter["User Agent"] = d.profile.UserAgent
+	}
+
 	var versionCommand *exec.Cmd
 	if strings.Contains(runtime.GOOS, "windows") {
 		versionCommand = exec2.NewCommand(d.browserPath, "--version")
@@ -158,6 +185,21 @@ func (d *Device) UninstallApp(params *app.Parameter) error {
 
 func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sessionId string, nodeUrl string) error {
 	applicationURL := fmt.Sprintf("%s?sessionId=%s&nodeURL=%s&deviceId=%s", appParams.Web.StartURL, sessionId, nodeUrl, d.DeviceID())
+
+	if IsChromium(d.browser, d.browserPath) {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		d.stopChromium()
+		return d.startChromium(applicationURL)
+	}
+
+	if IsFirefox(d.browser, d.browserPath) {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		d.stopFirefox()
+		return d.startFirefox(applicationURL)
+	}
+
 	if strings.Contains(runtime.GOOS, "windows") {
 		d.applicationProcess = exec2.NewCommand(d.browserPath, applicationURL)
 	} else if strings.Contains(runtime.GOOS, "darwin") {
@@ -177,7 +219,36 @@ func (d *Device) StartApp(_ *device.DeviceConfig, appParams *app.Parameter, sess
 	return nil
 }
 
+// viewport of the browser profile, falls back to the one of the manager
+func (d *Device) viewport() string {
+	if len(d.profile.Viewport) > 0 {
+		return d.profile.Viewport
+	}
+	return d.cfg.Viewport
+}
+
+// userDataDir returns the configured profile directory or a temporary one which has to be removed after use
+func (d *Device) userDataDir() (string, bool, error) {
+	if len(d.profile.ProfileDir) > 0 {
+		return d.profile.ProfileDir, false, os.MkdirAll(d.profile.ProfileDir, os.ModePerm)
+	}
+	dir, err := os.MkdirTemp("", "automationhub_"+d.browser)
+	return dir, true, err
+}
+
+func (d *Device) stopChromium() {
+	if d.chromium != nil {
+		d.chromium.stop()
+		d.chromium = nil
+	}
+}
+
 func (d *Device) StopApp(params *app.Parameter) error {
+	d.mutex.Lock()
+	d.stopChromium()
+	d.stopFirefox()
+	d.mutex.Unlock()
+
 	if d.applicationProcess != nil && d.applicationProcess.Process != nil {
 		d.applicationProcess.Process.Kill()
 	}
@@ -199,15 +270,56 @@ func (d *Device) StopRecording() error {
 }
 
 func (d *Device) GetScreenshot() ([]byte, int, int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.chromium != nil {
+		return d.chromiumScreenshot()
+	}
 	return nil, 0, 0, fmt.Errorf("GetScreenshot not Supported by this device")
 }
 
-func (d *Device) HasFeature(string) bool {
-	return false
+// features are executed via devtools, arguments are passed as "feature:argument" e.g. "network:slow-3g"
+var features = map[string]func(d *Device, arg string) error{
+	"reload": func(d *Device, _ string) error {
+		cdp, err := d.devtools()
+		if err != nil {
+			return err
+		}
+		return cdp.Call("Page.reload", nil, nil)
+	},
+	"back": func(d *Device, _ string) error {
+		cdp, err := d.devtools()
+		if err != nil {
+			return err
+		}
+		return cdp.Call("Runtime.evaluate", map[string]interface{}{"expression": "history.back()"}, nil)
+	},
+	"network":  (*Device).throttle,
+	"emulate":  (*Device).emulate,
+	"viewport": (*Device).setViewport,
 }
 
-func (d *Device) Execute(string) {
+func (d *Device) HasFeature(feature string) bool {
+	if !IsChromium(d.browser, d.browserPath) {
+		return false
+	}
+	name, _, _ := strings.Cut(feature, ":")
+	_, ok := features[name]
+	return ok
+}
 
+func (d *Device) Execute(feature string) {
+	d.Log("device", "Execute Feature: '%s'", feature)
+	name, arg, _ := strings.Cut(feature, ":")
+	f, ok := features[name]
+	if !ok {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err := f(d, arg); err != nil {
+		d.Error("device", "feature %s failed: %v", feature, err)
+	}
 }
 
 func (d *Device) ConnectionTimeout() time.Duration {
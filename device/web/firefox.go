@@ -0,0 +1,109 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	exec2 "github.com/fsuhrau/automationhub/tools/exec"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// IsFirefox reports if the browser is a firefox which is configured through the prefs of its profile
+func IsFirefox(browser, browserPath string) bool {
+	return strings.Contains(strings.ToLower(browser+" "+filepath.Base(browserPath)), "firefox")
+}
+
+// firefox is a browser process with its own profile so several instances can run side by side
+type firefox struct {
+	cmd         *exec.Cmd
+	userDataDir string
+}
+
+func (d *Device) firefoxPrefs() map[string]interface{} {
+	prefs := map[string]interface{}{
+		"browser.shell.checkDefaultBrowser":          false,
+		"browser.startup.homepage_override.mstone":   "ignore",
+		"browser.sessionstore.resume_from_crash":     false,
+		"datareporting.policy.dataSubmissionEnabled": false,
+		"toolkit.telemetry.reportingpolicy.firstRun": false,
+		"media.autoplay.default":                     0,
+		"webgl.force-enabled":                        true,
+	}
+	if len(d.profile.Locale) > 0 {
+		prefs["intl.accept_languages"] = d.profile.Locale
+		prefs["intl.locale.requested"] = d.profile.Locale
+	}
+	if len(d.profile.UserAgent) > 0 {
+		prefs["general.useragent.override"] = d.profile.UserAgent
+	}
+	return prefs
+}
+
+func writeUserPrefs(dir string, prefs map[string]interface{}) error {
+	var content strings.Builder
+	for key, value := range prefs {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		content.WriteString(fmt.Sprintf("user_pref(%q, %s);\n", key, data))
+	}
+	return os.WriteFile(filepath.Join(dir, "user.js"), []byte(content.String()), 0644)
+}
+
+func (d *Device) startFirefox(applicationURL string) error {
+	userDataDir, temporary, err := d.userDataDir()
+	if err != nil {
+		return err
+	}
+
+	f := &firefox{}
+	if temporary {
+		f.userDataDir = userDataDir
+	}
+
+	if err := writeUserPrefs(userDataDir, d.firefoxPrefs()); err != nil {
+		f.cleanup()
+		return err
+	}
+
+	args := []string{"-no-remote", "-profile", userDataDir}
+	if d.cfg.Headless {
+		args = append(args, "-headless")
+	}
+	if width, height, ok := d.windowSize(); ok {
+		args = append(args, "-width", fmt.Sprint(width), "-height", fmt.Sprint(height))
+	}
+	args = append(args, applicationURL)
+
+	f.cmd = exec2.NewCommand(d.browserPath, args...)
+	if err := f.cmd.Start(); err != nil {
+		f.cleanup()
+		return err
+	}
+	d.firefox = f
+	return nil
+}
+
+func (d *Device) stopFirefox() {
+	if d.firefox != nil {
+		d.firefox.stop()
+		d.firefox = nil
+	}
+}
+
+func (f *firefox) stop() {
+	if f.cmd != nil && f.cmd.Process != nil {
+		f.cmd.Process.Kill()
+		f.cmd.Wait()
+	}
+	f.cleanup()
+}
+
+func (f *firefox) cleanup() {
+	if len(f.userDataDir) > 0 {
+		os.RemoveAll(f.userDataDir)
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/fsuhrau/automationhub/device/generic"
 	"github.com/fsuhrau/automationhub/hub/node"
 	"github.com/fsuhrau/automationhub/storage"
+	"github.com/sirupsen/logrus"
+	"os/exec"
+	"sort"
 )
 
 const (
@@ -41,10 +44,44 @@ func (m *Handler) Init(masterUrl, nodeIdentifier string, authToken *string) erro
 		m.init = false
 	}()
 
-	for k, v := range m.cfg.Browser {
+	var webCfg config.Web
+	if m.cfg.Web != nil {
+		webCfg = *m.cfg.Web
+	}
+	debugPort := webCfg.DebugPort
+	if debugPort == 0 {
+		debugPort = DefaultDebugPort
+	}
+
+	profiles := make([]string, 0, len(m.cfg.Browser))
+	for k := range m.cfg.Browser {
+		profiles = append(profiles, k)
+	}
+	sort.Strings(profiles)
+
+	for i, k := range profiles {
+		profile := m.cfg.Browser[k]
+		browser := profile.Browser
+		if len(browser) == 0 {
+			browser = k
+		}
+		browserPath := profile.Path
+		if len(browserPath) == 0 {
+			path, err := exec.LookPath(browser)
+			if err != nil {
+				logrus.Errorf("web: no path configured for browser profile %s: %v", k, err)
+				continue
+			}
+			browserPath = path
+		}
+
 		dev := &Device{
-			browser:     k,
-			browserPath: v,
+			profileName: k,
+			profile:     profile,
+			browser:     browser,
+			browserPath: browserPath,
+			cfg:         webCfg,
+			debugPort:   debugPort + i,
 		}
 
 		dev.UpdateDeviceInfos()
@@ -3,7 +3,9 @@ package web
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 )
 
@@ -52,11 +54,17 @@ func getSystemProfilerValue(dataType, key string) (string, error) {
 
 // GetDeviceName returns the device name
 func GetDeviceName() (string, error) {
+	if runtime.GOOS != "darwin" {
+		return os.Hostname()
+	}
 	return getSysctlValue("kern.hostname")
 }
 
 // GetOSName returns the OS name
 func GetOSName() (string, error) {
+	if runtime.GOOS != "darwin" {
+		return runtime.GOOS, nil
+	}
 	return getSwVersValue("productName")
 }
 
@@ -66,6 +74,13 @@ func GetOSVersion() (string, error) {
 }
 
 func GetHardwareUUID() (string, error) {
+	if runtime.GOOS == "linux" {
+		data, err := os.ReadFile("/etc/machine-id")
+		if err != nil {
+			return os.Hostname()
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
 	cmd := exec.Command("ioreg", "-rd1", "-c", "IOPlatformExpertDevice")
 	var out bytes.Buffer
 	cmd.Stdout = &out
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NetworkProfile are the conditions passed to Network.emulateNetworkConditions, throughput in bytes per second
+type NetworkProfile struct {
+	Offline            bool    `json:"offline"`
+	Latency            float64 `json:"latency"`
+	DownloadThroughput float64 `json:"downloadThroughput"`
+	UploadThroughput   float64 `json:"uploadThroughput"`
+}
+
+// NetworkProfiles follow the presets of the chrome devtools
+var NetworkProfiles = map[string]NetworkProfile{
+	"none":    {Latency: 0, DownloadThroughput: -1, UploadThroughput: -1},
+	"offline": {Offline: true, DownloadThroughput: 0, UploadThroughput: 0},
+	"slow-3g": {Latency: 2000, DownloadThroughput: 500 * 1024 / 8, UploadThroughput: 500 * 1024 / 8},
+	"fast-3g": {Latency: 562.5, DownloadThroughput: 1.6 * 1024 * 1024 / 8, UploadThroughput: 750 * 1024 / 8},
+	"4g":      {Latency: 20, DownloadThroughput: 4 * 1024 * 1024 / 8, UploadThroughput: 3 * 1024 * 1024 / 8},
+	"wifi":    {Latency: 2, DownloadThroughput: 30 * 1024 * 1024 / 8, UploadThroughput: 15 * 1024 * 1024 / 8},
+}
+
+// EmulationProfile describes the viewport and the identity of an emulated device
+type EmulationProfile struct {
+	Width     int
+	Height    int
+	Scale     float64
+	Mobile    bool
+	Touch     bool
+	UserAgent string
+}
+
+// EmulationProfiles are common devices the browser can emulate
+var EmulationProfiles = map[string]EmulationProfile{
+	"desktop":   {Width: 1920, Height: 1080, Scale: 1},
+	"laptop":    {Width: 1366, Height: 768, Scale: 1},
+	"iphone-12": {Width: 390, Height: 844, Scale: 3, Mobile: true, Touch: true, UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 14_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.0 Mobile/15E148 Safari/604.1"},
+	"pixel-5":   {Width: 393, Height: 851, Scale: 2.75, Mobile: true, Touch: true, UserAgent: "Mozilla/5.0 (Linux; Android 11; Pixel 5) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/90.0.4430.91 Mobile Safari/537.36"},
+	"ipad":      {Width: 810, Height: 1080, Scale: 2, Mobile: true, Touch: true, UserAgent: "Mozilla/5.0 (iPad; CPU OS 14_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.0 Mobile/15E148 Safari/604.1"},
+}
+
+// parseViewport parses sizes like 1280x720
+func parseViewport(viewport string) (int, int, error) {
+	w, h, ok := strings.Cut(strings.ToLower(viewport), "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid viewport %s", viewport)
+	}
+	width, err := strconv.Atoi(strings.TrimSpace(w))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid viewport %s", viewport)
+	}
+	height, err := strconv.Atoi(strings.TrimSpace(h))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid viewport %s", viewport)
+	}
+	return width, height, nil
+}
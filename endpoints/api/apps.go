@@ -199,6 +199,9 @@ func (s *Service) updateBinary(c *gin.Context, project *models.Project, applicat
 	}
 
 	binary.Tags = newApp.Tags
+	if len(newApp.Executable.Executable) > 0 {
+		binary.Executable = newApp.Executable
+	}
 	if err := s.db.Save(&binary).Error; err != nil {
 		s.error(c, http.StatusInternalServerError, err)
 		return
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/storage/models"
+)
+
+// nodeLoads returns the current load of every node, based on the resource usage the node reports
+// and the devices which are locked on it
+func (s *Service) nodeLoads() (map[uint]float64, error) {
+	var nodes []models.Node
+	if err := s.db.Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+
+	loads := make(map[uint]float64)
+	for _, n := range nodes {
+		metrics, _ := s.nodeManager.GetMetrics(manager.NodeIdentifier(n.Identifier))
+		loads[n.ID] = metrics.Load()
+	}
+
+	var devices []models.Device
+	if err := s.db.Find(&devices).Error; err != nil {
+		return nil, err
+	}
+	for _, d := range devices {
+		if dev, _ := s.devicesManager.GetDevice(d.DeviceIdentifier); dev != nil && dev.IsLocked() {
+			loads[d.NodeID] += manager.DeviceSessionLoad
+		}
+	}
+	return loads, nil
+}
+
+// spreadByLoad orders equivalent devices so that each next device is placed on the least loaded node,
+// every device picked adds to the load of its node
+func (s *Service) spreadByLoad(devices []models.Device) []models.Device {
+	loads, err := s.nodeLoads()
+	if err != nil {
+		s.logger.Warnf("unable to determine node loads: %v", err)
+		return devices
+	}
+	return spreadDevices(devices, loads)
+}
+
+func spreadDevices(devices []models.Device, loads map[uint]float64) []models.Device {
+	remaining := append([]models.Device(nil), devices...)
+	ordered := make([]models.Device, 0, len(devices))
+	for len(remaining) > 0 {
+		next := 0
+		for i := range remaining {
+			if loads[remaining[i].NodeID] < loads[remaining[next].NodeID] {
+				next = i
+			}
+		}
+		loads[remaining[next].NodeID] += manager.DeviceSessionLoad
+		ordered = append(ordered, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	return ordered
+}
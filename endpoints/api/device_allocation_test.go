@@ -0,0 +1,37 @@
+package api
+
+import (
+	"github.com/fsuhrau/automationhub/storage/models"
+	"strings"
+	"testing"
+)
+
+func TestSpreadDevices(t *testing.T) {
+	device := func(identifier string, nodeID uint) models.Device {
+		return models.Device{DeviceIdentifier: identifier, NodeID: nodeID}
+	}
+	devices := []models.Device{
+		device("a1", 1), device("a2", 1), device("a3", 1),
+		device("b1", 2), device("b2", 2),
+		device("c1", 3),
+	}
+	// node 1 is idle, node 2 half loaded and node 3 almost fully loaded
+	loads := map[uint]float64{1: 0, 2: 0.5, 3: 0.9}
+
+	var order []string
+	for _, d := range spreadDevices(devices, loads) {
+		order = append(order, d.DeviceIdentifier)
+	}
+	if strings.Join(order, ",") != "a1,a2,a3,b1,b2,c1" {
+		t.Errorf("unexpected allocation order %v", order)
+	}
+
+	loads = map[uint]float64{1: 0.3, 2: 0, 3: 0.1}
+	order = nil
+	for _, d := range spreadDevices(devices, loads) {
+		order = append(order, d.DeviceIdentifier)
+	}
+	if strings.Join(order, ",") != "b1,c1,b2,a1,a2,a3" {
+		t.Errorf("unexpected allocation order %v", order)
+	}
+}
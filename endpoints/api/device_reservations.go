@@ -0,0 +1,164 @@
+package api
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type ReservationRequest struct {
+	Duration string `json:"duration"`
+	Owner    string `json:"owner"`
+	Reason   string `json:"reason"`
+}
+
+func (s *Service) reserveDevice(c *gin.Context, project *models.Project) {
+	deviceID := c.Param("device_id")
+
+	var req ReservationRequest
+	if err := c.Bind(&req); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("invalid duration: %s", req.Duration))
+		return
+	}
+
+	owner := strings.TrimSpace(req.Owner)
+	if len(owner) == 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("missing owner"))
+		return
+	}
+
+	var device models.Device
+	if err := s.db.First(&device, deviceID).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	existing, err := storage.GetActiveReservation(s.db, device.ID)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	if existing != nil {
+		s.error(c, http.StatusConflict, fmt.Errorf("device is reserved by %s until %s", existing.Owner, existing.ExpiresAt.Format(time.RFC3339)))
+		return
+	}
+
+	if dev, _ := s.devicesManager.GetDevice(device.DeviceIdentifier); dev != nil && dev.IsLocked() {
+		s.error(c, http.StatusConflict, fmt.Errorf("device is locked by a running test"))
+		return
+	}
+
+	reservation := models.DeviceReservation{
+		DeviceID:  device.ID,
+		Owner:     owner,
+		Reason:    req.Reason,
+		ExpiresAt: time.Now().Add(duration),
+	}
+	if err := s.db.Create(&reservation).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	events.DeviceReservationChanged.Trigger(events.DeviceReservationChangedPayload{
+		DeviceID:    device.ID,
+		Reserved:    true,
+		Reservation: reservation,
+	})
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+func (s *Service) extendReservation(c *gin.Context, project *models.Project) {
+	deviceID := c.Param("device_id")
+
+	var req ReservationRequest
+	if err := c.Bind(&req); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("invalid duration: %s", req.Duration))
+		return
+	}
+
+	var device models.Device
+	if err := s.db.First(&device, deviceID).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	reservation, err := storage.GetActiveReservation(s.db, device.ID)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	if reservation == nil {
+		s.error(c, http.StatusNotFound, fmt.Errorf("device is not reserved"))
+		return
+	}
+
+	reservation.ExpiresAt = reservation.ExpiresAt.Add(duration)
+	if len(req.Reason) > 0 {
+		reservation.Reason = req.Reason
+	}
+	if err := s.db.Save(reservation).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	events.DeviceReservationChanged.Trigger(events.DeviceReservationChangedPayload{
+		DeviceID:    device.ID,
+		Reserved:    true,
+		Reservation: *reservation,
+	})
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+func (s *Service) releaseReservation(c *gin.Context, project *models.Project) {
+	deviceID := c.Param("device_id")
+
+	var device models.Device
+	if err := s.db.First(&device, deviceID).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	reservation, err := storage.GetActiveReservation(s.db, device.ID)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	if reservation == nil {
+		s.error(c, http.StatusNotFound, fmt.Errorf("device is not reserved"))
+		return
+	}
+
+	now := time.Now()
+	reservation.ReleasedAt = &now
+	if err := s.db.Save(reservation).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	events.DeviceReservationChanged.Trigger(events.DeviceReservationChangedPayload{
+		DeviceID:    device.ID,
+		Reserved:    false,
+		Reservation: *reservation,
+	})
+
+	c.JSON(http.StatusOK, reservation)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func reservationRequest(t *testing.T, handler func(*gin.Context, *models.Project), deviceID uint, req *ReservationRequest) (*httptest.ResponseRecorder, models.DeviceReservation) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	var body bytes.Buffer
+	if req != nil {
+		json.NewEncoder(&body).Encode(req)
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, "/", &body)
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "device_id", Value: fmt.Sprintf("%d", deviceID)}}
+
+	handler(c, &models.Project{})
+
+	var reservation models.DeviceReservation
+	json.Unmarshal(w.Body.Bytes(), &reservation)
+	return w, reservation
+}
+
+func TestDeviceReservation(t *testing.T) {
+	s := newTestService(t, map[string]*testDevice{
+		"phone": {state: device.StateBooted},
+	})
+	var phone models.Device
+	s.db.First(&phone)
+
+	if w, _ := reservationRequest(t, s.reserveDevice, phone.ID, &ReservationRequest{Duration: "1h"}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected missing owner to be rejected got %d", w.Code)
+	}
+
+	w, reservation := reservationRequest(t, s.reserveDevice, phone.ID, &ReservationRequest{Duration: "1h", Owner: "alice", Reason: "debugging"})
+	if w.Code != http.StatusCreated || reservation.Owner != "alice" {
+		t.Fatalf("expected reservation got %d %s", w.Code, w.Body.String())
+	}
+
+	if w, _ := reservationRequest(t, s.reserveDevice, phone.ID, &ReservationRequest{Duration: "1h", Owner: "bob"}); w.Code != http.StatusConflict {
+		t.Errorf("expected conflict for reserved device got %d", w.Code)
+	}
+
+	if _, err := s.selectDevices("os=android"); err == nil {
+		t.Errorf("expected reserved device to be excluded from selection")
+	}
+
+	w, extended := reservationRequest(t, s.extendReservation, phone.ID, &ReservationRequest{Duration: "30m"})
+	if w.Code != http.StatusOK || !extended.ExpiresAt.Equal(reservation.ExpiresAt.Add(30*time.Minute)) {
+		t.Errorf("expected extended reservation got %d %v", w.Code, extended.ExpiresAt)
+	}
+
+	if w, _ := reservationRequest(t, s.releaseReservation, phone.ID, nil); w.Code != http.StatusOK {
+		t.Errorf("expected release got %d", w.Code)
+	}
+	if w, _ := reservationRequest(t, s.releaseReservation, phone.ID, nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected released device to be not reserved got %d", w.Code)
+	}
+
+	devices, err := s.selectDevices("os=android")
+	if err != nil || len(devices) != 1 {
+		t.Errorf("expected released device to be selectable got %v %v", devices, err)
+	}
+}
+
+func TestSelectDevicesSkipsReservedDevices(t *testing.T) {
+	s := newTestService(t, map[string]*testDevice{
+		"reserved": {state: device.StateBooted},
+		"expired":  {state: device.StateBooted},
+		"released": {state: device.StateBooted},
+		"free":     {state: device.StateBooted},
+	})
+	reserve := func(identifier string, expiresAt time.Time, releasedAt *time.Time) {
+		var d models.Device
+		s.db.Where("device_identifier = ?", identifier).First(&d)
+		s.db.Create(&models.DeviceReservation{DeviceID: d.ID, Owner: "alice", ExpiresAt: expiresAt, ReleasedAt: releasedAt})
+	}
+	now := time.Now()
+	reserve("reserved", now.Add(time.Hour), nil)
+	reserve("expired", now.Add(-time.Minute), nil)
+	reserve("released", now.Add(time.Hour), &now)
+
+	devices, err := s.selectDevices("os=android")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selected := selectedIdentifiers(devices)
+	if len(selected) != 3 || selected["reserved"] {
+		t.Errorf("expected all devices but the reserved one got %v", selected)
+	}
+}
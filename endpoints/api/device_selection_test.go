@@ -0,0 +1,141 @@
+package api
+
+import (
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/storage/migrations"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/glebarez/sqlite"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"testing"
+)
+
+type testDevice struct {
+	device.Device
+	state  device.State
+	locked bool
+}
+
+func (d *testDevice) DeviceState() device.State {
+	return d.state
+}
+
+func (d *testDevice) IsLocked() bool {
+	return d.locked
+}
+
+// devicesManager names the embedded interface, manager.Devices has a method called Devices
+type devicesManager = manager.Devices
+
+type testDevicesManager struct {
+	devicesManager
+	devices map[string]*testDevice
+}
+
+func (m *testDevicesManager) GetDevice(id string) (device.Device, string) {
+	if d, ok := m.devices[id]; ok {
+		return d, ""
+	}
+	return nil, ""
+}
+
+// testNodes reports no resource usage for any node
+type testNodes struct {
+	manager.Nodes
+}
+
+func (n *testNodes) GetMetrics(nodeIdentifier manager.NodeIdentifier) (*manager.NodeMetrics, error) {
+	return nil, nil
+}
+
+// newTestService creates a service on an in memory database with an android device for every given device
+func newTestService(t *testing.T, devices map[string]*testDevice) *Service {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("unable to open database: %v", err)
+	}
+	if err := migrations.InitSchema(db); err != nil {
+		t.Fatalf("unable to migrate database: %v", err)
+	}
+	for id := range devices {
+		if err := db.Create(&models.Device{DeviceIdentifier: id, OS: "android"}).Error; err != nil {
+			t.Fatalf("unable to create device: %v", err)
+		}
+	}
+	return &Service{
+		logger:         logrus.NewEntry(logrus.New()),
+		db:             db,
+		devicesManager: &testDevicesManager{devices: devices},
+		nodeManager:    &testNodes{},
+	}
+}
+
+func selectedIdentifiers(devices []models.Device) map[string]bool {
+	result := make(map[string]bool)
+	for _, d := range devices {
+		result[d.DeviceIdentifier] = true
+	}
+	return result
+}
+
+func TestSelectDevicesStates(t *testing.T) {
+	s := newTestService(t, map[string]*testDevice{
+		"booted":       {state: device.StateBooted},
+		"shutdown":     {state: device.StateShutdown},
+		"locked":       {state: device.StateBooted, locked: true},
+		"unknown":      {state: device.StateUnknown},
+		"disconnected": {state: device.StateRemoteDisconnected},
+		"node":         {state: device.StateNodeDisconnected},
+		"quarantined":  {state: device.StateQuarantined},
+		"none":         {state: device.StateNone},
+	})
+
+	devices, err := s.selectDevices("os=android")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selected := selectedIdentifiers(devices)
+	if len(selected) != 2 || !selected["booted"] || !selected["shutdown"] {
+		t.Errorf("expected booted and shutdown devices got %v", selected)
+	}
+
+	if _, err := s.selectDevices("os=android, count=3"); err == nil {
+		t.Errorf("expected error for too few selectable devices")
+	}
+}
+
+func TestIsSelectableState(t *testing.T) {
+	for state := device.StateNone; state <= device.StateQuarantined; state++ {
+		expected := state == device.StateBooted || state == device.StateShutdown
+		if isSelectableState(state) != expected {
+			t.Errorf("state %s expected selectable %v", device.StateToString(state), expected)
+		}
+	}
+}
+
+func TestSelectDevicesSkipsNodesInMaintenance(t *testing.T) {
+	s := newTestService(t, map[string]*testDevice{
+		"draining": {state: device.StateBooted},
+		"drained":  {state: device.StateBooted},
+		"free":     {state: device.StateBooted},
+	})
+	for _, maintenance := range []models.NodeMaintenanceState{models.NodeMaintenanceDraining, models.NodeMaintenanceDrained, models.NodeMaintenanceNone} {
+		identifier := string(maintenance)
+		if maintenance == models.NodeMaintenanceNone {
+			identifier = "free"
+		}
+		node := models.Node{Identifier: identifier, Maintenance: maintenance}
+		s.db.Create(&node)
+		s.db.Model(&models.Device{}).Where("device_identifier = ?", identifier).Update("node_id", node.ID)
+	}
+
+	devices, err := s.selectDevices("os=android")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selected := selectedIdentifiers(devices)
+	if len(selected) != 1 || !selected["free"] {
+		t.Errorf("expected only the device of the node without maintenance got %v", selected)
+	}
+}
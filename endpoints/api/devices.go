@@ -5,6 +5,7 @@ import (
 	"fmt"
 	device2 "github.com/fsuhrau/automationhub/device"
 	"github.com/fsuhrau/automationhub/hub/action"
+	"github.com/fsuhrau/automationhub/storage"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/fsuhrau/automationhub/tester/unity"
 	"github.com/gin-gonic/gin"
@@ -51,7 +52,15 @@ func (s *Service) getDevices(c *gin.Context, project *models.Project) {
 		return
 	}
 
+	reservations, err := storage.GetActiveReservations(s.db)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	for i := range devices {
+		devices[i].Reservation = reservations[devices[i].ID]
+
 		dev, _ := s.devicesManager.GetDevice(devices[i].DeviceIdentifier)
 		devices[i].Dev = dev
 
@@ -93,6 +102,28 @@ func (s *Service) unlockDevice(c *gin.Context, project *models.Project) {
 	c.JSON(http.StatusOK, device)
 }
 
+func (s *Service) releaseQuarantine(c *gin.Context, project *models.Project) {
+	deviceID := c.Param("device_id")
+
+	var device models.Device
+	if err := s.db.First(&device, deviceID).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	dev, _ := s.devicesManager.GetDevice(device.DeviceIdentifier)
+	if dev == nil {
+		s.error(c, http.StatusNotFound, device2.DeviceNotFoundError)
+		return
+	}
+
+	if err := s.devicesManager.ReleaseQuarantine(dev); err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, device)
+}
+
 func (s *Service) getDevice(c *gin.Context, project *models.Project) {
 
 	deviceID := c.Param("device_id")
@@ -104,6 +135,13 @@ func (s *Service) getDevice(c *gin.Context, project *models.Project) {
 		return
 	}
 
+	reservation, err := storage.GetActiveReservation(s.db, device.ID)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	device.Reservation = reservation
+
 	dev, _ := s.devicesManager.GetDevice(device.DeviceIdentifier)
 	device.Dev = dev
 	device.Status = device2.StateUnknown
@@ -172,6 +210,12 @@ func (s *Service) updateDevice(c *gin.Context, project *models.Project) {
 		s.error(c, http.StatusInternalServerError, err)
 		return
 	}
+
+	// labels can be cleared so Updates would skip the empty value
+	if err := s.db.Model(&device).Update("labels", dev.Labels).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
 	c.Status(http.StatusOK)
 }
 
@@ -221,7 +265,7 @@ func (s *Service) deviceRunTests(c *gin.Context, project *models.Project) {
 	if false {
 		var testsAction action.TestsGet
 		s.devicesManager.SendAction(dev, &testsAction)
-		logrus.Info("tests %v", testsAction)
+		logrus.Infof("tests %v", testsAction)
 		time.Sleep(2 * time.Minute)
 		for _, test := range testsAction.Tests {
 			runTestAction := action.TestStart{
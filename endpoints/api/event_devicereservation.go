@@ -0,0 +1,25 @@
+package api
+
+import (
+	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/hub/sse"
+)
+
+type deviceReservationNotifier struct {
+	publisher sse.Publisher
+}
+
+func RegisterEventDeviceReservationListener(publisher sse.Publisher) {
+	notifier := deviceReservationNotifier{
+		publisher: publisher,
+	}
+
+	events.DeviceReservationChanged.Register(notifier)
+}
+
+func (u deviceReservationNotifier) Handle(payload events.DeviceReservationChangedPayload) {
+	u.publisher.PublishEvent(sse.Event{
+		Channel: "device_reservations",
+		Content: payload,
+	})
+}
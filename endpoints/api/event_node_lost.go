@@ -0,0 +1,40 @@
+package api
+
+import (
+	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/hub/sse"
+	"github.com/fsuhrau/automationhub/tester"
+)
+
+type nodeLostNotifier struct {
+	service *Service
+}
+
+func RegisterEventNodeLostListener(service *Service) {
+	notifier := nodeLostNotifier{
+		service: service,
+	}
+	events.NodeLost.Register(notifier)
+}
+
+func (u nodeLostNotifier) Handle(payload events.NodeLostPayload) {
+	u.service.logger.Warnf("node %s lost: %s", payload.NodeIdentifier, payload.Reason)
+
+	u.service.runnersMutex.Lock()
+	var handlers []tester.NodeLostHandler
+	for _, runner := range u.service.runners {
+		if handler, ok := runner.(tester.NodeLostHandler); ok {
+			handlers = append(handlers, handler)
+		}
+	}
+	u.service.runnersMutex.Unlock()
+
+	for _, handler := range handlers {
+		handler.NodeLost(payload.NodeIdentifier, payload.Reason, u.service.cfg.NodeHeartbeat.Reschedule)
+	}
+
+	u.service.PublishEvent(sse.Event{
+		Channel: "nodes",
+		Content: payload,
+	})
+}
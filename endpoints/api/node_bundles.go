@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+func (s *Service) getBundleNode(c *gin.Context) (manager.NodeIdentifier, bool) {
+	var node models.Node
+	if err := s.db.First(&node, c.Param("node_id")).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return "", false
+	}
+	return manager.NodeIdentifier(node.Identifier), true
+}
+
+func (s *Service) getNodeBundles(c *gin.Context) {
+	node, ok := s.getBundleNode(c)
+	if !ok {
+		return
+	}
+
+	bundles, err := s.nodeManager.GetBundles(node)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundles)
+}
+
+func (s *Service) pinNodeBundle(c *gin.Context) {
+	s.setNodeBundlePinned(c, true)
+}
+
+func (s *Service) unpinNodeBundle(c *gin.Context) {
+	s.setNodeBundlePinned(c, false)
+}
+
+func (s *Service) setNodeBundlePinned(c *gin.Context, pinned bool) {
+	node, ok := s.getBundleNode(c)
+	if !ok {
+		return
+	}
+
+	if err := s.nodeManager.PinBundle(node, c.Param("hash"), pinned); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (s *Service) deleteNodeBundle(c *gin.Context) {
+	node, ok := s.getBundleNode(c)
+	if !ok {
+		return
+	}
+
+	evicted, err := s.nodeManager.EvictBundles(node, []string{c.Param("hash")}, manager.BundlePolicy{})
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, evicted)
+}
+
+func (s *Service) evictNodeBundles(c *gin.Context) {
+	node, ok := s.getBundleNode(c)
+	if !ok {
+		return
+	}
+
+	type Request struct {
+		MaxSizeMB    int64  `json:"maxSizeMB"`
+		MaxAge       string `json:"maxAge"`
+		KeepVersions int    `json:"keepVersions"`
+	}
+	var req Request
+	if err := c.Bind(&req); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	policy := manager.BundlePolicy{
+		MaxSize:      req.MaxSizeMB * 1024 * 1024,
+		KeepVersions: req.KeepVersions,
+	}
+	if len(req.MaxAge) > 0 {
+		var err error
+		policy.MaxAge, err = time.ParseDuration(req.MaxAge)
+		if err != nil || policy.MaxAge <= 0 {
+			s.error(c, http.StatusBadRequest, fmt.Errorf("invalid max age: %s", req.MaxAge))
+			return
+		}
+	}
+
+	if policy.MaxSize <= 0 && policy.MaxAge <= 0 && policy.KeepVersions <= 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("no eviction policy given"))
+		return
+	}
+
+	evicted, err := s.nodeManager.EvictBundles(node, nil, policy)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, evicted)
+}
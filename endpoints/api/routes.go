@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/config"
 	"github.com/fsuhrau/automationhub/hub/manager"
 	"github.com/fsuhrau/automationhub/hub/sse"
@@ -20,12 +22,17 @@ type Service struct {
 	nodeUrl         string
 	sseBroker       *sse.Broker
 	cfg             config.Service
+	ca              *mtls.CA
 
 	runners      map[string]tester.Interface
 	runnersMutex sync.Mutex
+
+	runWaiter     *testRunWaiter
+	matrices      map[uint]context.CancelFunc
+	matricesMutex sync.Mutex
 }
 
-func New(logger *logrus.Logger, db *gorm.DB, nodeUrl string, dm manager.Devices, sm manager.Sessions, config config.Service, nodeManager manager.Nodes) *Service {
+func New(logger *logrus.Logger, db *gorm.DB, nodeUrl string, dm manager.Devices, sm manager.Sessions, config config.Service, nodeManager manager.Nodes, ca *mtls.CA) *Service {
 	return &Service{
 		logger:          logger.WithField("Service", "Api"),
 		nodeUrl:         nodeUrl,
@@ -35,7 +42,10 @@ func New(logger *logrus.Logger, db *gorm.DB, nodeUrl string, dm manager.Devices,
 		nodeManager:     nodeManager,
 		sseBroker:       sse.NewBroker(),
 		cfg:             config,
+		ca:              ca,
 		runners:         make(map[string]tester.Interface),
+		runWaiter:       newTestRunWaiter(),
+		matrices:        make(map[uint]context.CancelFunc),
 	}
 }
 
@@ -56,6 +66,13 @@ func (s *Service) RegisterRoutes(r *gin.Engine, auth *gin.RouterGroup) error {
 	api.GET("/:project_id/settings/nodes", s.getNodes)
 	api.DELETE("/:project_id/settings/nodes/:node_id", s.deleteNode)
 	api.GET("/:project_id/settings/nodes/:node_id", s.getNodeStatus)
+	api.POST("/:project_id/settings/nodes/:node_id/drain", s.drainNode)
+	api.DELETE("/:project_id/settings/nodes/:node_id/drain", s.endNodeMaintenance)
+	api.GET("/:project_id/settings/nodes/:node_id/bundles", s.getNodeBundles)
+	api.POST("/:project_id/settings/nodes/:node_id/bundles/evict", s.evictNodeBundles)
+	api.DELETE("/:project_id/settings/nodes/:node_id/bundles/:hash", s.deleteNodeBundle)
+	api.POST("/:project_id/settings/nodes/:node_id/bundles/:hash/pin", s.pinNodeBundle)
+	api.DELETE("/:project_id/settings/nodes/:node_id/bundles/:hash/pin", s.unpinNodeBundle)
 
 	api.GET("/projects", s.getProjects)
 	api.POST("/project", s.createProject)
@@ -70,9 +87,14 @@ func (s *Service) RegisterRoutes(r *gin.Engine, auth *gin.RouterGroup) error {
 		projectApi.GET("/device/:device_id", s.WithProject(s.getDevice))
 		projectApi.DELETE("/device/:device_id", s.WithProject(s.deleteDevice))
 		projectApi.POST("/device/:device_id/unlock", s.WithProject(s.unlockDevice))
+		projectApi.POST("/device/:device_id/reserve", s.WithProject(s.reserveDevice))
+		projectApi.PUT("/device/:device_id/reserve", s.WithProject(s.extendReservation))
+		projectApi.DELETE("/device/:device_id/reserve", s.WithProject(s.releaseReservation))
+		projectApi.POST("/device/:device_id/quarantine/release", s.WithProject(s.releaseQuarantine))
 		projectApi.PUT("/device/:device_id", s.WithProject(s.updateDevice))
 		projectApi.POST("/device/:device_id/tests", s.WithProject(s.deviceRunTests))
 		projectApi.GET("/devices", s.WithProject(s.getDevices))
+		projectApi.GET("/devices/utilization", s.WithProject(s.getDeviceUtilization))
 
 		projectApi.POST("/app", s.WithProject(s.createApp))
 		projectApi.GET("/apps", s.WithProject(s.getApps))
@@ -92,10 +114,19 @@ func (s *Service) RegisterRoutes(r *gin.Engine, auth *gin.RouterGroup) error {
 			appApi.GET("/test/:test_id", s.WithApp(s.getTest))
 			appApi.PUT("/test/:test_id", s.WithApp(s.updateTest))
 			appApi.POST("/test/:test_id/run", s.WithApp(s.runTest))
+			appApi.POST("/test/:test_id/import", s.WithApp(s.importTestResults))
+			appApi.POST("/test/:test_id/matrix", s.WithApp(s.runTestMatrix))
+			appApi.GET("/test/:test_id/matrix/:matrix_id", s.WithApp(s.getTestMatrix))
+			appApi.POST("/test/:test_id/matrix/:matrix_id/cancel", s.WithApp(s.cancelTestMatrix))
+			appApi.GET("/test/:test_id/quarantine", s.WithApp(s.getTestQuarantines))
+			appApi.POST("/test/:test_id/quarantine", s.WithApp(s.quarantineTest))
+			appApi.PUT("/test/:test_id/quarantine/:quarantine_id", s.WithApp(s.extendTestQuarantine))
+			appApi.DELETE("/test/:test_id/quarantine/:quarantine_id", s.WithApp(s.releaseTestQuarantine))
 			appApi.GET("/test/:test_id/runs", s.WithApp(s.getTestRuns))
 			appApi.GET("/test/:test_id/runs/last", s.WithApp(s.getLastTestRun))
 			appApi.GET("/test/:test_id/run/:run_id", s.WithApp(s.getTestRun))
 			appApi.POST("/test/:test_id/run/:run_id/cancel", s.WithApp(s.cancelTestRun))
+			appApi.POST("/test/:test_id/run/:run_id/rerun-failed", s.WithApp(s.rerunFailedTests))
 			appApi.GET("/test/:test_id/run/:run_id/:protocol_id", s.WithApp(s.getTestRunProtocol))
 			appApi.GET("/tests", s.WithApp(s.getTests))
 		}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/storage"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
@@ -137,7 +138,29 @@ func (s *Service) createNode(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, newNode)
+	if s.ca == nil {
+		c.JSON(http.StatusCreated, newNode)
+		return
+	}
+
+	// the private key is not stored on the master, it can only be downloaded once
+	certificate, key, err := s.ca.IssueNodeCertificate(newNode.Identifier)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, struct {
+		models.Node
+		Certificate   string `json:"certificate"`
+		PrivateKey    string `json:"privateKey"`
+		CACertificate string `json:"caCertificate"`
+	}{
+		Node:          newNode,
+		Certificate:   string(certificate),
+		PrivateKey:    string(key),
+		CACertificate: string(s.ca.CertificatePEM()),
+	})
 }
 
 func (s *Service) getNodes(c *gin.Context) {
@@ -149,11 +172,27 @@ func (s *Service) getNodes(c *gin.Context) {
 
 	for i := range nodes {
 		nodes[i].Status, _ = s.nodeManager.GetStatus(manager.NodeIdentifier(nodes[i].Identifier))
+		s.setNodeVersion(&nodes[i])
+		if !nodes[i].AcceptsLocks() {
+			nodes[i].RunningTests, _ = storage.CountRunningProtocols(s.db, nodes[i].ID)
+		}
 	}
 
 	c.JSON(http.StatusOK, nodes)
 }
 
+// setNodeVersion fills the version and resource usage of connected nodes
+func (s *Service) setNodeVersion(node *models.Node) {
+	version, err := s.nodeManager.GetVersion(manager.NodeIdentifier(node.Identifier))
+	if err != nil {
+		return
+	}
+	node.Version = version.Version
+	node.ProtocolVersion = version.ProtocolVersion
+	node.Features = version.Features
+	node.Metrics, _ = s.nodeManager.GetMetrics(manager.NodeIdentifier(node.Identifier))
+}
+
 func (s *Service) deleteNode(c *gin.Context) {
 	nodeId := c.Param("node_id")
 
@@ -180,7 +219,73 @@ func (s *Service) getNodeStatus(c *gin.Context) {
 		return
 	}
 
-	// TODO add node status
+	node.Status, _ = s.nodeManager.GetStatus(manager.NodeIdentifier(node.Identifier))
+	s.setNodeVersion(&node)
+
+	var err error
+	node.RunningTests, err = storage.CountRunningProtocols(s.db, node.ID)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, node)
+}
+
+func (s *Service) drainNode(c *gin.Context) {
+	nodeId := c.Param("node_id")
+
+	type Request struct {
+		Deadline string `json:"deadline"`
+		Reason   string `json:"reason"`
+	}
+	var req Request
+	if c.Request.ContentLength > 0 {
+		if err := c.Bind(&req); err != nil {
+			s.error(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	var deadline time.Duration
+	if len(req.Deadline) > 0 {
+		var err error
+		deadline, err = time.ParseDuration(req.Deadline)
+		if err != nil || deadline <= 0 {
+			s.error(c, http.StatusBadRequest, fmt.Errorf("invalid deadline: %s", req.Deadline))
+			return
+		}
+	}
+
+	var node models.Node
+	if err := s.db.First(&node, nodeId).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	if err := s.nodeManager.Drain(manager.NodeIdentifier(node.Identifier), deadline, req.Reason); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	s.db.First(&node, node.ID)
+	c.JSON(http.StatusOK, node)
+}
+
+func (s *Service) endNodeMaintenance(c *gin.Context) {
+	nodeId := c.Param("node_id")
+
+	var node models.Node
+	if err := s.db.First(&node, nodeId).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	if err := s.nodeManager.EndMaintenance(manager.NodeIdentifier(node.Identifier)); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
 
+	s.db.First(&node, node.ID)
 	c.JSON(http.StatusOK, node)
 }
@@ -12,12 +12,14 @@ func (s *Service) PublishEvent(event sse.Event) {
 
 func (s *Service) initSSE(api *gin.RouterGroup) {
 	RegisterEventDeviceStatusListener(s)
+	RegisterEventDeviceReservationListener(s)
 	RegisterEventAppCreatedListener(s)
 	RegisterEventTestCreatedListener(s)
 	RegisterNewTestLogEntryListener(s)
 	RegisterNewTestProtocolListener(s)
 	RegisterNewTestProtocolLogListener(s)
 	RegisterEventTestRunFinishedListener(s)
+	RegisterEventNodeLostListener(s)
 
 	sseApi := api.Group("/sse")
 	sseApi.GET("/", sse.HeadersMiddleware(), s.sseBroker.ServeHTTP(), func(c *gin.Context) {
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/fsuhrau/automationhub/tester/protocol"
+	"github.com/fsuhrau/automationhub/tools/testresults"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// importTestResults creates a test run from a NUnit, xUnit or JUnit result file of tests executed outside the hub,
+// the form contains the file as results and optionally format, appBinaryId, deviceId and params
+func (s *Service) importTestResults(c *gin.Context, project *models.Project, application *models.App) {
+	testId := c.Param("test_id")
+
+	file, err := c.FormFile("results")
+	if err != nil {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("get form err: %s", err.Error()))
+		return
+	}
+	reader, err := file.Open()
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := testresults.Parse(c.PostForm("format"), data)
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var test models.Test
+	if err := s.db.Where("app_id = ?", application.ID).First(&test, testId).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	var binaryId uint
+	if id := c.PostForm("appBinaryId"); len(id) > 0 {
+		var binary models.AppBinary
+		if err := s.db.Where("app_id = ?", application.ID).First(&binary, id).Error; err != nil {
+			s.error(c, http.StatusNotFound, err)
+			return
+		}
+		binaryId = binary.ID
+	}
+
+	var dev *models.Device
+	if id := c.PostForm("deviceId"); len(id) > 0 {
+		dev = &models.Device{}
+		if err := s.db.Where("device_identifier = ?", id).First(dev).Error; err != nil {
+			s.error(c, http.StatusNotFound, err)
+			return
+		}
+	}
+
+	var params []string
+	for k, v := range extractParams(c.PostForm("params")) {
+		params = append(params, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(params)
+
+	sessionID, _ := uuid.NewV4()
+	run := models.TestRun{
+		TestID:      test.ID,
+		AppBinaryID: binaryId,
+		SessionID:   sessionID.String(),
+		Parameter:   strings.Join(params, "\n"),
+	}
+	if err := s.db.Create(&run).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	writer := protocol.NewProtocolWriter(s.db, project.Identifier, application.ID, test.Name, &run)
+	if err := writer.Import(report, dev); err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, run)
+}
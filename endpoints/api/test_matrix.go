@@ -0,0 +1,261 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/fsuhrau/automationhub/tester"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// testRunWaiter notifies about finished runs, it stays locked while a run is started so that
+// the run can't finish before its waiter is registered
+type testRunWaiter struct {
+	mutex   sync.Mutex
+	waiting map[uint]chan struct{}
+}
+
+func newTestRunWaiter() *testRunWaiter {
+	waiter := &testRunWaiter{
+		waiting: make(map[uint]chan struct{}),
+	}
+	events.TestRunFinished.Register(waiter)
+	return waiter
+}
+
+func (w *testRunWaiter) Handle(payload events.TestRunFinishedPayload) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if finished, ok := w.waiting[payload.TestRunID]; ok {
+		close(finished)
+		delete(w.waiting, payload.TestRunID)
+	}
+}
+
+// start runs the test runner and returns a channel which is closed when the run finished
+func (w *testRunWaiter) start(testRunner tester.Interface, devices []models.Device, binary *models.AppBinary, startURL string) (*models.TestRun, <-chan struct{}, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	run, err := testRunner.Run(devices, binary, startURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	finished := make(chan struct{})
+	w.waiting[run.ID] = finished
+	return run, finished, nil
+}
+
+type RunTestMatrixRequest struct {
+	AppBinaryID int    `json:"appBinaryId"`
+	StartURL    string `json:"startUrl"`
+	Params      string `json:"params"`
+	Matrix      string `json:"matrix"` // e.g. graphics=[low,high];locale=[en,de,ja]
+}
+
+// validateMatrixDimensions checks the values of dimensions which are option parameters of the app
+func (s *Service) validateMatrixDimensions(application *models.App, dimensions models.MatrixDimensions) error {
+	var parameters []models.AppParameter
+	if err := s.db.Where("app_id = ?", application.ID).Find(&parameters).Error; err != nil {
+		return err
+	}
+
+	options := make(map[string][]string)
+	for _, p := range parameters {
+		var option models.AppParameterOption
+		if err := json.Unmarshal([]byte(p.Type), &option); err == nil && option.Type == "option" {
+			options[p.Name] = option.Options
+		}
+	}
+
+	for _, dimension := range dimensions {
+		allowed, ok := options[dimension.Name]
+		if !ok {
+			continue
+		}
+		for _, value := range dimension.Values {
+			valid := false
+			for _, o := range allowed {
+				if o == value {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return fmt.Errorf("invalid value %s for parameter %s, options are %v", value, dimension.Name, allowed)
+			}
+		}
+	}
+	return nil
+}
+
+// runTestMatrix starts a run of the test for every combination of the matrix, the combinations are executed
+// one after another because every run locks the devices of the test
+func (s *Service) runTestMatrix(c *gin.Context, project *models.Project, application *models.App) {
+	testId := c.Param("test_id")
+	var req RunTestMatrixRequest
+	if err := c.Bind(&req); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var test models.Test
+	if err := s.db.Where("app_id = ?", application.ID).First(&test, testId).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	dimensions, err := models.ParseMatrixDimensions(req.Matrix)
+	if err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.validateMatrixDimensions(application, dimensions); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// prepare the first combination to report configuration errors before the matrix starts
+	combinations := dimensions.Combinations()
+	env := matrixParameter(extractParams(req.Params), dimensions.Parameter(combinations[0]))
+	testRunner, _, _, status, err := s.prepareTestRun(project, application, testId, uint(req.AppBinaryID), env)
+	if err != nil {
+		s.error(c, status, err)
+		return
+	}
+	if _, ok := testRunner.(tester.MatrixRunner); !ok {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("test type does not support matrix runs"))
+		return
+	}
+
+	matrix := models.TestMatrix{
+		TestID:      test.ID,
+		AppBinaryID: uint(req.AppBinaryID),
+		StartURL:    req.StartURL,
+		Parameter:   req.Params,
+		Dimensions:  dimensions.String(),
+	}
+	if err := s.db.Create(&matrix).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.matricesMutex.Lock()
+	s.matrices[matrix.ID] = cancel
+	s.matricesMutex.Unlock()
+
+	go s.executeTestMatrix(ctx, project, application, testId, matrix, dimensions)
+
+	summary, err := models.NewTestMatrixSummary(&matrix)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+func matrixParameter(params map[string]string, combination map[string]string) map[string]string {
+	env := make(map[string]string)
+	for k, v := range params {
+		env[k] = v
+	}
+	for k, v := range combination {
+		env[k] = v
+	}
+	return env
+}
+
+// executeTestMatrix runs the combinations of the matrix and waits for each run to finish
+func (s *Service) executeTestMatrix(ctx context.Context, project *models.Project, application *models.App, testId string, matrix models.TestMatrix, dimensions models.MatrixDimensions) {
+	defer func() {
+		s.matricesMutex.Lock()
+		delete(s.matrices, matrix.ID)
+		s.matricesMutex.Unlock()
+
+		now := time.Now()
+		if err := s.db.Model(&matrix).Update("finished_at", &now).Error; err != nil {
+			s.logger.Errorf("unable to finish matrix %d: %v", matrix.ID, err)
+		}
+	}()
+
+	params := extractParams(matrix.Parameter)
+	for _, combination := range dimensions.Combinations() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		key := dimensions.Key(combination)
+		testRunner, devices, binary, _, err := s.prepareTestRun(project, application, testId, matrix.AppBinaryID, matrixParameter(params, dimensions.Parameter(combination)))
+		if err != nil {
+			s.logger.Errorf("unable to prepare combination %s of matrix %d: %v", key, matrix.ID, err)
+			continue
+		}
+		testRunner.(tester.MatrixRunner).MatrixRun(matrix.ID, key)
+
+		s.runnersMutex.Lock()
+		s.runners[testId] = testRunner
+		s.runnersMutex.Unlock()
+
+		run, finished, err := s.runWaiter.start(testRunner, devices, binary, matrix.StartURL)
+		if err != nil {
+			s.logger.Errorf("unable to run combination %s of matrix %d: %v", key, matrix.ID, err)
+			continue
+		}
+
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			if err := testRunner.Cancel(fmt.Sprintf("%d", run.ID)); err != nil {
+				s.logger.Errorf("unable to cancel combination %s of matrix %d: %v", key, matrix.ID, err)
+			}
+			<-finished
+		}
+	}
+}
+
+func (s *Service) getTestMatrix(c *gin.Context, project *models.Project, application *models.App) {
+	testId := c.Param("test_id")
+	matrixId := c.Param("matrix_id")
+
+	var matrix models.TestMatrix
+	if err := s.db.Preload("Runs").Preload("Runs.Protocols").Where("test_id = ?", testId).First(&matrix, matrixId).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	summary, err := models.NewTestMatrixSummary(&matrix)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+func (s *Service) cancelTestMatrix(c *gin.Context, project *models.Project, application *models.App) {
+	testId := c.Param("test_id")
+	matrixId := c.Param("matrix_id")
+
+	var matrix models.TestMatrix
+	if err := s.db.Where("test_id = ?", testId).First(&matrix, matrixId).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return
+	}
+
+	s.matricesMutex.Lock()
+	cancel, exists := s.matrices[matrix.ID]
+	s.matricesMutex.Unlock()
+
+	if !exists {
+		s.error(c, http.StatusNotFound, fmt.Errorf("matrix %d is not running", matrix.ID))
+		return
+	}
+	cancel()
+
+	c.JSON(http.StatusOK, gin.H{"status": "cancelled"})
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type QuarantineRequest struct {
+	TestName            string `json:"testName"`
+	UnityTestFunctionID *uint  `json:"unityTestFunctionId"`
+	Duration            string `json:"duration"`
+	Owner               string `json:"owner"`
+	Reason              string `json:"reason"`
+}
+
+func (s *Service) getAppTest(c *gin.Context, application *models.App) (*models.Test, bool) {
+	var test models.Test
+	if err := s.db.Where("app_id = ?", application.ID).First(&test, c.Param("test_id")).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return nil, false
+	}
+	return &test, true
+}
+
+func (s *Service) getActiveQuarantine(c *gin.Context, test *models.Test) (*models.TestQuarantine, bool) {
+	var quarantine models.TestQuarantine
+	if err := s.db.Where("test_id = ? and released_at is null and expires_at > ?", test.ID, time.Now()).First(&quarantine, c.Param("quarantine_id")).Error; err != nil {
+		s.error(c, http.StatusNotFound, err)
+		return nil, false
+	}
+	return &quarantine, true
+}
+
+func (s *Service) getTestQuarantines(c *gin.Context, project *models.Project, application *models.App) {
+	test, ok := s.getAppTest(c, application)
+	if !ok {
+		return
+	}
+
+	quarantines, err := storage.GetActiveQuarantines(s.db, test.ID)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quarantines)
+}
+
+// quarantineTest puts a unity test function, a test by its protocol name or the whole test into quarantine
+func (s *Service) quarantineTest(c *gin.Context, project *models.Project, application *models.App) {
+	var req QuarantineRequest
+	if err := c.Bind(&req); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("invalid duration: %s", req.Duration))
+		return
+	}
+
+	owner := strings.TrimSpace(req.Owner)
+	if len(owner) == 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("missing owner"))
+		return
+	}
+
+	test, ok := s.getAppTest(c, application)
+	if !ok {
+		return
+	}
+
+	testName := strings.TrimSpace(req.TestName)
+	if req.UnityTestFunctionID != nil {
+		var function models.UnityTestFunction
+		if err := s.db.Joins("JOIN test_config_unities ON test_config_unities.id = unity_test_functions.test_config_unity_id").
+			Joins("JOIN test_configs ON test_configs.id = test_config_unities.test_config_id").
+			Where("test_configs.test_id = ?", test.ID).
+			First(&function, *req.UnityTestFunctionID).Error; err != nil {
+			s.error(c, http.StatusNotFound, err)
+			return
+		}
+		// protocols are named after the method without its return type
+		method := function.Method
+		if parts := strings.Split(method, " "); len(parts) > 1 {
+			method = parts[1]
+		}
+		testName = fmt.Sprintf("%s/%s", function.Class, method)
+	}
+
+	quarantines, err := storage.GetActiveQuarantines(s.db, test.ID)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+	for _, q := range quarantines {
+		if q.TestName == testName {
+			s.error(c, http.StatusConflict, fmt.Errorf("test is quarantined by %s until %s", q.Owner, q.ExpiresAt.Format(time.RFC3339)))
+			return
+		}
+	}
+
+	quarantine := models.TestQuarantine{
+		TestID:              test.ID,
+		UnityTestFunctionID: req.UnityTestFunctionID,
+		TestName:            testName,
+		Owner:               owner,
+		Reason:              req.Reason,
+		ExpiresAt:           time.Now().Add(duration),
+	}
+	if err := s.db.Create(&quarantine).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, quarantine)
+}
+
+func (s *Service) extendTestQuarantine(c *gin.Context, project *models.Project, application *models.App) {
+	var req QuarantineRequest
+	if err := c.Bind(&req); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("invalid duration: %s", req.Duration))
+		return
+	}
+
+	test, ok := s.getAppTest(c, application)
+	if !ok {
+		return
+	}
+	quarantine, ok := s.getActiveQuarantine(c, test)
+	if !ok {
+		return
+	}
+
+	quarantine.ExpiresAt = quarantine.ExpiresAt.Add(duration)
+	if len(req.Reason) > 0 {
+		quarantine.Reason = req.Reason
+	}
+	if owner := strings.TrimSpace(req.Owner); len(owner) > 0 {
+		quarantine.Owner = owner
+	}
+	if err := s.db.Save(quarantine).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quarantine)
+}
+
+func (s *Service) releaseTestQuarantine(c *gin.Context, project *models.Project, application *models.App) {
+	test, ok := s.getAppTest(c, application)
+	if !ok {
+		return
+	}
+	quarantine, ok := s.getActiveQuarantine(c, test)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	quarantine.ReleasedAt = &now
+	if err := s.db.Save(quarantine).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quarantine)
+}
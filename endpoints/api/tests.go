@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	device2 "github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/storage"
 	"github.com/fsuhrau/automationhub/storage/apps"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/fsuhrau/automationhub/tester"
@@ -33,6 +35,7 @@ func (s *Service) newTest(c *gin.Context, project *models.Project, application *
 		Assembly string `json:"assembly"`
 		Class    string `json:"class"`
 		Method   string `json:"method"`
+		Timeout  int64  `json:"timeout"`
 	}
 	type Request struct {
 		Name                  string                       `json:"name"`
@@ -43,7 +46,10 @@ func (s *Service) newTest(c *gin.Context, project *models.Project, application *
 		UnitySelectedTests    []TestFunc                   `json:"unitySelectedTests"`
 		AllDevices            bool                         `json:"allDevices"`
 		SelectedDevices       []uint                       `json:"selectedDevices"`
+		DeviceSelector        string                       `json:"deviceSelector"`
 		Categories            []string                     `json:"categories"`
+		TestTimeout           uint                         `json:"testTimeout"`
+		RunTimeout            uint                         `json:"runTimeout"`
 	}
 
 	var request Request
@@ -73,11 +79,16 @@ func (s *Service) newTest(c *gin.Context, project *models.Project, application *
 		return
 	}
 
-	if request.AllDevices == false && len(request.SelectedDevices) == 0 {
+	if request.AllDevices == false && len(request.SelectedDevices) == 0 && len(request.DeviceSelector) == 0 {
 		s.error(c, http.StatusBadRequest, fmt.Errorf("unsupported Test Type"))
 		return
 	}
 
+	if _, err := models.ParseDeviceSelector(request.DeviceSelector); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
 	tx := s.db.Begin()
 	defer func() {
 		tx.Rollback()
@@ -92,10 +103,13 @@ func (s *Service) newTest(c *gin.Context, project *models.Project, application *
 	}
 
 	config := models.TestConfig{
-		TestID:        test.ID,
-		Type:          request.TestType,
-		AllDevices:    request.AllDevices,
-		ExecutionType: request.ExecutionType,
+		TestID:         test.ID,
+		Type:           request.TestType,
+		AllDevices:     request.AllDevices,
+		DeviceSelector: strings.TrimSpace(request.DeviceSelector),
+		ExecutionType:  request.ExecutionType,
+		TestTimeout:    request.TestTimeout,
+		RunTimeout:     request.RunTimeout,
 	}
 	if err := tx.Create(&config).Error; err != nil {
 		s.error(c, http.StatusInternalServerError, err)
@@ -122,6 +136,7 @@ func (s *Service) newTest(c *gin.Context, project *models.Project, application *
 				Assembly:          r.Assembly,
 				Class:             r.Class,
 				Method:            r.Method,
+				Timeout:           r.Timeout,
 			}
 			if err := tx.Create(&function).Error; err != nil {
 				s.error(c, http.StatusInternalServerError, err)
@@ -165,14 +180,22 @@ func (s *Service) updateTest(c *gin.Context, project *models.Project, applicatio
 		ExecutionType         models.ExecutionType         `json:"executionType"`
 		AllDevices            bool                         `json:"allDevices"`
 		Devices               []uint                       `json:"devices"`
+		DeviceSelector        string                       `json:"deviceSelector"`
 		UnityTestCategoryType models.UnityTestCategoryType `json:"unityTestCategoryType"`
 		Categories            string                       `json:"categories"`
 		TestFunctions         []models.UnityTestFunction   `json:"testFunctions"`
+		TestTimeout           uint                         `json:"testTimeout"`
+		RunTimeout            uint                         `json:"runTimeout"`
 	}
 
 	var req request
 	c.Bind(&req)
 
+	if _, err := models.ParseDeviceSelector(req.DeviceSelector); err != nil {
+		s.error(c, http.StatusBadRequest, err)
+		return
+	}
+
 	var test models.Test
 	if err := s.db.Preload("TestConfig").Preload("TestConfig.Devices").Preload("TestConfig.Devices.Device").Preload("TestConfig.Unity").Preload("TestConfig.Unity.UnityTestFunctions").First(&test, testId).Error; err != nil {
 		s.error(c, http.StatusNotFound, err)
@@ -182,6 +205,9 @@ func (s *Service) updateTest(c *gin.Context, project *models.Project, applicatio
 	test.Name = req.Name
 	test.TestConfig.ExecutionType = req.ExecutionType
 	test.TestConfig.AllDevices = req.AllDevices
+	test.TestConfig.DeviceSelector = strings.TrimSpace(req.DeviceSelector)
+	test.TestConfig.TestTimeout = req.TestTimeout
+	test.TestConfig.RunTimeout = req.RunTimeout
 
 	if req.AllDevices {
 		// since all devices are selected we don't need to specify them
@@ -252,9 +278,16 @@ func (s *Service) updateTest(c *gin.Context, project *models.Project, applicatio
 
 		for _, reqFunc := range req.TestFunctions {
 			needCreation := true
-			for _, extFunc := range test.TestConfig.Unity.UnityTestFunctions {
+			for i, extFunc := range test.TestConfig.Unity.UnityTestFunctions {
 				if extFunc.Assembly == reqFunc.Assembly && extFunc.Class == reqFunc.Class && extFunc.Method == reqFunc.Method {
 					needCreation = false
+					// clients which don't know the timeout of a test send none
+					if reqFunc.Timeout > 0 && extFunc.Timeout != reqFunc.Timeout {
+						if err := s.db.Model(&test.TestConfig.Unity.UnityTestFunctions[i]).Update("timeout", reqFunc.Timeout).Error; err != nil {
+							s.error(c, http.StatusBadRequest, err)
+							return
+						}
+					}
 				}
 			}
 
@@ -264,6 +297,7 @@ func (s *Service) updateTest(c *gin.Context, project *models.Project, applicatio
 					Assembly:          reqFunc.Assembly,
 					Class:             reqFunc.Class,
 					Method:            reqFunc.Method,
+					Timeout:           reqFunc.Timeout,
 				}
 				if err := s.db.Create(&newFunction).Error; err != nil {
 					s.error(c, http.StatusBadRequest, err)
@@ -323,33 +357,112 @@ func (s *Service) runTest(c *gin.Context, project *models.Project, application *
 		return
 	}
 
-	environmentParams := extractParams(req.Params)
+	s.startTestRun(c, project, application, testId, uint(req.AppBinaryID), req.StartURL, extractParams(req.Params), nil)
+}
 
-	var test models.Test
-	if err := s.db.Preload("App").Preload("TestConfig").Preload("TestConfig.Devices").First(&test, testId).Error; err != nil {
+type testRerun struct {
+	runID     uint
+	testNames []string
+}
+
+// rerunFailedTests starts a new run with the binary and parameters of a previous run which only contains
+// the tests that failed or were unstable in it, the new run references the previous one
+func (s *Service) rerunFailedTests(c *gin.Context, project *models.Project, application *models.App) {
+	testId := c.Param("test_id")
+	runId := c.Param("run_id")
+
+	var run models.TestRun
+	if err := s.db.Preload("Protocols").Where("test_id = ?", testId).First(&run, runId).Error; err != nil {
 		s.error(c, http.StatusNotFound, err)
 		return
 	}
 
+	rerun := &testRerun{runID: run.ID}
+	known := make(map[string]bool)
+	for _, p := range run.Protocols {
+		switch p.TestResult {
+		case models.TestResultFailed, models.TestResultUnstable, models.TestResultNodeLost, models.TestResultTimedOut:
+			if !known[p.TestName] {
+				known[p.TestName] = true
+				rerun.testNames = append(rerun.testNames, p.TestName)
+			}
+		}
+	}
+	if len(rerun.testNames) == 0 {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("run %d has no failed tests", run.ID))
+		return
+	}
+
+	env := make(map[string]string)
+	for _, p := range strings.FieldsFunc(run.Parameter, func(r rune) bool { return r == '\n' || r == ';' }) {
+		if key, value, ok := strings.Cut(p, "="); ok {
+			env[key] = value
+		}
+	}
+
+	s.startTestRun(c, project, application, testId, run.AppBinaryID, run.StartURL, env, rerun)
+}
+
+// startTestRun starts a runner for the test on the configured devices, a rerun limits the run to the given tests
+func (s *Service) startTestRun(c *gin.Context, project *models.Project, application *models.App, testId string, binaryId uint, startURL string, environmentParams map[string]string, rerun *testRerun) {
+	testRunner, devices, binary, status, err := s.prepareTestRun(project, application, testId, binaryId, environmentParams)
+	if err != nil {
+		s.error(c, status, err)
+		return
+	}
+
+	if rerun != nil {
+		rerunner, ok := testRunner.(tester.Rerunner)
+		if !ok {
+			s.error(c, http.StatusBadRequest, fmt.Errorf("test type does not support reruns"))
+			return
+		}
+		rerunner.RerunOf(rerun.runID, rerun.testNames)
+	}
+
+	s.runnersMutex.Lock()
+	s.runners[testId] = testRunner
+	s.runnersMutex.Unlock()
+
+	run, err := testRunner.Run(devices, binary, startURL)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err) // Todo status code
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// prepareTestRun initializes a runner for the test and selects the devices and binary to run it with,
+// on failure it returns the http status for the error
+func (s *Service) prepareTestRun(project *models.Project, application *models.App, testId string, binaryId uint, environmentParams map[string]string) (tester.Interface, []models.Device, *models.AppBinary, int, error) {
+	var test models.Test
+	if err := s.db.Preload("App").Preload("TestConfig").Preload("TestConfig.Devices").First(&test, testId).Error; err != nil {
+		return nil, nil, nil, http.StatusNotFound, err
+	}
+
 	var binary *models.AppBinary
 	if test.App.Platform != models.PlatformTypeEditor && test.App.Platform != models.PlatformTypeWeb {
 		binary = &models.AppBinary{}
-		if err := s.db.Preload("App").First(binary, req.AppBinaryID).Error; err != nil {
-			s.error(c, http.StatusNotFound, err)
-			return
+		if err := s.db.Preload("App").First(binary, binaryId).Error; err != nil {
+			return nil, nil, nil, http.StatusNotFound, err
 		}
 	}
 
 	var devices []models.Device
-	if test.TestConfig.AllDevices {
+	if len(test.TestConfig.DeviceSelector) > 0 {
+		var err error
+		if devices, err = s.selectDevices(test.TestConfig.DeviceSelector); err != nil {
+			return nil, nil, nil, http.StatusNotFound, err
+		}
+	} else if test.TestConfig.AllDevices {
 		if err := s.db.Find(&devices).Error; err != nil {
-			s.error(c, http.StatusNotFound, err)
-			return
+			return nil, nil, nil, http.StatusNotFound, err
 		}
+		devices = s.spreadByLoad(devices)
 	} else {
 		if err := s.db.Find(&devices, test.TestConfig.GetDeviceIds()).Error; err != nil {
-			s.error(c, http.StatusNotFound, err)
-			return
+			return nil, nil, nil, http.StatusNotFound, err
 		}
 	}
 
@@ -357,42 +470,88 @@ func (s *Service) runTest(c *gin.Context, project *models.Project, application *
 		devices[i].Dev, _ = s.devicesManager.GetDevice(devices[i].DeviceIdentifier)
 	}
 
-	var run *models.TestRun
 	var testRunner tester.Interface
 
 	switch test.TestConfig.Type {
 	case models.TestTypeUnity:
 		testRunner = unity.New(s.db, s.nodeUrl, s.devicesManager, s, project.Identifier, application.ID)
 		if err := s.db.Preload("UnityTestFunctions").Where("test_config_id = ?", test.TestConfig.ID).First(&test.TestConfig.Unity).Error; err != nil {
-			s.error(c, http.StatusInternalServerError, err) // Todo status code
-			return
+			return nil, nil, nil, http.StatusInternalServerError, err
 		}
 		break
 	case models.TestTypeScenario:
 		testRunner = scenario.New(s.db, s.nodeUrl, s.devicesManager, s, project.Identifier, application.ID)
 		break
 	default:
-		s.error(c, http.StatusInternalServerError, fmt.Errorf("invalid test config type")) // Todo status code
-		return
+		return nil, nil, nil, http.StatusInternalServerError, fmt.Errorf("invalid test config type")
 	}
 
 	if err := testRunner.Initialize(test, environmentParams); err != nil {
-		s.error(c, http.StatusInternalServerError, err) // Todo status code
-		return
+		return nil, nil, nil, http.StatusInternalServerError, err
 	}
 
-	s.runnersMutex.Lock()
-	s.runners[testId] = testRunner
-	s.runnersMutex.Unlock()
+	return testRunner, devices, binary, http.StatusOK, nil
+}
+
+// isSelectableState reports if a device in the state can be picked by a device selector, booted devices are
+// used as they are and shut down devices get booted by the test runner when the run starts
+func isSelectableState(state device2.State) bool {
+	switch state {
+	case device2.StateBooted, device2.StateShutdown:
+		return true
+	}
+	return false
+}
 
-	var err error
-	run, err = testRunner.Run(devices, binary, req.StartURL)
+// selectDevices returns all idle devices matching the selector
+func (s *Service) selectDevices(deviceSelector string) ([]models.Device, error) {
+	selector, err := models.ParseDeviceSelector(deviceSelector)
 	if err != nil {
-		s.error(c, http.StatusInternalServerError, err) // Todo status code
-		return
+		return nil, err
 	}
 
-	c.JSON(http.StatusOK, run)
+	var devices []models.Device
+	if err := s.db.Preload("CustomParameter").Preload("DeviceParameter").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	reservations, err := storage.GetActiveReservations(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenanceNodes, err := storage.GetMaintenanceNodes(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var idleDevices []models.Device
+	for i := range devices {
+		if _, reserved := reservations[devices[i].ID]; reserved {
+			continue
+		}
+		if maintenanceNodes[devices[i].NodeID] {
+			continue
+		}
+		dev, _ := s.devicesManager.GetDevice(devices[i].DeviceIdentifier)
+		if dev == nil || dev.IsLocked() {
+			continue
+		}
+		if !isSelectableState(dev.DeviceState()) {
+			continue
+		}
+		idleDevices = append(idleDevices, devices[i])
+	}
+
+	// prefer devices on nodes with spare capacity
+	selected := selector.Select(s.spreadByLoad(selector.Filter(idleDevices)))
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no idle device matches selector: %s", deviceSelector)
+	}
+	if selector.Count > 0 && len(selected) < selector.Count {
+		return nil, fmt.Errorf("only %d of %d requested devices match selector: %s", len(selected), selector.Count, deviceSelector)
+	}
+	return selected, nil
 }
 
 func (s *Service) cancelTestRun(c *gin.Context, project *models.Project, application *models.App) {
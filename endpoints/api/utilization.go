@@ -0,0 +1,306 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultUtilizationRange = 7 * 24 * time.Hour
+)
+
+type Utilization struct {
+	BootedHours       float64 `json:"bootedHours"`
+	LockedHours       float64 `json:"lockedHours"`
+	DisconnectedHours float64 `json:"disconnectedHours"`
+	QuarantinedHours  float64 `json:"quarantinedHours"`
+	TestMinutes       float64 `json:"testMinutes"`
+	Tests             int     `json:"tests"`
+	FailedTests       int     `json:"failedTests"`
+	FailureRatio      float64 `json:"failureRatio"`
+	Failures          int     `json:"failures"`
+	MTBFHours         float64 `json:"mtbfHours"`
+}
+
+type DeviceUtilization struct {
+	Utilization
+	DeviceID         uint   `json:"deviceId"`
+	DeviceIdentifier string `json:"deviceIdentifier"`
+	Name             string `json:"name"`
+	Pool             string `json:"pool"`
+}
+
+type PoolUtilization struct {
+	Utilization
+	Pool    string `json:"pool"`
+	Devices int    `json:"devices"`
+}
+
+type UtilizationReport struct {
+	From    time.Time           `json:"from"`
+	To      time.Time           `json:"to"`
+	GroupBy string              `json:"groupBy"`
+	Devices []DeviceUtilization `json:"devices"`
+	Pools   []PoolUtilization   `json:"pools"`
+}
+
+func parseReportTime(value string, fallback time.Time) (time.Time, error) {
+	if len(value) == 0 {
+		return fallback, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func (s *Service) getDeviceUtilization(c *gin.Context, project *models.Project) {
+	now := time.Now()
+	to, err := parseReportTime(c.Query("to"), now)
+	if err != nil {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("invalid to: %v", err))
+		return
+	}
+	from, err := parseReportTime(c.Query("from"), to.Add(-DefaultUtilizationRange))
+	if err != nil {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("invalid from: %v", err))
+		return
+	}
+	if !from.Before(to) {
+		s.error(c, http.StatusBadRequest, fmt.Errorf("from needs to be before to"))
+		return
+	}
+
+	groupBy := c.DefaultQuery("groupBy", "node")
+	switch groupBy {
+	case "node", "platform", "manager", "label":
+	default:
+		s.error(c, http.StatusBadRequest, fmt.Errorf("unsupported groupBy: %s", groupBy))
+		return
+	}
+
+	var devices []models.Device
+	if err := s.db.Preload("Node").Find(&devices).Error; err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	report := UtilizationReport{
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+	}
+
+	usages, err := s.computeUtilization(from, to)
+	if err != nil {
+		s.error(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	pools := make(map[string]*PoolUtilization)
+	for i := range devices {
+		usage := usages[devices[i].ID]
+		usage.finish()
+
+		for _, pool := range devicePools(&devices[i], groupBy) {
+			report.Devices = append(report.Devices, DeviceUtilization{
+				Utilization:      usage,
+				DeviceID:         devices[i].ID,
+				DeviceIdentifier: devices[i].DeviceIdentifier,
+				Name:             devices[i].Name,
+				Pool:             pool,
+			})
+
+			p, ok := pools[pool]
+			if !ok {
+				p = &PoolUtilization{Pool: pool}
+				pools[pool] = p
+			}
+			p.Devices++
+			p.add(usage)
+		}
+	}
+
+	for _, p := range pools {
+		p.finish()
+		report.Pools = append(report.Pools, *p)
+	}
+	sort.Slice(report.Pools, func(i, j int) bool {
+		return report.Pools[i].Pool < report.Pools[j].Pool
+	})
+
+	if c.Query("format") == "csv" {
+		s.writeUtilizationCSV(c, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func devicePools(d *models.Device, groupBy string) []string {
+	switch groupBy {
+	case "platform":
+		return []string{models.PlatformTypeToString(d.PlatformType)}
+	case "manager":
+		return []string{d.Manager}
+	case "label":
+		labels := d.GetLabels()
+		if len(labels) == 0 {
+			return []string{"unlabeled"}
+		}
+		return labels
+	}
+	if d.Node != nil {
+		return []string{d.Node.Name}
+	}
+	return []string{"master"}
+}
+
+type protocolStats struct {
+	DeviceID    uint
+	Tests       int
+	FailedTests int
+	TestMinutes float64
+}
+
+// computeUtilization sums up the utilization of all devices in the range, the device logs are loaded in two
+// queries and the protocols are aggregated by a single grouped query, the results are not finished yet
+func (s *Service) computeUtilization(from, to time.Time) (map[uint]Utilization, error) {
+	// the state before the range started is valid until the first log entry inside the range
+	var logs []models.DeviceLog
+	if err := s.db.Where("id in (?)", s.db.Model(&models.DeviceLog{}).Select("max(id)").Where("created_at < ?", from).Group("device_id")).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	for i := range logs {
+		logs[i].CreatedAt = from
+	}
+
+	var inRange []models.DeviceLog
+	if err := s.db.Where("created_at >= ? and created_at < ?", from, to).Order("device_id asc, created_at asc").Find(&inRange).Error; err != nil {
+		return nil, err
+	}
+
+	deviceLogs := make(map[uint][]models.DeviceLog)
+	for _, l := range append(logs, inRange...) {
+		deviceLogs[l.DeviceID] = append(deviceLogs[l.DeviceID], l)
+	}
+
+	usages := make(map[uint]Utilization)
+	for deviceID, l := range deviceLogs {
+		usages[deviceID] = computeDeviceUtilization(l, to)
+	}
+
+	// failed tests include lost nodes and timeouts, failures of quarantined tests don't count
+	var stats []protocolStats
+	if err := s.db.Model(&models.TestProtocol{}).
+		Select(fmt.Sprintf("device_id, count(*) as tests, sum(case when test_result in ? and not quarantined then 1 else 0 end) as failed_tests, sum(%s) as test_minutes", durationMinutes(s.db)), models.FailedTestResults).
+		Where("device_id is not null and parent_test_protocol_id is null and ended_at is not null and started_at >= ? and started_at < ?", from, to).
+		Group("device_id").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	for _, stat := range stats {
+		usage := usages[stat.DeviceID]
+		usage.Tests = stat.Tests
+		usage.FailedTests = stat.FailedTests
+		usage.TestMinutes = stat.TestMinutes
+		usages[stat.DeviceID] = usage
+	}
+
+	return usages, nil
+}
+
+// durationMinutes returns the sql expression for the duration of a protocol in minutes
+func durationMinutes(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return "extract(epoch from (ended_at - started_at)) / 60"
+	}
+	return "(julianday(ended_at) - julianday(started_at)) * 1440"
+}
+
+// computeDeviceUtilization walks through the device status log and sums up the time spent in each state.
+// A failure is counted whenever a device drops from an available state into a disconnected or quarantined one,
+// MTBF is the available time divided by those failures.
+func computeDeviceUtilization(logs []models.DeviceLog, to time.Time) Utilization {
+	var usage Utilization
+	for i := range logs {
+		end := to
+		if i+1 < len(logs) {
+			end = logs[i+1].CreatedAt
+		}
+		hours := end.Sub(logs[i].CreatedAt).Hours()
+
+		switch logs[i].Status {
+		case device.StateBooted:
+			usage.BootedHours += hours
+		case device.StateLocked:
+			usage.LockedHours += hours
+		case device.StateQuarantined:
+			usage.QuarantinedHours += hours
+		default:
+			usage.DisconnectedHours += hours
+		}
+
+		if i > 0 && isAvailableState(logs[i-1].Status) && !isAvailableState(logs[i].Status) {
+			usage.Failures++
+		}
+	}
+	return usage
+}
+
+func isAvailableState(state device.State) bool {
+	return state == device.StateBooted || state == device.StateLocked
+}
+
+func (u *Utilization) add(o Utilization) {
+	u.BootedHours += o.BootedHours
+	u.LockedHours += o.LockedHours
+	u.DisconnectedHours += o.DisconnectedHours
+	u.QuarantinedHours += o.QuarantinedHours
+	u.TestMinutes += o.TestMinutes
+	u.Tests += o.Tests
+	u.FailedTests += o.FailedTests
+	u.Failures += o.Failures
+}
+
+func (u *Utilization) finish() {
+	u.FailureRatio = 0
+	if u.Tests > 0 {
+		u.FailureRatio = float64(u.FailedTests) / float64(u.Tests)
+	}
+	u.MTBFHours = 0
+	if u.Failures > 0 {
+		u.MTBFHours = (u.BootedHours + u.LockedHours) / float64(u.Failures)
+	}
+}
+
+func (s *Service) writeUtilizationCSV(c *gin.Context, report UtilizationReport) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=utilization_%s_%s.csv", report.From.Format("20060102"), report.To.Format("20060102")))
+	c.Status(http.StatusOK)
+
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+	row := func(kind, pool, deviceID, name string, u Utilization) []string {
+		return []string{kind, pool, deviceID, name, f(u.BootedHours), f(u.LockedHours), f(u.DisconnectedHours), f(u.QuarantinedHours), f(u.TestMinutes), strconv.Itoa(u.Tests), strconv.Itoa(u.FailedTests), f(u.FailureRatio), strconv.Itoa(u.Failures), f(u.MTBFHours)}
+	}
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"type", "pool", "device", "name", "booted_hours", "locked_hours", "disconnected_hours", "quarantined_hours", "test_minutes", "tests", "failed_tests", "failure_ratio", "failures", "mtbf_hours"})
+	for _, d := range report.Devices {
+		_ = w.Write(row("device", d.Pool, d.DeviceIdentifier, d.Name, d.Utilization))
+	}
+	for _, p := range report.Pools {
+		_ = w.Write(row("pool", p.Pool, "", strconv.Itoa(p.Devices)+" devices", p.Utilization))
+	}
+	w.Flush()
+}
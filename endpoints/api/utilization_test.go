@@ -0,0 +1,59 @@
+package api
+
+import (
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestComputeUtilization(t *testing.T) {
+	s := newTestService(t, map[string]*testDevice{
+		"phone":  {state: device.StateBooted},
+		"tablet": {state: device.StateBooted},
+	})
+	var phone, tablet models.Device
+	s.db.Where("device_identifier = ?", "phone").First(&phone)
+	s.db.Where("device_identifier = ?", "tablet").First(&tablet)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+	deviceLog := func(deviceID uint, at time.Time, state device.State) {
+		entry := models.DeviceLog{DeviceID: deviceID, Status: state}
+		entry.CreatedAt = at
+		s.db.Create(&entry)
+	}
+	deviceLog(phone.ID, from.Add(-time.Hour), device.StateBooted)
+	deviceLog(phone.ID, from.Add(4*time.Hour), device.StateRemoteDisconnected)
+	deviceLog(phone.ID, from.Add(6*time.Hour), device.StateBooted)
+	deviceLog(tablet.ID, from.Add(2*time.Hour), device.StateQuarantined)
+
+	protocol := func(deviceID uint, result models.TestResultState, quarantined bool) {
+		start := from.Add(time.Hour)
+		end := start.Add(30 * time.Minute)
+		s.db.Create(&models.TestProtocol{DeviceID: &deviceID, StartedAt: start, EndedAt: &end, TestResult: result, Quarantined: quarantined})
+	}
+	protocol(phone.ID, models.TestResultSuccess, false)
+	protocol(phone.ID, models.TestResultFailed, false)
+	protocol(phone.ID, models.TestResultNodeLost, false)
+	protocol(phone.ID, models.TestResultTimedOut, false)
+	protocol(phone.ID, models.TestResultFailed, true)
+
+	usages, err := s.computeUtilization(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	usage := usages[phone.ID]
+	if usage.BootedHours != 8 || usage.DisconnectedHours != 2 || usage.Failures != 1 {
+		t.Errorf("unexpected device states %+v", usage)
+	}
+	if usage.Tests != 5 || usage.FailedTests != 3 || math.Abs(usage.TestMinutes-150) > 0.01 {
+		t.Errorf("unexpected tests %+v", usage)
+	}
+
+	if usage := usages[tablet.ID]; usage.QuarantinedHours != 8 || usage.Tests != 0 {
+		t.Errorf("unexpected tablet %+v", usage)
+	}
+}
@@ -23,6 +23,10 @@ func (s *nodeMaster) Action(c *gin.Context) {
 	if device == nil {
 		return
 	}
+	if !ownsDevice(c, device) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "device not attached to node"})
+		return
+	}
 
 	if handler := device.ActionHandlers(); handler != nil {
 		for i := range handler {
@@ -0,0 +1,124 @@
+package master
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/node"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+const NodeIdentityKey = "node_identity"
+
+// authenticateNode rejects requests which are not authenticated by a client certificate, a node token or,
+// without tls, the global token. The identity of the node is stored in the context to pin requests to the
+// authenticated node, nodes authenticated by the global token aren't pinned to an identity
+func (s *nodeMaster) authenticateNode(c *gin.Context) {
+	identity, err := s.nodeIdentity(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	c.Set(NodeIdentityKey, identity)
+	c.Next()
+}
+
+func (s *nodeMaster) nodeIdentity(c *gin.Context) (string, error) {
+	certIdentity := mtls.PeerIdentity(c.Request)
+	if s.cfg.TLS.Enabled && len(certIdentity) == 0 {
+		return "", fmt.Errorf("client certificate required")
+	}
+
+	xauth := c.GetHeader("X-Auth-Token")
+	token, err := s.accessToken(xauth)
+	if err != nil {
+		return "", err
+	}
+
+	tokenIdentity, err := s.tokenIdentity(token)
+	if err != nil {
+		return "", err
+	}
+
+	if len(certIdentity) > 0 {
+		if len(tokenIdentity) > 0 && tokenIdentity != certIdentity {
+			return "", fmt.Errorf("token doesn't belong to node %s", certIdentity)
+		}
+		if err := s.db.First(&models.Node{}, "identifier = ?", certIdentity).Error; err != nil {
+			return "", fmt.Errorf("unknown node %s", certIdentity)
+		}
+		return certIdentity, nil
+	}
+
+	if len(tokenIdentity) > 0 {
+		return tokenIdentity, nil
+	}
+
+	// nodes set up before node tokens were introduced authenticate with auth.token.auth_token, only a master
+	// without any authentication accepts nodes without a token
+	if s.isGlobalToken(xauth) || !s.nodeTokenRequired() {
+		return "", nil
+	}
+	return "", fmt.Errorf("unauthorized")
+}
+
+// nodeTokenRequired reports if nodes without a client certificate need a token to connect
+func (s *nodeMaster) nodeTokenRequired() bool {
+	return s.cfg.TLS.Enabled || s.cfg.Auth.Token != nil || s.cfg.Auth.AuthenticationRequired()
+}
+
+// accessToken returns the stored access token, tokens which are not stored e.g. the configured global token return nil
+func (s *nodeMaster) accessToken(xauth string) (*models.AccessToken, error) {
+	if len(xauth) == 0 {
+		return nil, nil
+	}
+
+	var token models.AccessToken
+	if err := s.db.First(&token, "token = ?", xauth).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if token.ExpiresAt != nil && token.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("unauthorized token expired")
+	}
+	return &token, nil
+}
+
+// tokenIdentity returns the identifier of the node of a node token
+func (s *nodeMaster) tokenIdentity(token *models.AccessToken) (string, error) {
+	if token == nil || token.NodeID == nil {
+		return "", nil
+	}
+
+	var n models.Node
+	if err := s.db.First(&n, token.NodeID).Error; err != nil {
+		return "", fmt.Errorf("unauthorized")
+	}
+	return n.Identifier, nil
+}
+
+// isGlobalToken reports if the token is the configured auth_token
+func (s *nodeMaster) isGlobalToken(xauth string) bool {
+	if len(xauth) == 0 || s.cfg.Auth.Token == nil || len(s.cfg.Auth.Token.AuthToken) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(xauth), []byte(s.cfg.Auth.Token.AuthToken)) == 1
+}
+
+// ownsDevice checks that the device is attached to the authenticated node, requests of nodes without a
+// pinned identity can access all node devices
+func ownsDevice(c *gin.Context, dev device.Device) bool {
+	nd, ok := dev.(*node.NodeDevice)
+	if !ok {
+		return false
+	}
+	identity := c.GetString(NodeIdentityKey)
+	return len(identity) == 0 || string(nd.GetNodeID()) == identity
+}
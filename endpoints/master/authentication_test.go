@@ -0,0 +1,80 @@
+package master
+
+import (
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/storage/migrations"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestNodeMaster(t *testing.T, cfg config.Service) *nodeMaster {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("unable to open database: %v", err)
+	}
+	if err := migrations.InitSchema(db); err != nil {
+		t.Fatalf("unable to migrate database: %v", err)
+	}
+
+	node := models.Node{Identifier: "node-1"}
+	db.Create(&node)
+	db.Create(&models.AccessToken{Name: "node", Token: "node-token", NodeID: &node.ID})
+	db.Create(&models.AccessToken{Name: "global", Token: "stored-token"})
+	projectID := uint(1)
+	db.Create(&models.AccessToken{Name: "project", Token: "project-token", ProjectID: &projectID})
+
+	return &nodeMaster{cfg: cfg, db: db}
+}
+
+func requestNodeIdentity(s *nodeMaster, xauth string) (string, error) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/node/connect", nil)
+	if len(xauth) > 0 {
+		c.Request.Header.Set("X-Auth-Token", xauth)
+	}
+	return s.nodeIdentity(c)
+}
+
+func TestNodeIdentity(t *testing.T) {
+	withUserAuth := config.Service{Auth: config.Auth{
+		Token:    &config.Token{AuthToken: "config-token"},
+		Password: &config.Password{Secret: "secret"},
+	}}
+	withToken := config.Service{Auth: config.Auth{Token: &config.Token{AuthToken: "config-token"}}}
+
+	tests := []struct {
+		name     string
+		cfg      config.Service
+		token    string
+		identity string
+		fails    bool
+	}{
+		{name: "node token is pinned", cfg: withUserAuth, token: "node-token", identity: "node-1"},
+		{name: "configured global token", cfg: withUserAuth, token: "config-token"},
+		{name: "stored token of neither project nor node", cfg: withUserAuth, token: "stored-token", fails: true},
+		{name: "project token", cfg: withUserAuth, token: "project-token", fails: true},
+		{name: "unknown token", cfg: withUserAuth, token: "unknown", fails: true},
+		{name: "missing token", cfg: withUserAuth, fails: true},
+		{name: "no authentication", cfg: config.Service{}},
+		{name: "configured token without user authentication", cfg: withToken, token: "config-token"},
+		{name: "missing token without user authentication", cfg: withToken, fails: true},
+		{name: "node token without user authentication", cfg: config.Service{}, token: "node-token", identity: "node-1"},
+		{name: "tls requires a certificate", cfg: config.Service{TLS: config.TLS{Enabled: true}}, token: "node-token", fails: true},
+	}
+
+	for _, test := range tests {
+		identity, err := requestNodeIdentity(newTestNodeMaster(t, test.cfg), test.token)
+		if test.fails != (err != nil) {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if identity != test.identity {
+			t.Errorf("%s: expected identity %q got %q", test.name, test.identity, identity)
+		}
+	}
+}
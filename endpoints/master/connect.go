@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/fsuhrau/automationhub/config/protocol"
 	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/hub"
 	"github.com/fsuhrau/automationhub/hub/manager"
 	"github.com/fsuhrau/automationhub/hub/node"
 	"github.com/gin-gonic/gin"
@@ -24,10 +25,10 @@ func (s *nodeMaster) Connect(c *gin.Context) {
 		return
 	}
 
-	go s.handleNode(conn, c)
+	go s.handleNode(conn, c, c.GetString(NodeIdentityKey))
 }
 
-func (s *nodeMaster) handleNode(conn *websocket.Conn, c *gin.Context) {
+func (s *nodeMaster) handleNode(conn *websocket.Conn, c *gin.Context, identity string) {
 
 	// read connect message first
 	_, data, err := conn.ReadMessage()
@@ -41,14 +42,52 @@ func (s *nodeMaster) handleNode(conn *websocket.Conn, c *gin.Context) {
 		return
 	}
 
-	fmt.Printf("node registered: %s\n", request.Hostname)
+	// the node identity is pinned to the authenticated certificate or node token
+	if len(identity) > 0 && request.GetIdentifier() != identity {
+		fmt.Printf("node %s tried to register as %s\n", identity, request.GetIdentifier())
+		_ = conn.Close()
+		return
+	}
+
+	version := manager.NodeVersion{
+		Version:         request.GetVersion(),
+		ProtocolVersion: request.GetProtocolVersion(),
+		Features:        node.NegotiateFeatures(request.GetFeatures()),
+	}
+	if version.ProtocolVersion > node.ProtocolVersion {
+		version.ProtocolVersion = node.ProtocolVersion
+	}
+
+	// nodes of protocol version 0 start serving rpc calls right after their registration
+	if version.ProtocolVersion > 0 {
+		if err := writeRegisterResponse(conn, &node.RegisterNodeResponse{
+			Accepted:        true,
+			Version:         hub.GetVersion(),
+			ProtocolVersion: version.ProtocolVersion,
+			Features:        version.Features,
+		}); err != nil {
+			fmt.Printf("Failed to answer registration: %+v\n", err)
+			_ = conn.Close()
+			return
+		}
+	}
+
+	fmt.Printf("node registered: %s (version %s, protocol %d)\n", request.Hostname, version.Version, version.ProtocolVersion)
 
 	rpcClient := node.NewRPCClient(conn, s.cfg.MasterURL)
 
-	s.nm.RegisterNode(manager.NodeIdentifier(request.GetIdentifier()), rpcClient, request.GetHostname(), c.RemoteIP(), request.GetOperationSystem(), request.GetEnvironmentVariables(), request.GetPort(), request.GetManagers())
+	s.nm.RegisterNode(manager.NodeIdentifier(request.GetIdentifier()), rpcClient, request.GetHostname(), c.RemoteIP(), request.GetOperationSystem(), request.GetEnvironmentVariables(), request.GetPort(), request.GetManagers(), version)
 
 	events.NodeConnected.Trigger(events.NodeConnectedPayload{
 		NodeIdentifier: manager.NodeIdentifier(request.GetIdentifier()),
 		Hostname:       request.GetHostname(),
 	})
 }
+
+func writeRegisterResponse(conn *websocket.Conn, response *node.RegisterNodeResponse) error {
+	data, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.BinaryMessage, data)
+}
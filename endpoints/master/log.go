@@ -29,6 +29,10 @@ func (s *nodeMaster) Log(c *gin.Context) {
 		return
 	}
 	device, _ := s.dm.GetDevice(req.DeviceID)
+	if device != nil && !ownsDevice(c, device) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "device not attached to node"})
+		return
+	}
 	if device != nil {
 		switch req.Type {
 		case 0:
@@ -5,6 +5,7 @@ import (
 	"github.com/fsuhrau/automationhub/hub/manager"
 	"github.com/fsuhrau/automationhub/hub/node"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type nodeMaster struct {
@@ -12,19 +13,23 @@ type nodeMaster struct {
 	dm  manager.Devices
 	nm  manager.Nodes
 	ch  node.ConnectionHandler
+	db  *gorm.DB
 }
 
-func New(config config.Service, dm manager.Devices, nm manager.Nodes, ch node.ConnectionHandler) *nodeMaster {
+func New(config config.Service, dm manager.Devices, nm manager.Nodes, ch node.ConnectionHandler, db *gorm.DB) *nodeMaster {
 	return &nodeMaster{
 		cfg: config,
 		dm:  dm,
 		nm:  nm,
 		ch:  ch,
+		db:  db,
 	}
 }
 
 func (s *nodeMaster) RegisterRoutes(r *gin.Engine, auth *gin.RouterGroup) error {
-	apiNode := auth.Group("/node")
+	// nodes don't have a user session, they authenticate with their certificate or node token
+	apiNode := r.Group("/node")
+	apiNode.Use(s.authenticateNode)
 
 	apiNode.POST("/log", s.Log)
 	apiNode.POST("/action", s.Action)
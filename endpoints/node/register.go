@@ -1,11 +1,16 @@
 package node
 
 import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/hub"
 	"github.com/fsuhrau/automationhub/hub/node"
 	"github.com/gorilla/websocket"
 	"google.golang.org/protobuf/proto"
+	"time"
 )
 
+const registrationTimeout = 30 * time.Second
+
 func (rpc *Service) RegisterNode(identifier, hostname, os, path string, port int32, managers []string) error {
 	request := node.RegisterNodeRequest{
 		Identifier:           identifier,
@@ -14,11 +19,84 @@ func (rpc *Service) RegisterNode(identifier, hostname, os, path string, port int
 		EnvironmentVariables: path,
 		Managers:             managers,
 		Port:                 port,
+		Version:              hub.GetVersion(),
+		ProtocolVersion:      node.ProtocolVersion,
+		Features:             node.SupportedFeatures,
 	}
 	data, err := proto.Marshal(&request)
 	if err != nil {
 		return err
 	}
 
-	return rpc.c.WriteMessage(websocket.BinaryMessage, data)
+	if err := rpc.c.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		return err
+	}
+
+	return rpc.awaitRegistration()
+}
+
+type frame struct {
+	messageType int
+	data        []byte
+	err         error
+}
+
+// readMessage returns the frame which was read while waiting for the registration before reading from the connection
+func (rpc *Service) readMessage() (int, []byte, error) {
+	if rpc.pending != nil {
+		f := <-rpc.pending
+		rpc.pending = nil
+		return f.messageType, f.data, f.err
+	}
+	return rpc.c.ReadMessage()
+}
+
+// awaitRegistration waits for the master to accept the node and checks if the master is compatible,
+// masters of protocol version 0 don't answer the registration, their first message is already a rpc call
+func (rpc *Service) awaitRegistration() error {
+	rpc.pending = nil
+
+	// a timed out read breaks the websocket connection, so the frame is read without a deadline
+	// and handed over to the rpc server if it isn't a registration response
+	frames := make(chan frame, 1)
+	go func() {
+		messageType, data, err := rpc.c.ReadMessage()
+		frames <- frame{messageType: messageType, data: data, err: err}
+	}()
+
+	var f frame
+	select {
+	case f = <-frames:
+	case <-time.After(registrationTimeout):
+		rpc.pending = frames
+		return rpc.registeredAtLegacyMaster()
+	}
+
+	if f.err != nil {
+		if closeErr, ok := f.err.(*websocket.CloseError); ok && len(closeErr.Text) > 0 {
+			return fmt.Errorf("registration rejected by master: %s", closeErr.Text)
+		}
+		return fmt.Errorf("registration failed: %v", f.err)
+	}
+
+	var response node.RegisterNodeResponse
+	if err := proto.Unmarshal(f.data, &response); err != nil || response.GetProtocolVersion() == 0 {
+		frames <- f
+		rpc.pending = frames
+		return rpc.registeredAtLegacyMaster()
+	}
+
+	if !response.GetAccepted() {
+		return fmt.Errorf("registration rejected by master %s: %s", response.GetVersion(), response.GetError())
+	}
+
+	rpc.rpcNode.SetMasterFeatures(response.GetFeatures())
+	rpc.logger.Infof("registered at master version %s using protocol %d with features %v", response.GetVersion(), response.GetProtocolVersion(), response.GetFeatures())
+	return nil
+}
+
+func (rpc *Service) registeredAtLegacyMaster() error {
+	rpc.rpcNode.SetMasterFeatures(nil)
+	rpc.logger.Infof("registered at master using protocol 0 without features")
+	return nil
 }
@@ -0,0 +1,72 @@
+package node
+
+import (
+	"github.com/fsuhrau/automationhub/hub/node"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// registerAt registers a node at a master which answers the registration with answer
+func registerAt(t *testing.T, answer func(conn *websocket.Conn)) (*Service, error) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		answer(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("unable to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	s := &Service{logger: logrus.NewEntry(logrus.New()), rpcNode: &node.RPCNode{}, c: c}
+	return s, s.RegisterNode("node", "host", "linux", "", 0, nil)
+}
+
+func TestRegisterNode(t *testing.T) {
+	s, err := registerAt(t, func(conn *websocket.Conn) {
+		data, _ := proto.Marshal(&node.RegisterNodeResponse{Accepted: true, ProtocolVersion: node.ProtocolVersion, Features: node.SupportedFeatures})
+		_ = conn.WriteMessage(websocket.BinaryMessage, data)
+	})
+	if err != nil {
+		t.Fatalf("expected registration got %v", err)
+	}
+	if s.pending != nil {
+		t.Errorf("expected the registration response not to be passed to the rpc server")
+	}
+
+	if _, err := registerAt(t, func(conn *websocket.Conn) {
+		data, _ := proto.Marshal(&node.RegisterNodeResponse{Accepted: false, ProtocolVersion: node.ProtocolVersion, Error: "unsupported"})
+		_ = conn.WriteMessage(websocket.BinaryMessage, data)
+	}); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expected rejection got %v", err)
+	}
+}
+
+func TestRegisterNodeAtLegacyMaster(t *testing.T) {
+	call := `{"method":"RPCNode.Ping","params":[{}],"id":0}`
+	s, err := registerAt(t, func(conn *websocket.Conn) {
+		_ = conn.WriteMessage(websocket.BinaryMessage, []byte(call))
+	})
+	if err != nil {
+		t.Fatalf("expected registration at protocol 0 master got %v", err)
+	}
+
+	_, data, err := s.readMessage()
+	if err != nil || string(data) != call {
+		t.Errorf("expected the first rpc call to be served got %q %v", data, err)
+	}
+}
@@ -22,6 +22,7 @@ type Service struct {
 	rpcNode        *node.RPCNode
 	managers       []string
 	c              *websocket.Conn
+	pending        chan frame
 }
 
 func New(logger *logrus.Logger, db *gorm.DB, rpcNode *node.RPCNode, dm manager.Devices, config config.Service, managers []string, reconnect node.ConnectionHandler) *Service {
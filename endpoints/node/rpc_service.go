@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/config/protocol"
 	"github.com/fsuhrau/automationhub/hub/node/jsonrpc"
 	"github.com/gorilla/websocket"
@@ -17,7 +18,7 @@ var rpcServer = rpc.DefaultServer
 
 func (s *Service) ConnectAndServe() {
 
-	serverURL := url.URL{Scheme: "ws", Host: s.cfg.MasterURL, Path: "/node/connect"}
+	serverURL := url.URL{Scheme: mtls.WebsocketScheme(), Host: s.cfg.MasterURL, Path: "/node/connect"}
 
 	// proxyURL, _ := url.Parse("http://10.35.111.236:8888")
 
@@ -32,6 +33,7 @@ func (s *Service) ConnectAndServe() {
 		ReadBufferSize:    protocol.SocketFrameSize,
 		WriteBufferSize:   protocol.SocketFrameSize,
 		EnableCompression: true,
+		TLSClientConfig:   mtls.ClientConfig(),
 	}
 	c, _, err := dialer.Dial(serverURL.String(), headers)
 	if err != nil {
@@ -43,10 +45,11 @@ func (s *Service) ConnectAndServe() {
 	gi, _ := goInfo.GetInfo()
 	if err := s.RegisterNode(s.cfg.Identifier, gi.Hostname, gi.OS, os.Getenv("PATH"), s.cfg.Port, s.managers); err != nil {
 		fmt.Printf("%v\n", err)
+		_ = c.Close()
 		return
 	}
 
 	// DefaultServer.ServeConn(c)
 
-	rpcServer.ServeCodec(jsonrpc.NewServerCodec(c))
+	rpcServer.ServeCodec(jsonrpc.NewServerCodecWithReader(c, s.readMessage))
 }
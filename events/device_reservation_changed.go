@@ -0,0 +1,27 @@
+package events
+
+var DeviceReservationChanged deviceReservationChanged
+
+type DeviceReservationChangedPayload struct {
+	DeviceID    uint        `json:"deviceId"`
+	Reserved    bool        `json:"reserved"`
+	Reservation interface{} `json:"reservation"`
+}
+
+type deviceReservationChanged struct {
+	handlers []interface {
+		Handle(DeviceReservationChangedPayload)
+	}
+}
+
+func (u *deviceReservationChanged) Register(handler interface {
+	Handle(DeviceReservationChangedPayload)
+}) {
+	u.handlers = append(u.handlers, handler)
+}
+
+func (u deviceReservationChanged) Trigger(payload DeviceReservationChangedPayload) {
+	for _, handler := range u.handlers {
+		go handler.Handle(payload)
+	}
+}
@@ -0,0 +1,25 @@
+package events
+
+import "github.com/fsuhrau/automationhub/hub/manager"
+
+var NodeLost nodeLost
+
+type NodeLostPayload struct {
+	NodeIdentifier manager.NodeIdentifier `json:"nodeIdentifier"`
+	Hostname       string                 `json:"hostname"`
+	Reason         string                 `json:"reason"`
+}
+
+type nodeLost struct {
+	handlers []interface{ Handle(NodeLostPayload) }
+}
+
+func (u *nodeLost) Register(handler interface{ Handle(NodeLostPayload) }) {
+	u.handlers = append(u.handlers, handler)
+}
+
+func (u nodeLost) Trigger(payload NodeLostPayload) {
+	for _, handler := range u.handlers {
+		go handler.Handle(payload)
+	}
+}
@@ -3,15 +3,16 @@ package events
 var TestRunFinished testRunFinished
 
 type TestRunFinishedPayload struct {
-	ProjectID string      `json:"projectId"`
-	AppID     uint        `json:"appId"`
-	TestRunID uint        `json:"testRunId"`
-	TestName  string      `json:"testName"`
-	Success   bool        `json:"success"`
-	Succeeded int         `json:"succeeded"`
-	Unstable  int         `json:"unstable"`
-	Failed    int         `json:"failed"`
-	TestRun   interface{} `json:"testRun"`
+	ProjectID   string      `json:"projectId"`
+	AppID       uint        `json:"appId"`
+	TestRunID   uint        `json:"testRunId"`
+	TestName    string      `json:"testName"`
+	Success     bool        `json:"success"`
+	Succeeded   int         `json:"succeeded"`
+	Unstable    int         `json:"unstable"`
+	Failed      int         `json:"failed"`
+	Quarantined int         `json:"quarantined"` // failures of quarantined tests, they don't fail the run
+	TestRun     interface{} `json:"testRun"`
 }
 
 type testRunFinished struct {
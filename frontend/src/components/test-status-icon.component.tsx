@@ -1,7 +1,7 @@
 import React from 'react';
 
 import {TestResultState} from '../types/test.result.state.enum';
-import {Cancel, CheckCircle, DirectionsRun, Explicit} from '@mui/icons-material';
+import {Cancel, CheckCircle, CloudOff, DirectionsRun, Explicit, TimerOff} from '@mui/icons-material';
 import {getTestStatusColor} from "../helper/TestStatusHelper";
 
 export interface TestStatusIconProps {
@@ -17,6 +17,8 @@ const TestStatusIconComponent: React.FC<TestStatusIconProps> = (props) => {
             {status == TestResultState.TestResultFailed && <Cancel htmlColor={getTestStatusColor(status)}/>}
             {status == TestResultState.TestResultOpen && <DirectionsRun htmlColor={getTestStatusColor(status)}/>}
             {status == TestResultState.TestResultUnstable && <Explicit htmlColor={getTestStatusColor(status)}/>}
+            {status == TestResultState.TestResultNodeLost && <CloudOff htmlColor={getTestStatusColor(status)}/>}
+            {status == TestResultState.TestResultTimedOut && <TimerOff htmlColor={getTestStatusColor(status)}/>}
         </>
     );
 };
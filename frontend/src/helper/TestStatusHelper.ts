@@ -11,6 +11,10 @@ export const getTestStatusText = (state: TestResultState): string => {
             return 'Open';
         case TestResultState.TestResultUnstable:
             return 'Unstable';
+        case TestResultState.TestResultNodeLost:
+            return 'Node Lost';
+        case TestResultState.TestResultTimedOut:
+            return 'Timed Out';
     }
     return ''
 }
@@ -25,6 +29,9 @@ export const getTestStatusColor = (state: TestResultState): string => {
             return 'gray';
         case TestResultState.TestResultUnstable:
             return 'yellow';
+        case TestResultState.TestResultNodeLost:
+        case TestResultState.TestResultTimedOut:
+            return 'orange';
     }
     return 'green'
 }
@@ -39,6 +46,9 @@ export const getTestStatusChipColor = (state: TestResultState): any => {
             return 'default';
         case TestResultState.TestResultUnstable:
             return 'warning';
+        case TestResultState.TestResultNodeLost:
+        case TestResultState.TestResultTimedOut:
+            return 'error';
     }
     return 'default'
 }
\ No newline at end of file
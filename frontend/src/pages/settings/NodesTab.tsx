@@ -20,6 +20,15 @@ import {createNode, deleteNode, getNodes, NewNodeRequest} from "../../services/s
 import {HubStateActions} from "../../application/HubState";
 import {useError} from "../../ErrorProvider";
 
+const downloadFile = (fileName: string, content: string): void => {
+    const url = URL.createObjectURL(new Blob([content], {type: 'application/x-pem-file'}));
+    const link = document.createElement('a');
+    link.href = url;
+    link.download = fileName;
+    link.click();
+    URL.revokeObjectURL(url);
+};
+
 const NodesTab: React.FC = () => {
 
     const {state, dispatch} = useHubState()
@@ -36,6 +45,13 @@ const NodesTab: React.FC = () => {
                 name: '',
             })
 
+            // the private key can't be requested again
+            if (node.certificate && node.privateKey && node.caCertificate) {
+                downloadFile(`${node.identifier}.crt`, node.certificate);
+                downloadFile(`${node.identifier}.key`, node.privateKey);
+                downloadFile('ca.crt', node.caCertificate);
+            }
+
             dispatch({
                 type: HubStateActions.NodeAdd,
                 payload: node
@@ -77,13 +93,17 @@ const NodesTab: React.FC = () => {
                             <TableHead>
                                 <TableRow>
                                     <TableCell>Name</TableCell>
+                                    <TableCell>Version</TableCell>
+                                    <TableCell>Load</TableCell>
                                     <TableCell align="right">Identifier</TableCell>
                                     <TableCell></TableCell>
                                 </TableRow>
                             </TableHead>
                             <TableBody>
                                 {state.nodes?.map((node) => <TableRow key={node.id}>
-                                    <TableCell>{node.name}</TableCell>
+                                    <TableCell>{node.name}{node.maintenance && ` (${node.maintenance})`}</TableCell>
+                                    <TableCell>{node.version ? `${node.version} (protocol ${node.protocolVersion})` : '-'}</TableCell>
+                                    <TableCell>{node.metrics ? `CPU ${node.metrics.cpuUsage.toFixed(0)}%, Memory ${node.metrics.memoryTotal > 0 ? (node.metrics.memoryUsed / node.metrics.memoryTotal * 100).toFixed(0) : 0}%, ${node.metrics.deviceSessions} sessions` : '-'}</TableCell>
                                     <TableCell>
                                         <Grid container={true} direction={"row"} spacing={1} justifyContent={"right"} alignItems={"center"}>
                                             <Grid>
@@ -126,6 +146,8 @@ const NodesTab: React.FC = () => {
                                                    }))}/>
                                     </TableCell>
                                     <TableCell></TableCell>
+                                    <TableCell></TableCell>
+                                    <TableCell></TableCell>
                                     <TableCell>
                                         <Button variant="contained" color="primary" size="small"
                                                 onClick={handleCreateNode}>
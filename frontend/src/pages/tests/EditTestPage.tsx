@@ -62,6 +62,8 @@ const EditTestPage: React.FC<TestContentProps> = (props: TestContentProps) => {
         selectedTestFunctions: IAppFunctionData[],
         testCategories: string[],
         category: string,
+        testTimeout: number,
+        runTimeout: number,
     };
 
     const {test} = props;
@@ -88,6 +90,8 @@ const EditTestPage: React.FC<TestContentProps> = (props: TestContentProps) => {
             } as IAppFunctionData)),
             testCategories: test.testConfig.unity === undefined || test.testConfig.unity === null || test.testConfig.unity.categories === '' ? [] : test.testConfig.unity.categories.split(','),
             category: '',
+            testTimeout: test.testConfig.testTimeout,
+            runTimeout: test.testConfig.runTimeout,
         }
     )
 
@@ -116,6 +120,8 @@ const EditTestPage: React.FC<TestContentProps> = (props: TestContentProps) => {
             unityTestCategoryType: uiState.testCategoryType,
             devices: uiState.selectedDevices,
             testFunctions: uiState.selectedTestFunctions,
+            testTimeout: uiState.testTimeout,
+            runTimeout: uiState.runTimeout,
         } as UpdateTestData).then(response => {
             navigate(`/project/${projectIdentifier}/app:${appId}/tests`);
         }).catch(ex => {
@@ -217,7 +223,39 @@ const EditTestPage: React.FC<TestContentProps> = (props: TestContentProps) => {
                             Concurrent = runs each test on a different free
                             device to get faster results<br/>
                             Simultaneously = runs every test on every device
-                            to get a better accuracy</Alert>
+                            to get a better accuracy<br/>
+                            Sharded = splits the tests by their previous durations
+                            into balanced shards per device</Alert>
+                    </Grid>
+
+                    <Grid size={{xs: 12, md: 2}}>
+                        Timeouts:
+                    </Grid>
+                    <Grid size={{xs: 12, md: 10}}>
+                        <TextField
+                            label="Test timeout (seconds)"
+                            type="number"
+                            size="small"
+                            value={uiState.testTimeout}
+                            onChange={event => setUiState(prevState => ({
+                                ...prevState,
+                                testTimeout: Math.max(0, +event.target.value)
+                            }))}
+                        />
+                        <TextField
+                            label="Run timeout (seconds)"
+                            type="number"
+                            size="small"
+                            sx={{ml: 2}}
+                            value={uiState.runTimeout}
+                            onChange={event => setUiState(prevState => ({
+                                ...prevState,
+                                runTimeout: Math.max(0, +event.target.value)
+                            }))}
+                        />
+                        <Alert severity="info">
+                            0 uses the default of 5 minutes per test, tests with a [Timeout] attribute use their own.
+                            A run exceeding the run timeout gets cancelled, 0 disables it</Alert>
                     </Grid>
 
                     <Grid size={{xs: 12, md: 2}}>
@@ -68,7 +68,9 @@ const ShowTestPage: React.FC<TestContentProps> = (props) => {
                                     Concurrent = runs each test on a different free
                                     device to get faster results<br/>
                                     Simultaneously = runs every test on every device
-                                    to get a better accuracy</Alert>
+                                    to get a better accuracy<br/>
+                                    Sharded = splits the tests by their previous durations
+                                    into balanced shards per device</Alert>
                             </Grid>
                         </Grid>
                         <Grid size={{xs: 12, md: 2}}>
@@ -20,7 +20,7 @@ import Moment from 'react-moment';
 import {useSSE} from 'react-hooks-sse';
 import ITesRunLogEntryData from '../../types/test.run.log.entry';
 import ITestProtocolData from '../../types/test.protocol';
-import {cancelTestRun, executeTest} from '../../services/test.service';
+import {cancelTestRun, executeTest, rerunFailedTests} from '../../services/test.service';
 import {useNavigate} from 'react-router-dom';
 import {TestContext} from '../../context/test.context';
 import {
@@ -108,14 +108,16 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
         protocols: ITestProtocolData[],
         runsOpen: number,
         runsFailed: number,
-        runsSuccess: number
+        runsSuccess: number,
+        runsQuarantined: number
     }>({
         currentRunID: testRun.id as number,
         log: [],
         protocols: testRun.protocols === undefined ? [] : testRun.protocols,
         runsOpen: 0,
         runsSuccess: 0,
-        runsFailed: 0
+        runsFailed: 0,
+        runsQuarantined: 0
     })
 
     const patchProtocols = (protocols: ITestProtocolData[], protocol: ITestProtocolData): ITestProtocolData[] => {
@@ -159,13 +161,15 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
         Pending: true,
     });
 
-    const rebuildStatistics = (run: ITestRunData): { ro: number, rf: number, rs: number } => {
+    const rebuildStatistics = (run: ITestRunData): { ro: number, rf: number, rs: number, rq: number } => {
         let ro: number;
         let rf: number;
         let rs: number;
+        let rq: number;
         ro = 0;
         rf = 0;
         rs = 0;
+        rq = 0;
 
         run.protocols.forEach(value => {
             switch (value.testResult) {
@@ -173,24 +177,32 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
                     ro++;
                     break;
                 case TestResultState.TestResultFailed:
-                    rf++;
+                case TestResultState.TestResultNodeLost:
+                case TestResultState.TestResultTimedOut:
+                    // failures of quarantined tests are reported separately
+                    if (value.quarantined) {
+                        rq++;
+                    } else {
+                        rf++;
+                    }
                     break;
                 case TestResultState.TestResultSuccess:
                     rs++;
                     break;
             }
         });
-        return {ro: ro, rf: rf, rs: rs}
+        return {ro: ro, rf: rf, rs: rs, rq: rq}
     }
 
     useEffect(() => {
-        let {ro, rf, rs} = rebuildStatistics(testRun);
+        let {ro, rf, rs, rq} = rebuildStatistics(testRun);
         setState(prevState => ({
             ...prevState,
             log: testRun.log,
             runsOpen: ro,
             runsFailed: rf,
             runsSuccess: rs,
+            runsQuarantined: rq,
             protocols: testRun.protocols
         }))
     }, [testRun]);
@@ -208,6 +220,14 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
         });
     };
 
+    const onRerunFailedTests = (): void => {
+        rerunFailedTests(projectIdentifier, appId, testRun.testId, testRun.id!).then(run => {
+            window.location.href = `/project/${projectIdentifier}/app:${appId}/test/${testRun.testId}/run/${run.id}`;
+        }).catch(error => {
+            setError(error);
+        });
+    };
+
     const onCancelTestRun = (): void => {
         cancelTestRun(projectIdentifier, appId, testRun.testId, testRun.id!).then(response => {
             console.log("test run cancelled", response);
@@ -228,7 +248,7 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
 
     const applyProtocolFilter = (value: ITestProtocolData): boolean => {
         return (filter.Success && value.testResult === TestResultState.TestResultSuccess)
-            || (filter.Failed && value.testResult === TestResultState.TestResultFailed)
+            || (filter.Failed && (value.testResult === TestResultState.TestResultFailed || value.testResult === TestResultState.TestResultNodeLost || value.testResult === TestResultState.TestResultTimedOut))
             || (filter.Pending && value.testResult === TestResultState.TestResultOpen);
     }
 
@@ -271,6 +291,7 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
                             underline="none">
                             {testName}
                         </Link>
+                        {row.quarantined && <Typography variant={"caption"}> (quarantined)</Typography>}
                     </TableCell>
                     <TableCell>
                         <Grid container={true}>
@@ -376,6 +397,16 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
                         </Grid>
                     ))}
                 </Grid>
+                {testRun.rerunOfId &&
+                    <Typography variant={"body1"}>
+                        Rerun of the failed tests of <Link
+                        onClick={() => navigate(`/project/${projectIdentifier}/app:${appId}/test/${testRun.testId}/run/${testRun.rerunOfId}`)}
+                        underline="none">run {testRun.rerunOfId}</Link>
+                    </Typography>}
+                {testRun.matrixId &&
+                    <Typography variant={"body1"}>
+                        Combination {testRun.matrixCombination} of matrix {testRun.matrixId}
+                    </Typography>}
                 <Grid sx={{flexGrow: 1}}></Grid>
             </TitleCard>
             {
@@ -447,6 +478,7 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
                                 {name: "Open", value: state.runsOpen},
                                 {name: "Failed", value: state.runsFailed},
                                 {name: "Success", value: state.runsSuccess},
+                                {name: "Quarantined", value: state.runsQuarantined},
                             ]}
                             cx="50%"
                             cy="50%"
@@ -458,6 +490,7 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
                             <Cell fill={'yellow'}/>
                             <Cell fill={'red'}/>
                             <Cell fill={'green'}/>
+                            <Cell fill={'gray'}/>
                         </Pie>
                         <Tooltip/>
                     </PieChart>
@@ -474,6 +507,12 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
                             <Typography
                                 variant={"caption"}>Success: {state.runsSuccess}</Typography>
                         </Grid>
+                        {state.runsQuarantined > 0 && (
+                            <Grid size={12}>
+                                <Typography
+                                    variant={"caption"}>Failed in quarantine: {state.runsQuarantined}</Typography>
+                            </Grid>
+                        )}
                     </Grid>
                 </Grid>
                 <Grid container={true} alignItems={"flex-end"}
@@ -483,6 +522,12 @@ const TestRunPage: React.FC<TestRunPageProps> = (props: TestRunPageProps) => {
                             Rerun
                         </Button>
                     </Grid>
+                    {state.runsOpen === 0 && state.runsFailed > 0 &&
+                        <Grid>
+                            <Button variant="contained" color="primary" onClick={onRerunFailedTests}>
+                                Rerun failed
+                            </Button>
+                        </Grid>}
                     <Grid>
                         {state.runsOpen > 0 &&
                             <Button variant="contained" color="secondary"
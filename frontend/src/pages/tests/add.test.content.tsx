@@ -273,7 +273,9 @@ const AddTestPage: React.FC = () => {
                                                                     Concurrent = runs each test on a different free
                                                                     device to get faster results<br/>
                                                                     Simultaneously = runs every test on every device
-                                                                    to get a better accuracy</Alert>
+                                                                    to get a better accuracy<br/>
+                                                                    Sharded = splits the tests by their previous durations
+                                                                    into balanced shards per device</Alert>
                                                             </Grid>
                                                         </Grid>
                                                     </Grid>
@@ -1,7 +1,7 @@
 import http from '../http-common';
 import IAccessTokenData from '../types/access.token';
 import {Dayjs} from "dayjs";
-import {INodeData} from "../types/node";
+import {INodeBundle, INodeData} from "../types/node";
 import {IUser} from '../types/user';
 
 export const getAccessTokens = (projectId: string): Promise<IAccessTokenData[]> => {
@@ -41,6 +41,42 @@ export const deleteNode = (projectId: string, id: number): Promise<void> => {
     return http.delete(`/${projectId}/settings/nodes/${id}`);
 };
 
+export interface DrainNodeRequest {
+    deadline?: string,
+    reason?: string,
+}
+
+export const drainNode = (projectId: string, id: number, data: DrainNodeRequest): Promise<INodeData> => {
+    return http.post(`/${projectId}/settings/nodes/${id}/drain`, data).then(response => response.data);
+};
+
+export const endNodeMaintenance = (projectId: string, id: number): Promise<INodeData> => {
+    return http.delete(`/${projectId}/settings/nodes/${id}/drain`).then(response => response.data);
+};
+
+export const getNodeBundles = (projectId: string, id: number): Promise<INodeBundle[]> => {
+    return http.get(`/${projectId}/settings/nodes/${id}/bundles`).then(response => response.data);
+};
+
+export const pinNodeBundle = (projectId: string, id: number, hash: string, pinned: boolean): Promise<void> => {
+    const url = `/${projectId}/settings/nodes/${id}/bundles/${hash}/pin`;
+    return pinned ? http.post(url) : http.delete(url);
+};
+
+export const deleteNodeBundle = (projectId: string, id: number, hash: string): Promise<INodeBundle[]> => {
+    return http.delete(`/${projectId}/settings/nodes/${id}/bundles/${hash}`).then(response => response.data);
+};
+
+export interface EvictNodeBundlesRequest {
+    maxSizeMB?: number,
+    maxAge?: string,
+    keepVersions?: number,
+}
+
+export const evictNodeBundles = (projectId: string, id: number, data: EvictNodeBundlesRequest): Promise<INodeBundle[]> => {
+    return http.post(`/${projectId}/settings/nodes/${id}/bundles/evict`, data).then(response => response.data);
+};
+
 export const getUsers = (projectId: string): Promise<IUser[]> => {
     return http.get(`/${projectId}/settings/users`).then(response => response.data)
 };
\ No newline at end of file
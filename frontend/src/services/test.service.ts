@@ -4,6 +4,7 @@ import ICreateTestData from '../types/request.create.test';
 import ITestRunData from '../types/test.run';
 import { TestExecutionType } from "../types/test.execution.type.enum";
 import IUnityTestFunctionData from "../types/unity.test.function";
+import { ITestMatrixSummary } from "../types/test.matrix";
 import { UnityTestCategory } from "../types/unity.test.category.type.enum";
 
 export const getAllTests = (projectId: string, appId: number | null): Promise<ITestData[]> => {
@@ -26,6 +27,8 @@ export interface UpdateTestData {
     unityTestCategoryType: UnityTestCategory,
     categories: string,
     testFunctions: IUnityTestFunctionData[],
+    testTimeout: number,
+    runTimeout: number,
 }
 
 export const updateTest = (projectId: string, appId: number | null, id: number, data: UpdateTestData): Promise<ITestData> => {
@@ -54,6 +57,26 @@ export const executeTest = (projectId: string, appId: number | null, id: number
     return http.post(`/${projectId}/app/${appId}/test/${id}/run`, testData).then(resp => resp.data)
 };
 
+export const rerunFailedTests = (projectId: string, appId: number | null, testId: number, runId: number): Promise<ITestRunData> => {
+    return http.post(`/${projectId}/app/${appId}/test/${testId}/run/${runId}/rerun-failed`).then(resp => resp.data)
+};
+
+export interface RunTestMatrixData extends RunTestData {
+    matrix: string,
+}
+
+export const executeTestMatrix = (projectId: string, appId: number | null, testId: number, testData: RunTestMatrixData): Promise<ITestMatrixSummary> => {
+    return http.post(`/${projectId}/app/${appId}/test/${testId}/matrix`, testData).then(resp => resp.data)
+};
+
+export const getTestMatrix = (projectId: string, appId: number | null, testId: number, matrixId: number): Promise<ITestMatrixSummary> => {
+    return http.get(`/${projectId}/app/${appId}/test/${testId}/matrix/${matrixId}`).then(resp => resp.data)
+};
+
+export const cancelTestMatrix = (projectId: string, appId: number | null, testId: number, matrixId: number): Promise<void> => {
+    return http.post(`/${projectId}/app/${appId}/test/${testId}/matrix/${matrixId}/cancel`);
+};
+
 export const cancelTestRun = (projectId: string, appId: number | null, testId: number, runId: number): Promise<void> => {
     return http.post(`/${projectId}/app/${appId}/test/${testId}/run/${runId}/cancel`);
 };
\ No newline at end of file
@@ -35,6 +35,12 @@ export interface IAppData {
     deletedAt: Date,
 }
 
+export interface IAppExecutable {
+    Executable: string,
+    Arguments: string,   // one argument per line
+    Environment: string, // one KEY=VALUE pair per line
+}
+
 export interface IAppBinaryData {
     id: number,
     name: string,
@@ -43,6 +49,7 @@ export interface IAppBinaryData {
     appPath: string,
     identifier: string,
     launchActivity: string,
+    executable?: IAppExecutable,
     additional: string,
     hash: string,
     size: number,
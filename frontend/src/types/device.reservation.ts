@@ -0,0 +1,9 @@
+export default interface IDeviceReservationData {
+    id?: number,
+    deviceId: number,
+    owner: string,
+    reason: string,
+    expiresAt: Date,
+    releasedAt?: Date | null,
+    createdAt?: Date,
+}
@@ -7,6 +7,7 @@ import {PlatformType} from "./platform.type.enum";
 import IParameter from './device.parameter';
 import {DeviceConnectionType} from "./device.connection.type.enum";
 import {DeviceStateType} from "./deviceStateType";
+import IDeviceReservationData from "./device.reservation";
 
 export default interface IDeviceData {
     id: number,
@@ -33,4 +34,6 @@ export default interface IDeviceData {
     deletedAt?: Date,
     customParameter: IParameter[],
     deviceParameter: IParameter[];
+    labels?: string,
+    reservation?: IDeviceReservationData | null,
 }
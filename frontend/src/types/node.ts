@@ -7,6 +7,19 @@ export interface INodeData {
     identifier: string,
     name: string,
     status: number,
+    maintenance: string,
+    maintenanceReason: string,
+    drainDeadline?: Date,
+    runningTests: number,
+    // only set while the node is connected
+    version?: string,
+    protocolVersion?: number,
+    features?: string[],
+    metrics?: INodeMetrics,
+    // only set once after the node was created and the master issued a certificate
+    certificate?: string,
+    privateKey?: string,
+    caCertificate?: string,
     //status?: INodeStatus,
 }
 
@@ -16,5 +29,32 @@ export const parseNodeData = (json: any): INodeData => {
         identifier: json.identifier,
         name: json.name,
         status: json.status,
+        maintenance: json.maintenance,
+        maintenanceReason: json.maintenanceReason,
+        drainDeadline: json.drainDeadline ? new Date(json.drainDeadline) : undefined,
+        runningTests: json.runningTests,
     }
 }
+
+export interface INodeBundle {
+    hash: string,
+    filename: string,
+    identifier: string,
+    size: number,
+    lastUsed: Date,
+    storedAt: Date,
+    pinned: boolean,
+}
+
+export interface INodeMetrics {
+    cpuCores: number,
+    cpuUsage: number,
+    memoryTotal: number,
+    memoryUsed: number,
+    diskTotal: number,
+    diskUsed: number,
+    networkReceived: number,
+    networkSent: number,
+    deviceSessions: number,
+    updatedAt: Date,
+}
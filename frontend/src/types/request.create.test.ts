@@ -13,4 +13,5 @@ export default interface ICreateTestData {
     unitySelectedTests: IAppFunctionData[],
     allDevices: boolean,
     selectedDevices: number[],
+    deviceSelector?: string,
 }
\ No newline at end of file
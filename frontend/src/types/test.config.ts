@@ -12,6 +12,9 @@ export default interface ITestConfigData {
     type: TestType,
     allDevices: boolean,
     devices: ITestConfigDeviceData[]
+    deviceSelector?: string,
+    testTimeout: number,
+    runTimeout: number,
     unity?: ITestConfigUnityData | null,
     createdAt: Date,
     updatedAt: Date,
@@ -3,6 +3,7 @@ import { IdName, ToArray } from '../helper/enum_to_array';
 export enum TestExecutionType {
     Concurrent,
     Simultaneously,
+    Sharded,
 }
 
 export const getExecutionTypes = (): Array<IdName> => {
@@ -0,0 +1,38 @@
+import ITestRunData from './test.run';
+import { TestResultState } from './test.result.state.enum';
+
+export default interface ITestMatrixData {
+    id?: number,
+    testId: number,
+    appBinaryId: number,
+    startUrl: string,
+    parameter: string,
+    dimensions: string,
+    finishedAt: Date | null,
+    runs?: ITestRunData[],
+    createdAt: Date,
+    updatedAt: Date,
+}
+
+export interface ITestMatrixDimension {
+    name: string,
+    values: string[],
+}
+
+export interface ITestMatrixCell {
+    combination: { [key: string]: string },
+    testRunId?: number,
+    result: TestResultState,
+    succeeded: number,
+    unstable: number,
+    failed: number,
+    quarantined: number,
+}
+
+export interface ITestMatrixSummary {
+    matrix: ITestMatrixData,
+    dimensions: ITestMatrixDimension[],
+    rows: string[],
+    columns: string[],
+    grid: ITestMatrixCell[][],
+}
@@ -17,6 +17,7 @@ export default interface ITestProtocolData {
     endedAt?: Date,
     entries: IProtocolEntryData[]
     testResult: TestResultState
+    quarantined?: boolean,
     performance: IProtocolPerformanceEntryData[]
     avgFps: number,
     avgMem: number,
@@ -3,4 +3,6 @@ export enum TestResultState {
     TestResultUnstable,
     TestResultFailed,
     TestResultSuccess,
+    TestResultNodeLost,
+    TestResultTimedOut,
 }
@@ -14,6 +14,9 @@ export default interface ITestRunData {
     appBinary: IAppBinaryData | null,
     startUrl: string,
     parameter: string,
+    rerunOfId?: number | null,
+    matrixId?: number | null,
+    matrixCombination?: string,
     testResult: TestResultState,
     protocols: ITestProtocolData[],
     log: ITesRunLogEntryData[],
@@ -127,6 +127,8 @@ type Test struct {
 	Method     string            `json:"method"`
 	Parameter  map[string]string `json:"parameter"`
 	Categories []string          `json:"categories"`
+	Platform   string            `json:"platform,omitempty"`
+	Timeout    int64             `json:"timeout,omitempty"` // milliseconds of the [Timeout] attribute
 }
 
 type Tests struct {
@@ -10,6 +10,10 @@ type TestStart struct {
 	Method   string
 	Env      map[string]string
 	Success  bool
+
+	// Categories and Platform select the tests of unity editors running in batchmode
+	Categories []string
+	Platform   string
 }
 
 func (a *TestStart) GetActionType() ActionType {
@@ -20,10 +24,12 @@ func (a *TestStart) Serialize() ([]byte, error) {
 	req := &Request{
 		ActionType: ActionType_ExecuteTest,
 		Payload: RequestData{Test: &Test{
-			Assembly:  a.Assembly,
-			Class:     a.Class,
-			Method:    a.Method,
-			Parameter: a.Env,
+			Assembly:   a.Assembly,
+			Class:      a.Class,
+			Method:     a.Method,
+			Parameter:  a.Env,
+			Categories: a.Categories,
+			Platform:   a.Platform,
 		}},
 	}
 	return json.Marshal(req)
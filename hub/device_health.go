@@ -0,0 +1,139 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"time"
+)
+
+const (
+	DefaultHealthCheckInterval = 1 * time.Minute
+)
+
+func (dm *DeviceManager) runHealthChecks(ctx context.Context) {
+	if !dm.healthCheck.Enabled {
+		return
+	}
+
+	interval := dm.healthCheck.Interval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				dm.checkDevicesHealth()
+			}
+		}
+	}()
+}
+
+func (dm *DeviceManager) checkDevicesHealth() {
+	for manager, m := range dm.deviceHandlers {
+		devices, err := m.GetDevices()
+		if err != nil {
+			continue
+		}
+		for _, dev := range devices {
+			// don't disturb running tests
+			if dev.IsLocked() {
+				continue
+			}
+			reason := dm.checkDeviceHealth(dev)
+			if len(reason) > 0 && !dev.IsQuarantined() {
+				dm.log.Warnf("quarantine device %s: %s", dev.DeviceID(), reason)
+				dev.Quarantine(reason)
+				dm.updateDeviceStore(manager, dev)
+			} else if len(reason) == 0 && dev.IsQuarantined() {
+				dm.log.Infof("device %s is healthy again", dev.DeviceID())
+				dev.ReleaseQuarantine()
+				dm.updateDeviceStore(manager, dev)
+			}
+		}
+	}
+}
+
+// TrackStartupFailures enables the quarantine of devices whose app failed to start in the last runs,
+// only the master stores the device status of test runs, the database of a node never has them
+func (dm *DeviceManager) TrackStartupFailures() {
+	dm.startupFailures = true
+}
+
+// checkDeviceHealth returns the reason why a device is unhealthy or an empty string
+func (dm *DeviceManager) checkDeviceHealth(dev device.Device) string {
+	if checker, ok := dev.(device.HealthChecker); ok {
+		// only booted devices can be probed
+		state := dev.DeviceState()
+		if state == device.StateBooted || state == device.StateQuarantined {
+			if err := checker.CheckHealth(device.HealthThresholds{
+				MinBatteryLevel:       dm.healthCheck.MinBatteryLevel,
+				MaxBatteryTemperature: dm.healthCheck.MaxBatteryTemperature,
+				MinFreeStorageMB:      dm.healthCheck.MinFreeStorageMB,
+			}); err != nil {
+				return err.Error()
+			}
+		}
+	}
+
+	if !dm.startupFailures || dm.db == nil || dm.healthCheck.StartupFailures <= 0 {
+		return ""
+	}
+
+	var dev2 models.Device
+	if err := dm.db.Where("device_identifier = ?", dev.DeviceID()).Limit(1).Find(&dev2).Error; err != nil || dev2.ID == 0 {
+		return ""
+	}
+
+	query := dm.db.Where("device_id = ?", dev2.ID)
+	dm.quarantineMutex.Lock()
+	releasedAt, released := dm.quarantineReleases[dev.DeviceID()]
+	dm.quarantineMutex.Unlock()
+	if released {
+		query = query.Where("created_at > ?", releasedAt)
+	}
+
+	var status []models.TestRunDeviceStatus
+	if err := query.Order("id desc").Limit(dm.healthCheck.StartupFailures).Find(&status).Error; err != nil {
+		return ""
+	}
+	if len(status) < dm.healthCheck.StartupFailures {
+		return ""
+	}
+	for i := range status {
+		if !status[i].StartupFailed {
+			return ""
+		}
+	}
+	return fmt.Sprintf("app startup failed in the last %d runs", len(status))
+}
+
+// ReleaseQuarantine releases a device manually, startup failures before the release are ignored afterwards
+func (dm *DeviceManager) ReleaseQuarantine(dev device.Device) error {
+	_, manager := dm.GetDevice(dev.DeviceID())
+	if len(manager) == 0 {
+		return device.DeviceNotFoundError
+	}
+	dm.quarantineMutex.Lock()
+	dm.quarantineReleases[dev.DeviceID()] = time.Now()
+	dm.quarantineMutex.Unlock()
+	dev.ReleaseQuarantine()
+	dm.updateDeviceStore(manager, dev)
+	return nil
+}
+
+func (dm *DeviceManager) updateDeviceStore(manager string, dev device.Device) {
+	if dm.deviceStore == nil {
+		return
+	}
+	if err := dm.deviceStore.Update(manager, dev); err != nil {
+		dm.log.Errorf("update device %s failed: %v", dev.DeviceID(), err)
+	}
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/gorilla/websocket"
 	"net/http"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/fsuhrau/automationhub/app"
 	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/storage"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"gorm.io/gorm"
 
@@ -35,11 +37,21 @@ type DeviceManager struct {
 	masterUrl      string
 	nodeIdentifier string
 	authToken      *string
+	deviceStore    storage.Device
+	healthCheck    config.HealthCheck
+	recovery       config.Recovery
+
+	quarantineReleases map[string]time.Time
+	quarantineMutex    sync.Mutex
+	startupFailures    bool
+	recoverySteps      []device.RecoveryStep
+	recoveryLevels     map[string]int
+	recoveryMutex      sync.Mutex
 
 	refreshSignal chan struct{}
 }
 
-func NewDeviceManager(logger *logrus.Logger, service config.Service) *DeviceManager {
+func NewDeviceManager(logger *logrus.Logger, service config.Service, db *gorm.DB, deviceStore storage.Device) *DeviceManager {
 	var authToken *string
 	if service.Auth.Token != nil {
 		authToken = &service.Auth.Token.AuthToken
@@ -47,11 +59,18 @@ func NewDeviceManager(logger *logrus.Logger, service config.Service) *DeviceMana
 	return &DeviceManager{log: logger.WithFields(logrus.Fields{
 		"prefix": "dm",
 	}),
+		db:             db,
+		deviceStore:    deviceStore,
+		healthCheck:    service.HealthCheck,
+		recovery:       service.Recovery,
 		masterUrl:      service.MasterURL,
 		nodeIdentifier: service.Identifier,
 		authToken:      authToken,
 		deviceHandlers: make(map[string]device.Handler),
 		deviceCache:    make(map[string]*models.Device),
+
+		quarantineReleases: make(map[string]time.Time),
+		recoveryLevels:     make(map[string]int),
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  protocol.SocketFrameSize,
 			WriteBufferSize: protocol.SocketFrameSize,
@@ -175,6 +194,10 @@ func (dm *DeviceManager) RegisterDevice(data device.RegisterData) (device.Device
 func (dm *DeviceManager) Run(ctx context.Context, runSocketListener bool) error {
 	dm.log.Debug("Starting device manager")
 
+	if err := dm.initRecovery(); err != nil {
+		return err
+	}
+
 	for _, v := range dm.deviceHandlers {
 		v.Init(dm.masterUrl, dm.nodeIdentifier, dm.authToken)
 		v.Start()
@@ -200,6 +223,8 @@ func (dm *DeviceManager) Run(ctx context.Context, runSocketListener bool) error
 		}
 	}()
 
+	dm.runHealthChecks(ctx)
+
 	return nil
 }
 
@@ -223,7 +248,7 @@ func (dm *DeviceManager) RegisterRoutes(r *gin.Engine, auth *gin.RouterGroup) er
 	deviceApi.GET("/connect", func(c *gin.Context) {
 		conn, err := dm.upgrader.Upgrade(c.Writer, c.Request, nil)
 		if err != nil {
-			fmt.Println("Failed to set websocket upgrade: %+v", err)
+			fmt.Printf("Failed to set websocket upgrade: %+v\n", err)
 			return
 		}
 
@@ -0,0 +1,136 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/app"
+	"github.com/fsuhrau/automationhub/device"
+	"time"
+)
+
+// initRecovery validates the configured recovery steps so that typos fail on startup instead of during a run
+func (dm *DeviceManager) initRecovery() error {
+	steps, err := device.ParseRecoverySteps(dm.recovery.Steps)
+	if err != nil {
+		return fmt.Errorf("invalid recovery configuration: %v", err)
+	}
+	dm.recoverySteps = steps
+	return nil
+}
+
+func (dm *DeviceManager) recoveryTimeout() time.Duration {
+	if dm.recovery.Timeout > 0 {
+		return dm.recovery.Timeout
+	}
+	return device.DefaultRecoveryTimeout
+}
+
+// RecoverDevice executes the next recovery step for a device which failed to start the app,
+// every consecutive failure escalates to the next configured step, the step is aborted after the recovery timeout
+func (dm *DeviceManager) RecoverDevice(ctx context.Context, dev device.Device, params *app.Parameter) error {
+	if !dm.recovery.Enabled {
+		return nil
+	}
+
+	steps := dm.recoverySteps
+	if len(steps) == 0 {
+		steps = device.DefaultRecoverySteps
+	}
+
+	dm.recoveryMutex.Lock()
+	level := dm.recoveryLevels[dev.DeviceID()]
+	if level >= len(steps) {
+		level = len(steps) - 1
+	}
+	dm.recoveryLevels[dev.DeviceID()] = level + 1
+	dm.recoveryMutex.Unlock()
+
+	step := steps[level]
+	dm.log.Infof("recovery step %d/%d '%s' for device %s", level+1, len(steps), step, dev.DeviceID())
+	dev.Log("recovery", "Execute recovery step %d/%d '%s'", level+1, len(steps), step)
+
+	ctx, cancel := context.WithTimeout(ctx, dm.recoveryTimeout())
+	defer cancel()
+
+	err := dm.ExecuteRecoveryStep(ctx, dev, params, step)
+	if err != nil {
+		dm.log.Errorf("recovery step '%s' for device %s failed: %v", step, dev.DeviceID(), err)
+		dev.Error("recovery", "Recovery step '%s' failed: %v", step, err)
+	}
+	return err
+}
+
+// ResetRecovery is called after a successful app start to restart the escalation from the first step
+func (dm *DeviceManager) ResetRecovery(dev device.Device) {
+	dm.recoveryMutex.Lock()
+	delete(dm.recoveryLevels, dev.DeviceID())
+	dm.recoveryMutex.Unlock()
+}
+
+// withContext runs an action which doesn't support a context, when the context is done the action
+// keeps running in the background but nobody waits for it anymore
+func withContext(ctx context.Context, action func() error) error {
+	result := make(chan error, 1)
+	go func() {
+		result <- action()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ExecuteRecoveryStep executes a single recovery step on the device, devices of nodes forward the step to their node
+func (dm *DeviceManager) ExecuteRecoveryStep(ctx context.Context, dev device.Device, params *app.Parameter, step device.RecoveryStep) error {
+	_, manager := dm.GetDevice(dev.DeviceID())
+	if len(manager) == 0 {
+		return device.DeviceNotFoundError
+	}
+
+	recoverable, isRecoverable := dev.(device.Recoverable)
+	restart := func() error {
+		if err := dm.Stop(dev); err != nil {
+			return err
+		}
+		return dm.Start(dev)
+	}
+
+	switch step {
+	case device.RecoveryForceStop:
+		if isRecoverable {
+			return recoverable.ForceStopApp(ctx, params)
+		}
+		return withContext(ctx, func() error {
+			return dev.StopApp(params)
+		})
+	case device.RecoveryClearData:
+		if isRecoverable {
+			return recoverable.ClearAppData(ctx, params)
+		}
+		return fmt.Errorf("clear data not supported by device")
+	case device.RecoveryReconnect:
+		if isRecoverable {
+			return recoverable.Reconnect(ctx)
+		}
+		return withContext(ctx, restart)
+	case device.RecoveryReboot:
+		if isRecoverable {
+			if err := recoverable.Reboot(ctx); err != nil {
+				return err
+			}
+			return withContext(ctx, func() error {
+				return dm.Start(dev)
+			})
+		}
+		return withContext(ctx, restart)
+	case device.RecoveryQuarantine:
+		reason := "app start failed after all recovery steps"
+		dm.log.Warnf("quarantine device %s: %s", dev.DeviceID(), reason)
+		dev.Quarantine(reason)
+		dm.updateDeviceStore(manager, dev)
+		return nil
+	}
+	return fmt.Errorf("unknown recovery step: %s", step)
+}
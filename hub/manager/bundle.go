@@ -0,0 +1,23 @@
+package manager
+
+import (
+	"time"
+)
+
+// Bundle is an app bundle in the cache of a node
+type Bundle struct {
+	Hash       string    `json:"hash"`
+	Filename   string    `json:"filename"`
+	Identifier string    `json:"identifier"`
+	Size       int64     `json:"size"`
+	LastUsed   time.Time `json:"lastUsed"`
+	StoredAt   time.Time `json:"storedAt"`
+	Pinned     bool      `json:"pinned"`
+}
+
+// BundlePolicy selects the bundles which are evicted from the cache of a node
+type BundlePolicy struct {
+	MaxSize      int64
+	MaxAge       time.Duration
+	KeepVersions int
+}
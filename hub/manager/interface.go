@@ -11,6 +11,22 @@ import (
 
 type NodeIdentifier string
 
+// NodeVersion is the build and protocol version a node registered with and the features negotiated with it
+type NodeVersion struct {
+	Version         string
+	ProtocolVersion int32
+	Features        []string
+}
+
+func (v NodeVersion) HasFeature(feature string) bool {
+	for _, f := range v.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
 type Devices interface {
 	Run(ctx context.Context, runSocketListener bool) error
 	Devices() (map[string][]device.Device, error)
@@ -26,6 +42,10 @@ type Devices interface {
 	FindDevice(params *app.Parameter) device.Device
 	RegisterDevice(data device.RegisterData) (device.Device, error)
 	Managers() []string
+	ReleaseQuarantine(dev device.Device) error
+	RecoverDevice(ctx context.Context, dev device.Device, params *app.Parameter) error
+	ExecuteRecoveryStep(ctx context.Context, dev device.Device, params *app.Parameter, step device.RecoveryStep) error
+	ResetRecovery(dev device.Device)
 }
 
 type Sessions interface {
@@ -48,9 +68,18 @@ type Nodes interface {
 	Run(ctx context.Context)
 	IsNodeKnown(nodeIdentifier NodeIdentifier) bool
 	GetStatus(nodeIdentifier NodeIdentifier) (int, error)
-	RegisterNode(nodeIdentifier NodeIdentifier, client RPCClient, hostname, address, operationSystem, environment string, port int32, managers []string)
+	RegisterNode(nodeIdentifier NodeIdentifier, client RPCClient, hostname, address, operationSystem, environment string, port int32, managers []string, version NodeVersion)
+	GetVersion(nodeIdentifier NodeIdentifier) (NodeVersion, error)
+	GetMetrics(nodeIdentifier NodeIdentifier) (*NodeMetrics, error)
 	GetNodes() []NodeIdentifier
 	GetManagers(nodeIdentifier NodeIdentifier) (map[string][]device.Device, error)
+	Drain(nodeIdentifier NodeIdentifier, deadline time.Duration, reason string) error
+	EndMaintenance(nodeIdentifier NodeIdentifier) error
+
+	// Bundle cache
+	GetBundles(nodeIdentifier NodeIdentifier) ([]Bundle, error)
+	PinBundle(nodeIdentifier NodeIdentifier, hash string, pinned bool) error
+	EvictBundles(nodeIdentifier NodeIdentifier, hashes []string, policy BundlePolicy) ([]Bundle, error)
 
 	// Manager Actions
 	StartDevice(nodeIdentifier NodeIdentifier, deviceId string) error
@@ -64,6 +93,7 @@ type Nodes interface {
 	UninstallApp(nodeIdentifier NodeIdentifier, deviceId string, parameter *app.Parameter) error
 	StartApp(nodeIdentifier NodeIdentifier, deviceId string, config *device.DeviceConfig, parameter *app.Parameter, sessionId string, nodeUrl string) error
 	StopApp(nodeIdentifier NodeIdentifier, deviceId string, parameter *app.Parameter) error
+	RecoverDevice(ctx context.Context, nodeIdentifier NodeIdentifier, deviceId string, step device.RecoveryStep, parameter *app.Parameter) error
 	IsConnected(nodeIdentifier NodeIdentifier, deviceId string) bool
 	StartRecording(nodeIdentifier NodeIdentifier, deviceId string, path string) error
 	StopRecording(nodeIdentifier NodeIdentifier, deviceId string) error
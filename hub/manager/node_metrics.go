@@ -0,0 +1,52 @@
+package manager
+
+import (
+	"time"
+)
+
+// DeviceSessionLoad is the load a single running device session adds to a node, it keeps
+// allocations spread even if a node doesn't report resource metrics
+const DeviceSessionLoad = 0.25
+
+// NetworkCapacity is the bandwidth in bytes per second the network rates are normalized to, nodes don't
+// report their link speed so a gigabit link is assumed
+const NetworkCapacity = 1000 * 1000 * 1000 / 8
+
+// NodeMetrics is the resource usage reported by a node, network rates are in bytes per second
+type NodeMetrics struct {
+	CPUCores        int       `json:"cpuCores"`
+	CPUUsage        float64   `json:"cpuUsage"`
+	MemoryTotal     uint64    `json:"memoryTotal"`
+	MemoryUsed      uint64    `json:"memoryUsed"`
+	DiskTotal       uint64    `json:"diskTotal"`
+	DiskUsed        uint64    `json:"diskUsed"`
+	NetworkReceived uint64    `json:"networkReceived"`
+	NetworkSent     uint64    `json:"networkSent"`
+	DeviceSessions  int       `json:"deviceSessions"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// Load returns the utilization of the most used resource between 0 and 1
+func (m *NodeMetrics) Load() float64 {
+	if m == nil {
+		return 0
+	}
+
+	load := m.CPUUsage / 100
+	if m.MemoryTotal > 0 {
+		if memory := float64(m.MemoryUsed) / float64(m.MemoryTotal); memory > load {
+			load = memory
+		}
+	}
+	if m.DiskTotal > 0 {
+		// a full disk only matters once it is nearly exhausted
+		if disk := float64(m.DiskUsed)/float64(m.DiskTotal)*2 - 1; disk > load {
+			load = disk
+		}
+	}
+	// bundle downloads and screen streaming of a busy node saturate its link before cpu or memory
+	if network := float64(m.NetworkReceived+m.NetworkSent) / NetworkCapacity; network > load {
+		load = network
+	}
+	return load
+}
@@ -0,0 +1,26 @@
+package manager
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNodeMetricsLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics *NodeMetrics
+		load    float64
+	}{
+		{name: "no metrics", metrics: nil, load: 0},
+		{name: "cpu", metrics: &NodeMetrics{CPUUsage: 40, MemoryTotal: 100, MemoryUsed: 20}, load: 0.4},
+		{name: "memory", metrics: &NodeMetrics{CPUUsage: 10, MemoryTotal: 100, MemoryUsed: 70}, load: 0.7},
+		{name: "disk nearly full", metrics: &NodeMetrics{CPUUsage: 10, DiskTotal: 100, DiskUsed: 90}, load: 0.8},
+		{name: "network", metrics: &NodeMetrics{CPUUsage: 10, NetworkReceived: NetworkCapacity / 2, NetworkSent: NetworkCapacity / 4}, load: 0.75},
+	}
+
+	for _, test := range tests {
+		if load := test.metrics.Load(); math.Abs(load-test.load) > 0.0001 {
+			t.Errorf("%s: expected load %.2f got %.2f", test.name, test.load, load)
+		}
+	}
+}
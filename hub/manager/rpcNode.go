@@ -9,6 +9,8 @@ import (
 
 type RPCClient interface {
 	Ping() error
+	Heartbeat(timeout time.Duration) error
+	Close() error
 	GetDevices() (map[string][]device.Device, error)
 	StartDevice(deviceId string) error
 	StopDevice(deviceId string) error
@@ -17,6 +19,7 @@ type RPCClient interface {
 	UninstallApp(deviceId string, parameter *app.Parameter) error
 	StartApp(deviceId string, config *device.DeviceConfig, parameter *app.Parameter, sessionId string, nodeUrl string) error
 	StopApp(deviceId string, parameter *app.Parameter) error
+	RecoverDevice(ctx context.Context, deviceId string, step device.RecoveryStep, parameter *app.Parameter) error
 	IsConnected(deviceId string) bool
 	StartRecording(deviceId string, path string) error
 	StopRecording(deviceId string) error
@@ -28,4 +31,8 @@ type RPCClient interface {
 	SendAction(deviceId string, action []byte)
 	UploadApp(ctx context.Context, parameter *app.Parameter) error
 	IsAppUploaded(parameter *app.Parameter) (bool, error)
+	GetMetrics(timeout time.Duration) (*NodeMetrics, error)
+	GetBundles() ([]Bundle, error)
+	PinBundle(hash string, pinned bool) error
+	EvictBundles(hashes []string, policy BundlePolicy) ([]Bundle, error)
 }
@@ -2,6 +2,7 @@ package hub
 
 import (
 	"github.com/fsuhrau/automationhub/hub/manager"
+	"time"
 )
 
 type NodeStatus int
@@ -21,4 +22,8 @@ type Node struct {
 	Managers        []string
 	Status          NodeStatus
 	Handler         manager.RPCClient
+	LastHeartbeat   time.Time
+	MissedHeartbeat int
+	Version         manager.NodeVersion
+	Metrics         *manager.NodeMetrics
 }
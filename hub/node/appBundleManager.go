@@ -1,34 +1,72 @@
 package node
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/sirupsen/logrus"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 )
 
 var (
-	ErrAppNotFound = fmt.Errorf("AppBundleMetaData not found")
+	ErrAppNotFound  = fmt.Errorf("AppBundleMetaData not found")
+	ErrBundlePinned = fmt.Errorf("bundle is pinned")
+	ErrBundleInUse  = fmt.Errorf("bundle is in use")
 )
 
 type AppBundleMetaData struct {
-	Filename string `json:"filename"`
-	FileHash string `json:"fileHash"`
-	FileSize int64  `json:"fileSize"`
-	FilePath string `json:"-"`
+	Filename   string    `json:"filename"`
+	FileHash   string    `json:"fileHash"`
+	FileSize   int64     `json:"fileSize"`
+	Identifier string    `json:"identifier"`
+	StoredAt   time.Time `json:"storedAt"`
+	LastUsed   time.Time `json:"lastUsed"`
+	Pinned     bool      `json:"pinned"`
+	FilePath   string    `json:"-"`
 }
 
+// CachePolicy defines which bundles are removed from the cache, pinned bundles are never removed by a policy
+type CachePolicy struct {
+	MaxSize      int64
+	MaxAge       time.Duration
+	KeepVersions int
+}
+
+// CachePolicyFromConfig converts the bundle cache configuration into a policy
+func CachePolicyFromConfig(cfg config.BundleCache) CachePolicy {
+	return CachePolicy{
+		MaxSize:      cfg.MaxSizeMB * 1024 * 1024,
+		MaxAge:       cfg.MaxAge,
+		KeepVersions: cfg.KeepVersions,
+	}
+}
+
+// AppBundleManager is a content addressed cache of app bundles, bundles are stored by their hash
+// and removed according to the cache policy
 type AppBundleManager struct {
 	dataDir  string
+	policy   CachePolicy
 	metadata map[string]*AppBundleMetaData
-	mutex    sync.Mutex
+	// inUse counts the installations of a bundle which are running, bundles in use are never removed
+	inUse map[string]int
+	mutex sync.Mutex
 }
 
-func NewAppBundleManager(dataDir string) (*AppBundleManager, error) {
+func NewAppBundleManager(dataDir string, policy CachePolicy) (*AppBundleManager, error) {
 	m := &AppBundleManager{
 		dataDir:  dataDir,
+		policy:   policy,
 		metadata: make(map[string]*AppBundleMetaData),
+		inUse:    make(map[string]int),
+	}
+
+	if err := os.MkdirAll(m.partialDir(), os.ModePerm); err != nil {
+		return nil, err
 	}
 
 	if err := m.loadMetadata(); err != nil {
@@ -56,7 +94,14 @@ func (dm *AppBundleManager) loadMetadata() error {
 			if err := json.Unmarshal(fileData, &metaData); err != nil {
 				return fmt.Errorf("failed to unmarshal metaData: %v", err)
 			}
-			metaData.FilePath = filepath.Join(dm.dataDir, metaData.Filename)
+			if metaData.StoredAt.IsZero() {
+				metaData.StoredAt = metaData.LastUsed
+			}
+			metaData.FilePath = dm.bundlePath(metaData.FileHash, metaData.Filename)
+			if _, err := os.Stat(metaData.FilePath); err != nil {
+				// bundles stored before the cache was content addressed
+				metaData.FilePath = filepath.Join(dm.dataDir, metaData.Filename)
+			}
 			dm.metadata[metaData.FileHash] = &metaData
 		}
 	}
@@ -64,28 +109,51 @@ func (dm *AppBundleManager) loadMetadata() error {
 	return nil
 }
 
-func (dm *AppBundleManager) StoreData(appData []byte, metadata *AppBundleMetaData) error {
-	dm.mutex.Lock()
-	defer dm.mutex.Unlock()
+func (dm *AppBundleManager) bundlePath(hash, filename string) string {
+	return filepath.Join(dm.dataDir, hash, filename)
+}
 
-	// Save the binary data
-	binaryFilePath := filepath.Join(dm.dataDir, metadata.Filename)
-	if err := os.WriteFile(binaryFilePath, appData, os.ModePerm); err != nil {
-		return fmt.Errorf("failed to save binary data: %v", err)
-	}
+func (dm *AppBundleManager) metadataPath(hash string) string {
+	return filepath.Join(dm.dataDir, fmt.Sprintf("%s.meta", hash))
+}
 
-	// Save the AppBundleMetaData as a JSON file
-	metadataFilePath := filepath.Join(dm.dataDir, fmt.Sprintf("%s.meta", metadata.FileHash))
-	metadata.FilePath = binaryFilePath
+func (dm *AppBundleManager) partialDir() string {
+	return filepath.Join(dm.dataDir, "partial")
+}
+
+func (dm *AppBundleManager) writeMetadata(metadata *AppBundleMetaData) error {
 	metadataBytes, err := json.Marshal(metadata)
 	if err != nil {
 		return fmt.Errorf("failed to marshal AppBundleMetaData: %v", err)
 	}
-	if err := os.WriteFile(metadataFilePath, metadataBytes, os.ModePerm); err != nil {
+	if err := os.WriteFile(dm.metadataPath(metadata.FileHash), metadataBytes, os.ModePerm); err != nil {
 		return fmt.Errorf("failed to save AppBundleMetaData: %v", err)
 	}
+	return nil
+}
+
+// StoreFile moves a downloaded and verified bundle into the cache
+func (dm *AppBundleManager) StoreFile(path string, metadata *AppBundleMetaData) error {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	binaryFilePath := dm.bundlePath(metadata.FileHash, metadata.Filename)
+	if err := os.MkdirAll(filepath.Dir(binaryFilePath), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.Rename(path, binaryFilePath); err != nil {
+		return fmt.Errorf("failed to save binary data: %v", err)
+	}
+
+	metadata.FilePath = binaryFilePath
+	metadata.StoredAt = time.Now()
+	metadata.LastUsed = metadata.StoredAt
+	if err := dm.writeMetadata(metadata); err != nil {
+		return err
+	}
 
 	dm.metadata[metadata.FileHash] = metadata
+	dm.enforce(dm.policy, metadata.FileHash)
 	return nil
 }
 
@@ -93,10 +161,219 @@ func (dm *AppBundleManager) GetAppParameter(hash string) (*AppBundleMetaData, er
 	dm.mutex.Lock()
 	defer dm.mutex.Unlock()
 
+	return dm.getAppParameter(hash)
+}
+
+// Acquire returns the bundle and protects it from being removed until it is released
+func (dm *AppBundleManager) Acquire(hash string) (*AppBundleMetaData, error) {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	metadata, err := dm.getAppParameter(hash)
+	if err != nil {
+		return nil, err
+	}
+	dm.inUse[hash]++
+	return metadata, nil
+}
+
+// Release allows the policy to remove the bundle again once all users released it
+func (dm *AppBundleManager) Release(hash string) {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	if dm.inUse[hash] <= 1 {
+		delete(dm.inUse, hash)
+		return
+	}
+	dm.inUse[hash]--
+}
+
+func (dm *AppBundleManager) getAppParameter(hash string) (*AppBundleMetaData, error) {
 	metadata, exists := dm.metadata[hash]
 	if !exists {
 		return nil, ErrAppNotFound
 	}
 
+	metadata.LastUsed = time.Now()
+	if err := dm.writeMetadata(metadata); err != nil {
+		logrus.Warnf("unable to update last use of bundle %s: %v", hash, err)
+	}
+
 	return metadata, nil
 }
+
+// Bundles returns all cached bundles ordered by their last use
+func (dm *AppBundleManager) Bundles() []AppBundleMetaData {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	var bundles []AppBundleMetaData
+	for _, m := range dm.sortedByLastUse() {
+		bundles = append(bundles, *m)
+	}
+	return bundles
+}
+
+// Pin protects a bundle from being removed by the cache policy
+func (dm *AppBundleManager) Pin(hash string, pinned bool) error {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	metadata, exists := dm.metadata[hash]
+	if !exists {
+		return ErrAppNotFound
+	}
+
+	metadata.Pinned = pinned
+	return dm.writeMetadata(metadata)
+}
+
+// Evict removes the given bundles from the cache, pinned bundles need to be unpinned first
+func (dm *AppBundleManager) Evict(hashes []string) ([]AppBundleMetaData, error) {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	var evicted []AppBundleMetaData
+	for _, hash := range hashes {
+		metadata, exists := dm.metadata[hash]
+		if !exists {
+			return evicted, ErrAppNotFound
+		}
+		if metadata.Pinned {
+			return evicted, fmt.Errorf("%s: %w", metadata.Filename, ErrBundlePinned)
+		}
+		if dm.inUse[hash] > 0 {
+			return evicted, fmt.Errorf("%s: %w", metadata.Filename, ErrBundleInUse)
+		}
+		if err := dm.remove(metadata); err != nil {
+			return evicted, err
+		}
+		logrus.Infof("evicted bundle %s (%s) from cache", metadata.Filename, humanReadableSize(metadata.FileSize))
+		evicted = append(evicted, *metadata)
+	}
+	return evicted, nil
+}
+
+// Enforce removes all bundles which are not covered by the policy
+func (dm *AppBundleManager) Enforce(policy CachePolicy) []AppBundleMetaData {
+	dm.mutex.Lock()
+	defer dm.mutex.Unlock()
+
+	return dm.enforce(policy, "")
+}
+
+// RunJanitor periodically enforces the configured cache policy until the context is done
+func (dm *AppBundleManager) RunJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if evicted := dm.Enforce(dm.policy); len(evicted) > 0 {
+				logrus.Infof("bundle janitor removed %d bundles", len(evicted))
+			}
+		}
+	}
+}
+
+func (dm *AppBundleManager) sortedByLastUse() []*AppBundleMetaData {
+	var bundles []*AppBundleMetaData
+	for _, m := range dm.metadata {
+		bundles = append(bundles, m)
+	}
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].LastUsed.Before(bundles[j].LastUsed)
+	})
+	return bundles
+}
+
+// enforce removes outdated bundles, surplus versions and the least recently used bundles until the cache fits into the quota
+func (dm *AppBundleManager) enforce(policy CachePolicy, keepHash string) []AppBundleMetaData {
+	var evict []*AppBundleMetaData
+	marked := make(map[string]bool)
+	protected := func(m *AppBundleMetaData) bool {
+		return m.Pinned || m.FileHash == keepHash || dm.inUse[m.FileHash] > 0
+	}
+	mark := func(m *AppBundleMetaData) {
+		if protected(m) || marked[m.FileHash] {
+			return
+		}
+		marked[m.FileHash] = true
+		evict = append(evict, m)
+	}
+
+	bundles := dm.sortedByLastUse()
+
+	if policy.MaxAge > 0 {
+		for _, m := range bundles {
+			if time.Since(m.LastUsed) > policy.MaxAge {
+				mark(m)
+			}
+		}
+	}
+
+	if policy.KeepVersions > 0 {
+		versions := make(map[string][]*AppBundleMetaData)
+		for _, m := range bundles {
+			if len(m.Identifier) > 0 {
+				versions[m.Identifier] = append(versions[m.Identifier], m)
+			}
+		}
+		for _, v := range versions {
+			sort.Slice(v, func(i, j int) bool {
+				return v[i].StoredAt.After(v[j].StoredAt)
+			})
+			for i := policy.KeepVersions; i < len(v); i++ {
+				mark(v[i])
+			}
+		}
+	}
+
+	if policy.MaxSize > 0 {
+		var size int64
+		for _, m := range bundles {
+			if !marked[m.FileHash] {
+				size += m.FileSize
+			}
+		}
+		for _, m := range bundles {
+			if size <= policy.MaxSize {
+				break
+			}
+			if protected(m) || marked[m.FileHash] {
+				continue
+			}
+			mark(m)
+			size -= m.FileSize
+		}
+	}
+
+	var evicted []AppBundleMetaData
+	for _, m := range evict {
+		if err := dm.remove(m); err != nil {
+			logrus.Errorf("unable to evict bundle %s: %v", m.Filename, err)
+			continue
+		}
+		logrus.Infof("evicted bundle %s (%s) from cache", m.Filename, humanReadableSize(m.FileSize))
+		evicted = append(evicted, *m)
+	}
+	return evicted
+}
+
+func (dm *AppBundleManager) remove(m *AppBundleMetaData) error {
+	if err := os.Remove(m.FilePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if dir := filepath.Dir(m.FilePath); dir != dm.dataDir {
+		_ = os.Remove(dir)
+	}
+	if err := os.Remove(dm.metadataPath(m.FileHash)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	delete(dm.metadata, m.FileHash)
+	return nil
+}
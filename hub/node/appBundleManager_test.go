@@ -0,0 +1,198 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testBundle struct {
+	hash       string
+	identifier string
+	size       int64
+	age        time.Duration // since the bundle was stored
+	unused     time.Duration // since the last use
+	pinned     bool
+	inUse      bool
+}
+
+// newTestBundleManager stores the bundles in a temp dir, the bundles are not evicted on store
+func newTestBundleManager(t *testing.T, bundles []testBundle) *AppBundleManager {
+	dm, err := NewAppBundleManager(t.TempDir(), CachePolicy{})
+	if err != nil {
+		t.Fatalf("unable to create bundle manager: %v", err)
+	}
+
+	now := time.Now()
+	for _, b := range bundles {
+		path := filepath.Join(dm.partialDir(), b.hash)
+		if err := os.WriteFile(path, make([]byte, b.size), os.ModePerm); err != nil {
+			t.Fatalf("unable to write bundle: %v", err)
+		}
+		metadata := &AppBundleMetaData{Filename: b.hash + ".apk", FileHash: b.hash, FileSize: b.size, Identifier: b.identifier}
+		if err := dm.StoreFile(path, metadata); err != nil {
+			t.Fatalf("unable to store bundle: %v", err)
+		}
+		metadata.StoredAt = now.Add(-b.age)
+		metadata.LastUsed = now.Add(-b.unused)
+		metadata.Pinned = b.pinned
+		if b.inUse {
+			if _, err := dm.Acquire(b.hash); err != nil {
+				t.Fatalf("unable to acquire bundle: %v", err)
+			}
+		}
+	}
+	return dm
+}
+
+func bundleHashes(bundles []AppBundleMetaData) string {
+	var hashes []string
+	for _, b := range bundles {
+		hashes = append(hashes, b.FileHash)
+	}
+	sort.Strings(hashes)
+	return strings.Join(hashes, ",")
+}
+
+func TestEnforceCachePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  CachePolicy
+		bundles []testBundle
+		evicted string
+	}{
+		{
+			name:   "max age",
+			policy: CachePolicy{MaxAge: time.Hour},
+			bundles: []testBundle{
+				{hash: "old", size: 10, unused: 2 * time.Hour},
+				{hash: "recent", size: 10, unused: 10 * time.Minute},
+			},
+			evicted: "old",
+		},
+		{
+			name:   "keep versions",
+			policy: CachePolicy{KeepVersions: 2},
+			bundles: []testBundle{
+				{hash: "v1", identifier: "app", size: 10, age: 3 * time.Hour},
+				{hash: "v2", identifier: "app", size: 10, age: 2 * time.Hour},
+				{hash: "v3", identifier: "app", size: 10, age: time.Hour, unused: 5 * time.Hour},
+				{hash: "other", identifier: "other", size: 10, age: 4 * time.Hour},
+			},
+			evicted: "v1",
+		},
+		{
+			name:   "least recently used exceeding the max size",
+			policy: CachePolicy{MaxSize: 200},
+			bundles: []testBundle{
+				{hash: "a", size: 100, unused: 3 * time.Hour},
+				{hash: "b", size: 100, unused: 2 * time.Hour},
+				{hash: "c", size: 100, unused: time.Hour},
+			},
+			evicted: "a",
+		},
+		{
+			name:   "pinned bundles are kept",
+			policy: CachePolicy{MaxSize: 200, MaxAge: time.Hour},
+			bundles: []testBundle{
+				{hash: "a", size: 100, unused: 3 * time.Hour, pinned: true},
+				{hash: "b", size: 100, unused: 2 * time.Minute},
+				{hash: "c", size: 100, unused: time.Minute},
+			},
+			evicted: "b",
+		},
+		{
+			name:   "bundles in use are kept",
+			policy: CachePolicy{MaxSize: 100, MaxAge: time.Hour},
+			bundles: []testBundle{
+				{hash: "a", size: 100, unused: 3 * time.Hour, inUse: true},
+				{hash: "b", size: 100, unused: 2 * time.Minute},
+			},
+			evicted: "b",
+		},
+		{
+			name:   "no policy",
+			policy: CachePolicy{},
+			bundles: []testBundle{
+				{hash: "a", size: 100, unused: 3 * time.Hour},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		dm := newTestBundleManager(t, test.bundles)
+		evicted := dm.Enforce(test.policy)
+		if hashes := bundleHashes(evicted); hashes != test.evicted {
+			t.Errorf("%s: expected %q to be evicted got %q", test.name, test.evicted, hashes)
+		}
+		for _, b := range evicted {
+			if _, err := os.Stat(b.FilePath); !os.IsNotExist(err) {
+				t.Errorf("%s: expected %s to be removed from disk", test.name, b.FilePath)
+			}
+		}
+		if remaining := len(dm.Bundles()); remaining != len(test.bundles)-len(evicted) {
+			t.Errorf("%s: unexpected %d remaining bundles", test.name, remaining)
+		}
+	}
+}
+
+func TestEvictBundles(t *testing.T) {
+	dm := newTestBundleManager(t, []testBundle{
+		{hash: "pinned", size: 10, pinned: true},
+		{hash: "installing", size: 10, inUse: true},
+		{hash: "free", size: 10},
+	})
+
+	if _, err := dm.Evict([]string{"pinned"}); !errors.Is(err, ErrBundlePinned) {
+		t.Errorf("expected pinned bundle to be kept got %v", err)
+	}
+	if _, err := dm.Evict([]string{"installing"}); !errors.Is(err, ErrBundleInUse) {
+		t.Errorf("expected bundle in use to be kept got %v", err)
+	}
+	if evicted, err := dm.Evict([]string{"free"}); err != nil || bundleHashes(evicted) != "free" {
+		t.Errorf("expected free bundle to be evicted got %v %v", evicted, err)
+	}
+
+	dm.Release("installing")
+	if evicted, err := dm.Evict([]string{"installing"}); err != nil || bundleHashes(evicted) != "installing" {
+		t.Errorf("expected released bundle to be evicted got %v %v", evicted, err)
+	}
+
+	if err := dm.Pin("pinned", false); err != nil {
+		t.Fatalf("unable to unpin bundle: %v", err)
+	}
+	if _, err := dm.Evict([]string{"pinned"}); err != nil {
+		t.Errorf("expected unpinned bundle to be evicted got %v", err)
+	}
+}
+
+func TestBundleJanitor(t *testing.T) {
+	dm := newTestBundleManager(t, []testBundle{
+		{hash: "old", size: 10, unused: 2 * time.Hour},
+		{hash: "recent", size: 10},
+	})
+	dm.policy = CachePolicy{MaxAge: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		dm.RunJanitor(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(dm.Bundles()) > 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if hashes := bundleHashes(dm.Bundles()); hashes != "recent" {
+		t.Errorf("expected janitor to remove the old bundle got %q", hashes)
+	}
+}
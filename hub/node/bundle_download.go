@@ -0,0 +1,361 @@
+package node
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultChunkSize      = 16 * 1024 * 1024
+	DefaultParallelChunks = 4
+	chunkRetries          = 5
+)
+
+// chunkRetryDelay is the delay before the first retry of a failed chunk, it grows with every attempt
+var chunkRetryDelay = 2 * time.Second
+
+// downloadState is stored next to the partial file so that an interrupted download continues with the missing chunks
+type downloadState struct {
+	Hash      string `json:"hash"`
+	Size      int64  `json:"size"`
+	ChunkSize int64  `json:"chunkSize"`
+	Completed []bool `json:"completed"`
+}
+
+type bundleDownload struct {
+	url       string
+	authToken string
+	hash      string
+	size      int64
+	chunkSize int64
+	parallel  int
+
+	partPath  string
+	statePath string
+
+	received  int64
+	mutex     sync.Mutex
+	state     downloadState
+	client    *http.Client
+	rangeable bool
+	chunked   bool
+}
+
+func newBundleDownload(dir, url, authToken, hash string, size, chunkSize int64, parallel int) *bundleDownload {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if parallel <= 0 {
+		parallel = DefaultParallelChunks
+	}
+	return &bundleDownload{
+		url:       url,
+		authToken: authToken,
+		hash:      hash,
+		size:      size,
+		chunkSize: chunkSize,
+		parallel:  parallel,
+		partPath:  filepath.Join(dir, hash+".part"),
+		statePath: filepath.Join(dir, hash+".state"),
+		client:    mtls.HTTPClient(0),
+		chunked:   true,
+	}
+}
+
+func (d *bundleDownload) Received() int64 {
+	return atomic.LoadInt64(&d.received)
+}
+
+// Run downloads the bundle in chunks and verifies it against the hash, the path of the verified file is returned
+func (d *bundleDownload) Run(ctx context.Context) (string, error) {
+	if err := d.probe(ctx); err != nil {
+		return "", err
+	}
+
+	if d.chunked && d.rangeable && d.size > 0 {
+		if err := d.downloadChunks(ctx); err != nil {
+			return "", err
+		}
+	} else {
+		if err := d.downloadStream(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	if err := d.verify(); err != nil {
+		d.cleanup()
+		return "", err
+	}
+	_ = os.Remove(d.statePath)
+	return d.partPath, nil
+}
+
+func (d *bundleDownload) newRequest(ctx context.Context, method string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, d.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.authToken) > 0 {
+		req.Header.Set("X-Auth-Token", d.authToken)
+	}
+	return req, nil
+}
+
+// probe checks if the master supports range requests for the bundle
+func (d *bundleDownload) probe(ctx context.Context) error {
+	req, err := d.newRequest(ctx, http.MethodHead)
+	if err != nil {
+		return err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bundle not available: %s", resp.Status)
+	}
+	if resp.ContentLength > 0 {
+		if d.size > 0 && d.size != resp.ContentLength {
+			return fmt.Errorf("bundle size mismatch expected %d got %d", d.size, resp.ContentLength)
+		}
+		d.size = resp.ContentLength
+	}
+	d.rangeable = resp.Header.Get("Accept-Ranges") == "bytes"
+	return nil
+}
+
+func (d *bundleDownload) loadState(chunks int) {
+	d.state = downloadState{Hash: d.hash, Size: d.size, ChunkSize: d.chunkSize, Completed: make([]bool, chunks)}
+
+	data, err := os.ReadFile(d.statePath)
+	if err != nil {
+		return
+	}
+	var state downloadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return
+	}
+	if state.Hash != d.hash || state.Size != d.size || state.ChunkSize != d.chunkSize || len(state.Completed) != chunks {
+		return
+	}
+	if info, err := os.Stat(d.partPath); err != nil || info.Size() != d.size {
+		return
+	}
+	d.state = state
+}
+
+func (d *bundleDownload) chunkDone(index int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.state.Completed[index] = true
+	if data, err := json.Marshal(&d.state); err == nil {
+		_ = os.WriteFile(d.statePath, data, os.ModePerm)
+	}
+}
+
+func (d *bundleDownload) downloadChunks(ctx context.Context) error {
+	chunks := int((d.size + d.chunkSize - 1) / d.chunkSize)
+	d.loadState(chunks)
+
+	file, err := os.OpenFile(d.partPath, os.O_CREATE|os.O_RDWR, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := file.Truncate(d.size); err != nil {
+		return err
+	}
+
+	jobs := make(chan int, chunks)
+	for i := 0; i < chunks; i++ {
+		if d.state.Completed[i] {
+			atomic.AddInt64(&d.received, d.chunkLength(i))
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+
+	if resumed := d.Received(); resumed > 0 {
+		logrus.Infof("resume download of %s at %s", d.hash, humanReadableSize(resumed))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		firstErr error
+	)
+	for w := 0; w < d.parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				if err := d.downloadChunkWithRetry(ctx, file, index); err != nil {
+					errMutex.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMutex.Unlock()
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (d *bundleDownload) chunkLength(index int) int64 {
+	start := int64(index) * d.chunkSize
+	if start+d.chunkSize > d.size {
+		return d.size - start
+	}
+	return d.chunkSize
+}
+
+func (d *bundleDownload) downloadChunkWithRetry(ctx context.Context, file *os.File, index int) error {
+	var err error
+	for attempt := 0; attempt < chunkRetries; attempt++ {
+		if attempt > 0 {
+			logrus.Warnf("retry chunk %d of %s (%d/%d): %v", index, d.hash, attempt, chunkRetries-1, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * chunkRetryDelay):
+			}
+		}
+		if err = d.downloadChunk(ctx, file, index); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return fmt.Errorf("download chunk %d failed: %v", index, err)
+}
+
+func (d *bundleDownload) downloadChunk(ctx context.Context, file *os.File, index int) error {
+	start := int64(index) * d.chunkSize
+	length := d.chunkLength(index)
+
+	req, err := d.newRequest(ctx, http.MethodGet)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, start+length-1))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	// count the progress while reading, a failed chunk is downloaded again completely
+	var written int64
+	buffer := make([]byte, 256*1024)
+	for written < length {
+		n, readErr := resp.Body.Read(buffer)
+		if n > 0 {
+			if int64(n) > length-written {
+				n = int(length - written)
+			}
+			if _, err := file.WriteAt(buffer[:n], start+written); err != nil {
+				atomic.AddInt64(&d.received, -written)
+				return err
+			}
+			written += int64(n)
+			atomic.AddInt64(&d.received, int64(n))
+		}
+		if readErr != nil {
+			if readErr == io.EOF && written == length {
+				break
+			}
+			atomic.AddInt64(&d.received, -written)
+			if readErr == io.EOF {
+				return fmt.Errorf("chunk incomplete %d of %d bytes", written, length)
+			}
+			return readErr
+		}
+	}
+
+	d.chunkDone(index)
+	return nil
+}
+
+// downloadStream is the fallback for servers without range support
+func (d *bundleDownload) downloadStream(ctx context.Context) error {
+	req, err := d.newRequest(ctx, http.MethodGet)
+	if err != nil {
+		return err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download file: %s", resp.Status)
+	}
+
+	file, err := os.Create(d.partPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, io.TeeReader(resp.Body, progressWriter{&d.received}))
+	return err
+}
+
+func (d *bundleDownload) verify() error {
+	if len(d.hash) == 0 {
+		return nil
+	}
+	file, err := os.Open(d.partPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	h := sha1.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return err
+	}
+	if sum := fmt.Sprintf("%x", h.Sum(nil)); sum != d.hash {
+		return fmt.Errorf("checksum mismatch expected %s got %s", d.hash, sum)
+	}
+	return nil
+}
+
+func (d *bundleDownload) cleanup() {
+	_ = os.Remove(d.partPath)
+	_ = os.Remove(d.statePath)
+}
+
+type progressWriter struct {
+	received *int64
+}
+
+func (w progressWriter) Write(p []byte) (int, error) {
+	atomic.AddInt64(w.received, int64(len(p)))
+	return len(p), nil
+}
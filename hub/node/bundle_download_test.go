@@ -0,0 +1,218 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testChunkSize = 1024
+
+// bundleServer serves a bundle like the master and records the requested ranges
+type bundleServer struct {
+	*httptest.Server
+	content []byte
+
+	mutex  sync.Mutex
+	ranges []string
+	gets   int
+	// truncate lets the server answer the first request of the range with half of the data
+	truncate string
+	// noRanges serves the bundle like a server without range support
+	noRanges bool
+}
+
+func newBundleServer(t *testing.T, size int) *bundleServer {
+	content := make([]byte, size)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	s := &bundleServer{content: content}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *bundleServer) serve(w http.ResponseWriter, r *http.Request) {
+	if s.noRanges {
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(s.content)))
+		if r.Method == http.MethodGet {
+			s.mutex.Lock()
+			s.gets++
+			s.mutex.Unlock()
+			_, _ = w.Write(s.content)
+		}
+		return
+	}
+
+	rangeHeader := r.Header.Get("Range")
+	if r.Method == http.MethodGet {
+		s.mutex.Lock()
+		s.gets++
+		s.ranges = append(s.ranges, rangeHeader)
+		truncate := len(rangeHeader) > 0 && rangeHeader == s.truncate
+		if truncate {
+			s.truncate = ""
+		}
+		s.mutex.Unlock()
+
+		if truncate {
+			var start, end int
+			_, _ = fmt.Sscanf(rangeHeader, "bytes=%d-%d", &start, &end)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(s.content)))
+			w.Header().Set("Content-Length", fmt.Sprintf("%d", end-start+1))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write(s.content[start : start+(end-start+1)/2])
+			return
+		}
+	}
+	http.ServeContent(w, r, "bundle.apk", time.Time{}, bytes.NewReader(s.content))
+}
+
+func (s *bundleServer) hash() string {
+	return fmt.Sprintf("%x", sha1.Sum(s.content))
+}
+
+func (s *bundleServer) requested(rangeHeader string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	count := 0
+	for _, r := range s.ranges {
+		if r == rangeHeader {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *bundleServer) download(dir, hash string) *bundleDownload {
+	return newBundleDownload(dir, s.URL, "", hash, int64(len(s.content)), testChunkSize, 2)
+}
+
+func chunkRange(index int) string {
+	return fmt.Sprintf("bytes=%d-%d", index*testChunkSize, (index+1)*testChunkSize-1)
+}
+
+func expectBundle(t *testing.T, path string, content []byte) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read bundle: %v", err)
+	}
+	if !bytes.Equal(data, content) {
+		t.Errorf("downloaded bundle differs from the served one")
+	}
+}
+
+func TestBundleDownloadChunks(t *testing.T) {
+	server := newBundleServer(t, 4*testChunkSize)
+	d := server.download(t.TempDir(), server.hash())
+
+	path, err := d.Run(context.Background())
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	expectBundle(t, path, server.content)
+
+	for i := 0; i < 4; i++ {
+		if server.requested(chunkRange(i)) != 1 {
+			t.Errorf("expected chunk %d to be requested once got %v", i, server.ranges)
+		}
+	}
+	if d.Received() != int64(len(server.content)) {
+		t.Errorf("expected progress of %d got %d", len(server.content), d.Received())
+	}
+	if _, err := os.Stat(d.statePath); !os.IsNotExist(err) {
+		t.Errorf("expected state to be removed after the download")
+	}
+}
+
+func TestBundleDownloadResume(t *testing.T) {
+	server := newBundleServer(t, 4*testChunkSize)
+	dir := t.TempDir()
+	d := server.download(dir, server.hash())
+
+	// the first two chunks were downloaded before the download got interrupted
+	part := make([]byte, len(server.content))
+	copy(part, server.content[:2*testChunkSize])
+	if err := os.WriteFile(d.partPath, part, os.ModePerm); err != nil {
+		t.Fatalf("unable to write part: %v", err)
+	}
+	state, _ := json.Marshal(downloadState{Hash: server.hash(), Size: int64(len(server.content)), ChunkSize: testChunkSize, Completed: []bool{true, true, false, false}})
+	if err := os.WriteFile(d.statePath, state, os.ModePerm); err != nil {
+		t.Fatalf("unable to write state: %v", err)
+	}
+
+	path, err := d.Run(context.Background())
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	expectBundle(t, path, server.content)
+
+	if server.requested(chunkRange(0)) != 0 || server.requested(chunkRange(1)) != 0 {
+		t.Errorf("expected completed chunks not to be downloaded again got %v", server.ranges)
+	}
+	if server.requested(chunkRange(2)) != 1 || server.requested(chunkRange(3)) != 1 {
+		t.Errorf("expected missing chunks to be downloaded got %v", server.ranges)
+	}
+}
+
+func TestBundleDownloadRetriesTruncatedChunk(t *testing.T) {
+	chunkRetryDelay = time.Millisecond
+	defer func() { chunkRetryDelay = 2 * time.Second }()
+
+	server := newBundleServer(t, 3*testChunkSize)
+	server.truncate = chunkRange(1)
+	d := server.download(t.TempDir(), server.hash())
+
+	path, err := d.Run(context.Background())
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	expectBundle(t, path, server.content)
+
+	if server.requested(chunkRange(1)) != 2 {
+		t.Errorf("expected truncated chunk to be requested again got %v", server.ranges)
+	}
+	if d.Received() != int64(len(server.content)) {
+		t.Errorf("expected progress of the truncated chunk to be reset got %d", d.Received())
+	}
+}
+
+func TestBundleDownloadWithoutRanges(t *testing.T) {
+	server := newBundleServer(t, 3*testChunkSize)
+	server.noRanges = true
+	d := server.download(t.TempDir(), server.hash())
+
+	path, err := d.Run(context.Background())
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	expectBundle(t, path, server.content)
+
+	if server.gets != 1 {
+		t.Errorf("expected a single download got %d requests", server.gets)
+	}
+}
+
+func TestBundleDownloadChecksumMismatch(t *testing.T) {
+	server := newBundleServer(t, 2*testChunkSize)
+	d := server.download(t.TempDir(), fmt.Sprintf("%x", sha1.Sum([]byte("other"))))
+
+	if _, err := d.Run(context.Background()); err == nil {
+		t.Fatalf("expected checksum mismatch")
+	}
+	if _, err := os.Stat(d.partPath); !os.IsNotExist(err) {
+		t.Errorf("expected part to be removed")
+	}
+	if _, err := os.Stat(d.statePath); !os.IsNotExist(err) {
+		t.Errorf("expected state to be removed")
+	}
+}
@@ -19,6 +19,8 @@ func fromDeviceState(state device2.State) DeviceState {
 		return DeviceState_Booted
 	case device2.StateLocked:
 		return DeviceState_Locked
+	case device2.StateQuarantined:
+		return DeviceState_Quarantined
 	}
 	return DeviceState_Unknown
 }
@@ -37,6 +39,8 @@ func toDeviceState(state DeviceState) device2.State {
 		return device2.StateBooted
 	case DeviceState_Locked:
 		return device2.StateLocked
+	case DeviceState_Quarantined:
+		return device2.StateQuarantined
 	}
 	return device2.StateUnknown
 }
@@ -44,15 +48,16 @@ func toDeviceState(state DeviceState) device2.State {
 func toDeviceResponse(manager string, dev device2.Device) *DeviceResponse {
 	data, _ := json.Marshal(dev.DeviceParameter())
 	return &DeviceResponse{
-		Manager:         manager,
-		DeviceID:        dev.DeviceID(),
-		Name:            dev.DeviceName(),
-		DeviceOS:        dev.DeviceOSName(),
-		DeviceOSVersion: dev.DeviceOSVersion(),
-		ProjectDir:      "",
-		State:           fromDeviceState(dev.DeviceState()),
-		DeviceType:      int32(dev.DeviceType()),
-		DeviceParameter: string(data),
-		PlatformType:    int32(dev.PlatformType()),
+		Manager:          manager,
+		DeviceID:         dev.DeviceID(),
+		Name:             dev.DeviceName(),
+		DeviceOS:         dev.DeviceOSName(),
+		DeviceOSVersion:  dev.DeviceOSVersion(),
+		ProjectDir:       "",
+		State:            fromDeviceState(dev.DeviceState()),
+		DeviceType:       int32(dev.DeviceType()),
+		DeviceParameter:  string(data),
+		PlatformType:     int32(dev.PlatformType()),
+		QuarantineReason: dev.QuarantineReason(),
 	}
 }
@@ -39,6 +39,10 @@ func (d *RPCDevice) DeviceState() device.State {
 	return toDeviceState(d.Dev.State)
 }
 
+func (d *RPCDevice) QuarantineReason() string {
+	return d.Dev.QuarantineReason
+}
+
 func (d *RPCDevice) DeviceOSName() string {
 	return d.Dev.DeviceOS
 }
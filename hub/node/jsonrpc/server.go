@@ -17,6 +17,7 @@ var errMissingParams = errors.New("jsonrpc: request body missing params")
 
 type serverCodec struct {
 	conn *websocket.Conn
+	read func() (int, []byte, error)
 	/*
 		dec  *json.Decoder // for reading JSON values
 		enc  *json.Encoder // for writing JSON values
@@ -39,8 +40,15 @@ type serverCodec struct {
 
 // NewServerCodec returns a new [rpc.ServerCodec] using JSON-RPC on conn.
 func NewServerCodec(conn *websocket.Conn) rpc.ServerCodec {
+	return NewServerCodecWithReader(conn, conn.ReadMessage)
+}
+
+// NewServerCodecWithReader returns a new [rpc.ServerCodec] using JSON-RPC on conn which reads the requests with read,
+// it allows to serve messages which were already read from conn before.
+func NewServerCodecWithReader(conn *websocket.Conn, read func() (int, []byte, error)) rpc.ServerCodec {
 	return &serverCodec{
 		conn: conn,
+		read: read,
 		/*
 			dec:     json.NewDecoder(conn),
 			enc:     json.NewEncoder(conn),
@@ -70,7 +78,7 @@ type serverResponse struct {
 
 func (c *serverCodec) ReadRequestHeader(r *rpc.Request) error {
 	c.req.reset()
-	_, msg, err := c.conn.ReadMessage()
+	_, msg, err := c.read()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,64 @@
+package node
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// cpuSample and networkSample are cumulative counters, the usage is calculated from the difference of two samples
+type cpuSample struct {
+	idle  uint64
+	total uint64
+}
+
+type networkSample struct {
+	received uint64
+	sent     uint64
+	time     time.Time
+}
+
+// metricsCollector collects the resource usage of the node
+type metricsCollector struct {
+	mutex       sync.Mutex
+	dataDir     string
+	lastCPU     *cpuSample
+	lastNetwork *networkSample
+}
+
+func newMetricsCollector(dataDir string) *metricsCollector {
+	return &metricsCollector{
+		dataDir: dataDir,
+	}
+}
+
+// Collect fills in the current usage, values which are not available on the platform stay zero
+func (c *metricsCollector) Collect(metrics *NodeMetricsResponse, deviceSessions int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	metrics.CPUCores = int32(runtime.NumCPU())
+	metrics.DeviceSessions = int32(deviceSessions)
+
+	if sample, ok := readCPUSample(); ok {
+		if c.lastCPU != nil && sample.total > c.lastCPU.total {
+			idle := float64(sample.idle - c.lastCPU.idle)
+			total := float64(sample.total - c.lastCPU.total)
+			metrics.CPUUsage = (1 - idle/total) * 100
+		}
+		c.lastCPU = sample
+	}
+
+	metrics.MemoryTotal, metrics.MemoryUsed, _ = readMemoryUsage()
+	metrics.DiskTotal, metrics.DiskUsed, _ = readDiskUsage(c.dataDir)
+
+	if sample, ok := readNetworkSample(); ok {
+		if c.lastNetwork != nil {
+			if seconds := sample.time.Sub(c.lastNetwork.time).Seconds(); seconds > 0 && sample.received >= c.lastNetwork.received && sample.sent >= c.lastNetwork.sent {
+				metrics.NetworkReceived = uint64(float64(sample.received-c.lastNetwork.received) / seconds)
+				metrics.NetworkSent = uint64(float64(sample.sent-c.lastNetwork.sent) / seconds)
+			}
+		}
+		c.lastNetwork = sample
+	}
+}
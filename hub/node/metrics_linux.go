@@ -0,0 +1,111 @@
+package node
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func readCPUSample() (*cpuSample, bool) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		sample := &cpuSample{}
+		for i, field := range fields[1:] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			sample.total += value
+			// idle and iowait
+			if i == 3 || i == 4 {
+				sample.idle += value
+			}
+		}
+		return sample, true
+	}
+	return nil, false
+}
+
+func readMemoryUsage() (uint64, uint64, bool) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0, false
+	}
+	defer file.Close()
+
+	var total, available uint64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = value * 1024
+		case "MemAvailable:":
+			available = value * 1024
+		}
+	}
+	if total == 0 || available > total {
+		return 0, 0, false
+	}
+	return total, total - available, true
+}
+
+func readDiskUsage(path string) (uint64, uint64, bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, false
+	}
+	total := stat.Blocks * uint64(stat.Bsize)
+	free := stat.Bavail * uint64(stat.Bsize)
+	return total, total - free, true
+}
+
+func readNetworkSample() (*networkSample, bool) {
+	file, err := os.Open("/proc/net/dev")
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	sample := &networkSample{time: time.Now()}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
+		}
+		if strings.TrimSpace(line[:idx]) == "lo" {
+			continue
+		}
+		fields := strings.Fields(line[idx+1:])
+		if len(fields) < 9 {
+			continue
+		}
+		received, _ := strconv.ParseUint(fields[0], 10, 64)
+		sent, _ := strconv.ParseUint(fields[8], 10, 64)
+		sample.received += received
+		sample.sent += sent
+	}
+	return sample, true
+}
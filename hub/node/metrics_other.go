@@ -0,0 +1,21 @@
+//go:build !linux
+
+package node
+
+// resource metrics are only collected on linux, other platforms report their device sessions only
+
+func readCPUSample() (*cpuSample, bool) {
+	return nil, false
+}
+
+func readMemoryUsage() (uint64, uint64, bool) {
+	return 0, 0, false
+}
+
+func readDiskUsage(path string) (uint64, uint64, bool) {
+	return 0, 0, false
+}
+
+func readNetworkSample() (*networkSample, bool) {
+	return nil, false
+}
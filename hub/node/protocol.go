@@ -0,0 +1,40 @@
+package node
+
+// ProtocolVersion is the version of the master/node protocol, it has to be increased on every breaking change.
+// nodes which don't send a version are treated as version 0 and accepted with an empty feature set, all protocol
+// versions are compatible so far, rejecting outdated nodes is deferred until a version drops support for an older one
+const ProtocolVersion int32 = 1
+
+// features which are optional and negotiated between master and node on registration
+const (
+	FeatureHeartbeat        = "heartbeat"
+	FeatureChunkedDownload  = "chunked_download"
+	FeatureBundleManagement = "bundle_management"
+	FeatureNodeMetrics      = "node_metrics"
+	FeatureDeviceRecovery   = "device_recovery"
+)
+
+// SupportedFeatures lists all features this build supports
+var SupportedFeatures = []string{
+	FeatureHeartbeat,
+	FeatureChunkedDownload,
+	FeatureBundleManagement,
+	FeatureNodeMetrics,
+	FeatureDeviceRecovery,
+}
+
+// NegotiateFeatures returns the features supported by both sides
+func NegotiateFeatures(remote []string) []string {
+	supported := make(map[string]bool)
+	for _, f := range remote {
+		supported[f] = true
+	}
+
+	var features []string
+	for _, f := range SupportedFeatures {
+		if supported[f] {
+			features = append(features, f)
+		}
+	}
+	return features
+}
@@ -29,7 +29,7 @@ func (h *ReconnectHandler) ObserveConnectionState(ctx context.Context, cfg confi
 		select {
 		case <-ctx.Done():
 		default:
-			if h.lastPing.Add(30 * time.Second).Before(time.Now()) {
+			if h.lastPing.Add(cfg.NodeHeartbeat.LostAfter()).Before(time.Now()) {
 				_ = h.HandleConnect()
 			}
 			time.Sleep(5 * time.Second)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/hub/action"
 	"net/http"
 	"time"
@@ -36,7 +37,7 @@ func (w *RemoteActionHandler) OnActionResponse(d interface{}, response *action.R
 		return
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/node/action", w.masterURL), bytes.NewBuffer(reqData))
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s://%s/node/action", mtls.HTTPScheme(), w.masterURL), bytes.NewBuffer(reqData))
 	if err != nil {
 		return
 	}
@@ -45,7 +46,7 @@ func (w *RemoteActionHandler) OnActionResponse(d interface{}, response *action.R
 		req.Header.Set("X-Auth-Token", *w.authToken)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := mtls.HTTPClient(10 * time.Second)
 	resp, err := client.Do(req)
 	if err != nil {
 		return
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"github.com/fsuhrau/automationhub/app"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/config/protocol"
 	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/hub/manager"
 	"github.com/fsuhrau/automationhub/hub/node/jsonrpc"
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
@@ -42,6 +44,22 @@ func (rpc *RPCClient) Ping() error {
 	return rpc.safeCall("RPCNode.Ping", &Void{}, &Void{})
 }
 
+// Heartbeat pings the node but doesn't wait longer than timeout for the answer
+func (rpc *RPCClient) Heartbeat(timeout time.Duration) error {
+	call := rpc.client.Go("RPCNode.Ping", &Void{}, &Void{}, nil)
+	select {
+	case <-call.Done:
+		return call.Error
+	case <-time.After(timeout):
+		return fmt.Errorf("heartbeat timed out after %v", timeout)
+	}
+}
+
+// Close closes the connection, pending calls return with an error instead of waiting for an answer of a lost node
+func (rpc *RPCClient) Close() error {
+	return rpc.client.Close()
+}
+
 func (rpc *RPCClient) GetDevices() (map[string][]device.Device, error) {
 	logrus.Info("RPCNode.GetDevices")
 
@@ -103,7 +121,9 @@ func getAppParameterRequest(deviceId string, parameter *app.Parameter) *AppParam
 		var executableParams *ExecutableParams
 		if parameter.App.Executable != nil {
 			executableParams = &ExecutableParams{
-				Executable: parameter.App.Executable.Executable,
+				Executable:  parameter.App.Executable.Executable,
+				Arguments:   parameter.App.Executable.Arguments,
+				Environment: parameter.App.Executable.Environment,
 			}
 		}
 
@@ -166,7 +186,7 @@ func (rpc *RPCClient) UploadApp(ctx context.Context, parameter *app.Parameter) e
 
 	filename := filepath.Base(parameter.App.AppPath)
 
-	fileURL, _ := url.JoinPath("http://"+rpc.masterURL, "upload", filename)
+	fileURL, _ := url.JoinPath(mtls.HTTPScheme()+"://"+rpc.masterURL, "upload", filename)
 	appId := int32(parameter.App.AppBinaryID)
 	appSize := int64(parameter.App.Size)
 	uploadRequest := &UploadAppRequest{
@@ -188,15 +208,19 @@ func (rpc *RPCClient) UploadApp(ctx context.Context, parameter *app.Parameter) e
 
 	var progressResponse UploadAppProgressResponse
 	for {
-		time.Sleep(time.Second)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
 		if err := rpc.safeCall("RPCNode.UploadAppProgress", UploadAppProgressRequest{AppID: appId}, &progressResponse); err != nil {
 			return err
 		}
-		if resp.ErrorCode != 0 {
-			return fmt.Errorf(resp.ErrorMessage)
-		}
 
-		if progressResponse.DataReceived == appSize {
+		if progressResponse.Completed {
+			if len(progressResponse.Error) > 0 {
+				return fmt.Errorf("upload failed: %s", progressResponse.Error)
+			}
 			logrus.Info("RPCNode.UploadApp complete")
 			break
 		}
@@ -204,6 +228,84 @@ func (rpc *RPCClient) UploadApp(ctx context.Context, parameter *app.Parameter) e
 	return nil
 }
 
+// GetMetrics fetches the resource usage of the node but doesn't wait longer than timeout for the answer
+func (rpc *RPCClient) GetMetrics(timeout time.Duration) (*manager.NodeMetrics, error) {
+	var resp NodeMetricsResponse
+	call := rpc.client.Go("RPCNode.GetMetrics", &Void{}, &resp, nil)
+	select {
+	case <-call.Done:
+		if call.Error != nil {
+			return nil, call.Error
+		}
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("metrics timed out after %v", timeout)
+	}
+	return &manager.NodeMetrics{
+		CPUCores:        int(resp.CPUCores),
+		CPUUsage:        resp.CPUUsage,
+		MemoryTotal:     resp.MemoryTotal,
+		MemoryUsed:      resp.MemoryUsed,
+		DiskTotal:       resp.DiskTotal,
+		DiskUsed:        resp.DiskUsed,
+		NetworkReceived: resp.NetworkReceived,
+		NetworkSent:     resp.NetworkSent,
+		DeviceSessions:  int(resp.DeviceSessions),
+		UpdatedAt:       time.Now(),
+	}, nil
+}
+
+func toBundles(entries []*BundleEntry) []manager.Bundle {
+	var bundles []manager.Bundle
+	for _, e := range entries {
+		bundles = append(bundles, manager.Bundle{
+			Hash:       e.Hash,
+			Filename:   e.Filename,
+			Identifier: e.Identifier,
+			Size:       e.Size,
+			LastUsed:   time.Unix(e.LastUsed, 0),
+			StoredAt:   time.Unix(e.StoredAt, 0),
+			Pinned:     e.Pinned,
+		})
+	}
+	return bundles
+}
+
+func (rpc *RPCClient) GetBundles() ([]manager.Bundle, error) {
+	logrus.Info("RPCNode.GetBundles")
+	var resp BundlesResponse
+	if err := rpc.safeCall("RPCNode.GetBundles", &Void{}, &resp); err != nil {
+		return nil, err
+	}
+	return toBundles(resp.Bundles), nil
+}
+
+func (rpc *RPCClient) PinBundle(hash string, pinned bool) error {
+	logrus.Info("RPCNode.PinBundle")
+	var resp BoolResponse
+	if err := rpc.safeCall("RPCNode.PinBundle", &PinBundleRequest{Hash: hash, Pinned: pinned}, &resp); err != nil {
+		return err
+	}
+	if resp.ErrorCode != 0 {
+		return fmt.Errorf(resp.ErrorMessage)
+	}
+	return nil
+}
+
+func (rpc *RPCClient) EvictBundles(hashes []string, policy manager.BundlePolicy) ([]manager.Bundle, error) {
+	logrus.Info("RPCNode.EvictBundles")
+	var resp BundlesResponse
+	req := &EvictBundlesRequest{
+		Hashes:       hashes,
+		MaxSize:      policy.MaxSize,
+		MaxAge:       int64(policy.MaxAge / time.Second),
+		KeepVersions: int32(policy.KeepVersions),
+	}
+	if err := rpc.safeCall("RPCNode.EvictBundles", req, &resp); err != nil {
+		return nil, err
+	}
+	return toBundles(resp.Bundles), nil
+}
+
 func (rpc *RPCClient) InstallApp(deviceId string, parameter *app.Parameter) error {
 	logrus.Info("RPCNode.InstallApp")
 	var resp BoolResponse
@@ -274,6 +376,37 @@ func (rpc *RPCClient) StopApp(deviceId string, parameter *app.Parameter) error {
 	return nil
 }
 
+// RecoverDevice executes the recovery step on the node, the node gets the remaining time of the context for the step
+func (rpc *RPCClient) RecoverDevice(ctx context.Context, deviceId string, step device.RecoveryStep, parameter *app.Parameter) error {
+	logrus.Info("RPCNode.RecoverDevice")
+
+	req := &RecoverDeviceRequest{
+		App:  &AppParameterRequest{DeviceID: deviceId},
+		Step: string(step),
+	}
+	if parameter != nil {
+		req.App = getAppParameterRequest(deviceId, parameter)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		req.Timeout = time.Until(deadline).Milliseconds()
+	}
+
+	var resp BoolResponse
+	call := rpc.client.Go("RPCNode.RecoverDevice", req, &resp, nil)
+	select {
+	case <-call.Done:
+		if call.Error != nil {
+			return call.Error
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if resp.ErrorCode != 0 {
+		return fmt.Errorf(resp.ErrorMessage)
+	}
+	return nil
+}
+
 func (rpc *RPCClient) IsConnected(deviceId string) bool {
 	logrus.Info("RPCNode.IsConnected")
 
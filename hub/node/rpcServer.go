@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/fsuhrau/automationhub/app"
@@ -9,16 +10,15 @@ import (
 	"github.com/fsuhrau/automationhub/hub/manager"
 	"github.com/fsuhrau/automationhub/storage/apps"
 	"github.com/sirupsen/logrus"
-	"io"
-	"net/http"
 	"sync"
 	"time"
 )
 
 type UploadProgress struct {
-	Request           *UploadAppRequest
-	NextProgressPrint *time.Time
-	Data              []byte
+	Request   *UploadAppRequest
+	download  *bundleDownload
+	completed bool
+	err       error
 }
 
 type RPCNode struct {
@@ -29,10 +29,13 @@ type RPCNode struct {
 	mutex             sync.Mutex
 	uploadRequests    map[int32]*UploadProgress
 	screenshots       map[string][]byte
+	metrics           *metricsCollector
+	sessions          map[string]bool
+	masterFeatures    map[string]bool
 }
 
 func NewRPCNode(config config.Service, dm manager.Devices, ch ConnectionHandler) *RPCNode {
-	abm, err := NewAppBundleManager(apps.AppBundleStoragePath)
+	abm, err := NewAppBundleManager(apps.AppBundleStoragePath, CachePolicyFromConfig(config.BundleCache))
 	if err != nil {
 		panic(err)
 	}
@@ -43,6 +46,19 @@ func NewRPCNode(config config.Service, dm manager.Devices, ch ConnectionHandler)
 		abm:               abm,
 		uploadRequests:    make(map[int32]*UploadProgress),
 		screenshots:       make(map[string][]byte),
+		metrics:           newMetricsCollector(apps.AppBundleStoragePath),
+		sessions:          make(map[string]bool),
+	}
+}
+
+// SetMasterFeatures stores the features negotiated with the master on registration
+func (s *RPCNode) SetMasterFeatures(features []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.masterFeatures = make(map[string]bool)
+	for _, f := range features {
+		s.masterFeatures[f] = true
 	}
 }
 
@@ -122,7 +138,9 @@ func getAppParameter(req *AppParameterRequest, data *AppBundleMetaData) *app.Par
 		var executableParams *app.ExecutableParams
 		if req.App.Executable != nil {
 			executableParams = &app.ExecutableParams{
-				Executable: req.App.Executable.Executable,
+				Executable:  req.App.Executable.Executable,
+				Arguments:   req.App.Executable.Arguments,
+				Environment: req.App.Executable.Environment,
 			}
 		}
 		appParams = &app.AppParams{
@@ -192,12 +210,32 @@ func (s *RPCNode) UploadApp(req *UploadAppRequest, resp *BoolResponse) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	// a download of the same bundle is still running
+	if progress, ok := s.uploadRequests[req.AppID]; ok && !progress.completed && progress.Request.Hash == req.Hash {
+		resp.Value = true
+		return nil
+	}
+
 	progress := &UploadProgress{
 		Request: req,
 	}
 
 	s.uploadRequests[req.AppID] = progress
 
+	if _, err := s.abm.GetAppParameter(req.Hash); err == nil {
+		progress.completed = true
+		resp.Value = true
+		return nil
+	}
+
+	var authToken string
+	if s.config.Auth.Token != nil {
+		authToken = s.config.Auth.Token.AuthToken
+	}
+	progress.download = newBundleDownload(s.abm.partialDir(), req.URL, authToken, req.Hash, req.Size, s.config.BundleCache.ChunkSizeMB*1024*1024, s.config.BundleCache.ParallelChunks)
+	// masters which didn't negotiate chunked downloads get a plain download
+	progress.download.chunked = s.masterFeatures[FeatureChunkedDownload]
+
 	go s.downloadFile(progress)
 
 	resp.Value = true
@@ -205,56 +243,25 @@ func (s *RPCNode) UploadApp(req *UploadAppRequest, resp *BoolResponse) error {
 }
 
 func (s *RPCNode) downloadFile(progress *UploadProgress) {
-	req, err := http.NewRequest("GET", progress.Request.URL, nil)
-	if err != nil {
-		logrus.Error("Failed to create request: ", err)
-		return
-	}
-
-	if s.config.Auth.Token != nil {
-		req.Header.Set("X-Auth-Token", s.config.Auth.Token.AuthToken)
+	start := time.Now()
+	path, err := progress.download.Run(context.Background())
+	if err == nil {
+		logrus.Infof("Download of %s complete in %v storing file", progress.Request.Name, time.Since(start))
+		err = s.abm.StoreFile(path, &AppBundleMetaData{
+			Filename:   progress.Request.Name,
+			FileHash:   progress.Request.Hash,
+			FileSize:   progress.download.size,
+			Identifier: progress.Request.Identifier,
+		})
 	}
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
 	if err != nil {
-		logrus.Error("Failed to download file: ", err)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		logrus.Error("Failed to download file: ", resp.Status)
-		return
-	}
-
-	progress.Data = make([]byte, 0, progress.Request.Size)
-	buffer := make([]byte, 10*1024*1024) // 10MB buffer size
-	totalRead := int64(0)
-
-	for {
-		n, err := resp.Body.Read(buffer)
-		if n > 0 {
-			progress.Data = append(progress.Data, buffer[:n]...)
-			totalRead += int64(n)
-		}
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			logrus.Error("Failed to read file data: ", err)
-			return
-		}
+		logrus.Errorf("Failed to download %s: %v", progress.Request.Name, err)
 	}
 
-	logrus.Infof("Download Complete stroging file")
-	if err := s.abm.StoreData(progress.Data, &AppBundleMetaData{
-		Filename: progress.Request.Name,
-		FileHash: progress.Request.Hash,
-		FileSize: progress.Request.Size,
-	}); err != nil {
-		logrus.Error(err)
-	}
+	s.mutex.Lock()
+	progress.completed = true
+	progress.err = err
+	s.mutex.Unlock()
 }
 
 func humanReadableSize(bytes int64) string {
@@ -283,11 +290,90 @@ func (s *RPCNode) UploadAppProgress(req *UploadAppProgressRequest, resp *UploadA
 	}
 
 	resp.AppID = req.AppID
-	resp.DataReceived = int64(len(progress.Data))
+	resp.Completed = progress.completed
+	if progress.download != nil {
+		resp.DataReceived = progress.download.Received()
+	} else if progress.completed {
+		resp.DataReceived = progress.Request.Size
+	}
+	if progress.err != nil {
+		resp.Error = progress.err.Error()
+	}
 
 	return nil
 }
 
+func (s *RPCNode) setSession(deviceID string, running bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if running {
+		s.sessions[deviceID] = true
+	} else {
+		delete(s.sessions, deviceID)
+	}
+}
+
+func (s *RPCNode) GetMetrics(req *Void, resp *NodeMetricsResponse) error {
+	s.mutex.Lock()
+	sessions := len(s.sessions)
+	s.mutex.Unlock()
+
+	s.metrics.Collect(resp, sessions)
+	return nil
+}
+
+// RunBundleJanitor enforces the bundle cache policy in the background until the context is done
+func (s *RPCNode) RunBundleJanitor(ctx context.Context) {
+	s.abm.RunJanitor(ctx, s.config.BundleCache.GetJanitorInterval())
+}
+
+func toBundleEntries(bundles []AppBundleMetaData) []*BundleEntry {
+	var entries []*BundleEntry
+	for _, b := range bundles {
+		entries = append(entries, &BundleEntry{
+			Hash:       b.FileHash,
+			Filename:   b.Filename,
+			Identifier: b.Identifier,
+			Size:       b.FileSize,
+			LastUsed:   b.LastUsed.Unix(),
+			StoredAt:   b.StoredAt.Unix(),
+			Pinned:     b.Pinned,
+		})
+	}
+	return entries
+}
+
+func (s *RPCNode) GetBundles(req *Void, resp *BundlesResponse) error {
+	logrus.Info("RPC: GetBundles")
+	resp.Bundles = toBundleEntries(s.abm.Bundles())
+	return nil
+}
+
+func (s *RPCNode) PinBundle(req *PinBundleRequest, resp *BoolResponse) error {
+	logrus.Info("RPC: PinBundle")
+	if err := s.abm.Pin(req.Hash, req.Pinned); err != nil {
+		return err
+	}
+	resp.Value = true
+	return nil
+}
+
+func (s *RPCNode) EvictBundles(req *EvictBundlesRequest, resp *BundlesResponse) error {
+	logrus.Info("RPC: EvictBundles")
+	if len(req.Hashes) > 0 {
+		evicted, err := s.abm.Evict(req.Hashes)
+		resp.Bundles = toBundleEntries(evicted)
+		return err
+	}
+
+	resp.Bundles = toBundleEntries(s.abm.Enforce(CachePolicy{
+		MaxSize:      req.MaxSize,
+		MaxAge:       time.Duration(req.MaxAge) * time.Second,
+		KeepVersions: int(req.KeepVersions),
+	}))
+	return nil
+}
+
 func (s *RPCNode) InstallApp(req *AppParameterRequest, resp *BoolResponse) error {
 	logrus.Info("RPC: InstallApp")
 	device, _ := s.dm.GetDevice(req.DeviceID)
@@ -295,10 +381,11 @@ func (s *RPCNode) InstallApp(req *AppParameterRequest, resp *BoolResponse) error
 		return fmt.Errorf("device with id %v not found", req.DeviceID)
 	}
 
-	data, err := s.abm.GetAppParameter(req.App.Hash)
+	data, err := s.abm.Acquire(req.App.Hash)
 	if err != nil {
 		return err
 	}
+	defer s.abm.Release(req.App.Hash)
 
 	err = device.InstallApp(getAppParameter(req, data))
 
@@ -359,6 +446,7 @@ func (s *RPCNode) StartApp(req *StartAppRequest, resp *BoolResponse) error {
 		resp.ErrorCode = 1
 		return err
 	}
+	s.setSession(req.App.DeviceID, true)
 	resp.Value = true
 	return nil
 }
@@ -371,6 +459,7 @@ func (s *RPCNode) StopApp(req *AppParameterRequest, resp *BoolResponse) error {
 	}
 
 	err := device.StopApp(getAppParameter(req, nil))
+	s.setSession(req.DeviceID, false)
 
 	if err != nil {
 		resp.ErrorMessage = err.Error()
@@ -381,6 +470,29 @@ func (s *RPCNode) StopApp(req *AppParameterRequest, resp *BoolResponse) error {
 	return nil
 }
 
+func (s *RPCNode) RecoverDevice(req *RecoverDeviceRequest, resp *BoolResponse) error {
+	logrus.Info("RPC: RecoverDevice")
+	dev, _ := s.dm.GetDevice(req.App.DeviceID)
+	if dev == nil {
+		return fmt.Errorf("device with id %v not found", req.App.DeviceID)
+	}
+
+	timeout := device.DefaultRecoveryTimeout
+	if req.Timeout > 0 {
+		timeout = time.Duration(req.Timeout) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := s.dm.ExecuteRecoveryStep(ctx, dev, getAppParameter(req.App, nil), device.RecoveryStep(req.Step)); err != nil {
+		resp.ErrorMessage = err.Error()
+		resp.ErrorCode = 1
+		return nil
+	}
+	resp.Value = true
+	return nil
+}
+
 func (s *RPCNode) IsConnected(req *DeviceRequest, resp *BoolResponse) error {
 	logrus.Info("RPC: IsConnected")
 	device, _ := s.dm.GetDevice(req.DeviceID)
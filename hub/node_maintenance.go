@@ -0,0 +1,106 @@
+package hub
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"time"
+)
+
+const DefaultDrainDeadline = 1 * time.Hour
+
+// drainCheckInterval is the interval in which draining nodes are checked for running tests
+var drainCheckInterval = 10 * time.Second
+
+// Drain puts the node into maintenance, devices of a draining node can't be locked by new test runs.
+// Once all running protocols of the node finished or the deadline passed the node is reported as drained.
+func (nm *NodeManager) Drain(nodeIdentifier manager.NodeIdentifier, deadline time.Duration, reason string) error {
+	var node models.Node
+	if err := nm.db.First(&node, "identifier = ?", nodeIdentifier).Error; err != nil {
+		return err
+	}
+	if node.Maintenance != models.NodeMaintenanceNone {
+		return fmt.Errorf("node '%s' is already %s", nodeIdentifier, node.Maintenance)
+	}
+
+	if deadline <= 0 {
+		deadline = DefaultDrainDeadline
+	}
+	drainDeadline := time.Now().Add(deadline)
+	node.Maintenance = models.NodeMaintenanceDraining
+	node.MaintenanceReason = reason
+	node.DrainDeadline = &drainDeadline
+	if err := nm.db.Model(&node).Select("maintenance", "maintenance_reason", "drain_deadline").Updates(&node).Error; err != nil {
+		return err
+	}
+
+	nm.log.Infof("drain node '%s' until %s: %s", nodeIdentifier, drainDeadline.Format(time.RFC3339), reason)
+	go nm.waitForDrain(node.ID)
+	return nil
+}
+
+// EndMaintenance makes the devices of the node available for test runs again
+func (nm *NodeManager) EndMaintenance(nodeIdentifier manager.NodeIdentifier) error {
+	var node models.Node
+	if err := nm.db.First(&node, "identifier = ?", nodeIdentifier).Error; err != nil {
+		return err
+	}
+	if node.Maintenance == models.NodeMaintenanceNone {
+		return fmt.Errorf("node '%s' is not in maintenance", nodeIdentifier)
+	}
+
+	nm.log.Infof("end maintenance of node '%s'", nodeIdentifier)
+	return nm.db.Model(&node).Updates(map[string]interface{}{
+		"maintenance":        models.NodeMaintenanceNone,
+		"maintenance_reason": "",
+		"drain_deadline":     nil,
+	}).Error
+}
+
+// resumeDraining continues to observe nodes which were draining when the master was stopped
+func (nm *NodeManager) resumeDraining() {
+	var nodes []models.Node
+	if err := nm.db.Where("maintenance = ?", models.NodeMaintenanceDraining).Find(&nodes).Error; err != nil {
+		nm.log.Errorf("unable to load draining nodes: %v", err)
+		return
+	}
+	for i := range nodes {
+		go nm.waitForDrain(nodes[i].ID)
+	}
+}
+
+func (nm *NodeManager) waitForDrain(nodeID uint) {
+	for {
+		var node models.Node
+		if err := nm.db.First(&node, nodeID).Error; err != nil {
+			nm.log.Errorf("stop draining node %d: %v", nodeID, err)
+			return
+		}
+
+		// maintenance ended before the node was drained
+		if node.Maintenance != models.NodeMaintenanceDraining {
+			return
+		}
+
+		running, err := storage.CountRunningProtocols(nm.db, node.ID)
+		if err != nil {
+			nm.log.Errorf("unable to count running protocols of node '%s': %v", node.Identifier, err)
+		}
+
+		deadlineReached := node.DrainDeadline != nil && node.DrainDeadline.Before(time.Now())
+		if (err == nil && running == 0) || deadlineReached {
+			if running > 0 {
+				nm.log.Warnf("node '%s' drain deadline reached with %d running tests", node.Identifier, running)
+			}
+			if err := nm.db.Model(&node).Update("maintenance", models.NodeMaintenanceDrained).Error; err != nil {
+				nm.log.Errorf("unable to update maintenance state of node '%s': %v", node.Identifier, err)
+				return
+			}
+			nm.log.Infof("node '%s' drained", node.Identifier)
+			return
+		}
+
+		time.Sleep(drainCheckInterval)
+	}
+}
@@ -0,0 +1,110 @@
+package hub
+
+import (
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/fsuhrau/automationhub/storage/migrations"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/glebarez/sqlite"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newMaintenanceNodeManager creates a node manager on a database with a node which runs a test on its device,
+// the drain is observed in the background so the database is stored in a file shared by all connections
+func newMaintenanceNodeManager(t *testing.T) (*NodeManager, models.Node, models.TestProtocol) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "hub.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("unable to open database: %v", err)
+	}
+	if err := migrations.InitSchema(db); err != nil {
+		t.Fatalf("unable to migrate database: %v", err)
+	}
+
+	node := models.Node{Identifier: "node"}
+	db.Create(&node)
+	dev := models.Device{DeviceIdentifier: "phone", NodeID: node.ID}
+	db.Create(&dev)
+	run := models.TestRun{SessionID: "session"}
+	db.Create(&run)
+	protocol := models.TestProtocol{TestRunID: run.ID, DeviceID: &dev.ID, StartedAt: time.Now()}
+	db.Create(&protocol)
+
+	drainCheckInterval = 10 * time.Millisecond
+	t.Cleanup(func() { drainCheckInterval = 10 * time.Second })
+
+	return NewNodeManager(logrus.New(), db, config.NodeHeartbeat{}), node, protocol
+}
+
+func awaitMaintenance(t *testing.T, nm *NodeManager, nodeID uint, state models.NodeMaintenanceState) {
+	deadline := time.Now().Add(5 * time.Second)
+	var node models.Node
+	for time.Now().Before(deadline) {
+		nm.db.First(&node, nodeID)
+		if node.Maintenance == state {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected node to be %q got %q", state, node.Maintenance)
+}
+
+func TestDrainWaitsForRunningTests(t *testing.T) {
+	nm, node, protocol := newMaintenanceNodeManager(t)
+
+	if err := nm.Drain(manager.NodeIdentifier(node.Identifier), time.Hour, "update"); err != nil {
+		t.Fatalf("unable to drain node: %v", err)
+	}
+	if err := nm.Drain(manager.NodeIdentifier(node.Identifier), time.Hour, "update"); err == nil {
+		t.Errorf("expected draining node not to be drained again")
+	}
+	if maintenance, _ := storage.IsNodeInMaintenance(nm.db, node.ID); !maintenance {
+		t.Errorf("expected draining node to be in maintenance")
+	}
+
+	time.Sleep(5 * drainCheckInterval)
+	awaitMaintenance(t, nm, node.ID, models.NodeMaintenanceDraining)
+
+	ended := time.Now()
+	nm.db.Model(&protocol).Update("ended_at", &ended)
+	awaitMaintenance(t, nm, node.ID, models.NodeMaintenanceDrained)
+
+	if err := nm.EndMaintenance(manager.NodeIdentifier(node.Identifier)); err != nil {
+		t.Fatalf("unable to end maintenance: %v", err)
+	}
+	if maintenance, _ := storage.IsNodeInMaintenance(nm.db, node.ID); maintenance {
+		t.Errorf("expected node to accept test runs again")
+	}
+}
+
+func TestDrainDeadline(t *testing.T) {
+	nm, node, _ := newMaintenanceNodeManager(t)
+
+	if err := nm.Drain(manager.NodeIdentifier(node.Identifier), 50*time.Millisecond, "update"); err != nil {
+		t.Fatalf("unable to drain node: %v", err)
+	}
+	awaitMaintenance(t, nm, node.ID, models.NodeMaintenanceDrained)
+
+	if running, err := storage.CountRunningProtocols(nm.db, node.ID); err != nil || running != 1 {
+		t.Errorf("expected the test to be still running got %d %v", running, err)
+	}
+}
+
+func TestCountRunningProtocols(t *testing.T) {
+	nm, node, protocol := newMaintenanceNodeManager(t)
+
+	if running, _ := storage.CountRunningProtocols(nm.db, node.ID); running != 1 {
+		t.Errorf("expected one running protocol got %d", running)
+	}
+
+	// protocols of runs which were finished e.g. by a shutdown don't block the drain
+	finished := time.Now()
+	nm.db.Model(&models.TestRun{}).Where("id = ?", protocol.TestRunID).Update("finished_at", &finished)
+	if running, _ := storage.CountRunningProtocols(nm.db, node.ID); running != 0 {
+		t.Errorf("expected no running protocol of a finished run got %d", running)
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"github.com/fsuhrau/automationhub/app"
+	"github.com/fsuhrau/automationhub/config"
 	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/events"
 	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/hub/node"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"gorm.io/gorm"
+	"net/rpc"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -17,28 +22,31 @@ var ErrNodeNotFound = fmt.Errorf("node not found")
 var ErrNodeNotConnected = fmt.Errorf("node not connected")
 
 const (
-	NodeConnectionTimeout   = 5 * time.Minute
-	NodeHealthCheckInterval = 5 * time.Second
+	NodeConnectionTimeout = 5 * time.Minute
 )
 
 type NodeManager struct {
-	db    *gorm.DB
-	nodes map[manager.NodeIdentifier]Node
-	stop  bool
-	log   *logrus.Entry
+	db        *gorm.DB
+	nodes     map[manager.NodeIdentifier]Node
+	mutex     sync.RWMutex
+	stop      bool
+	log       *logrus.Entry
+	heartbeat config.NodeHeartbeat
 }
 
-func NewNodeManager(logger *logrus.Logger, db *gorm.DB) *NodeManager {
+func NewNodeManager(logger *logrus.Logger, db *gorm.DB, heartbeat config.NodeHeartbeat) *NodeManager {
 	return &NodeManager{log: logger.WithFields(logrus.Fields{
 		"prefix": "dm",
 	}),
-		db:    db,
-		nodes: make(map[manager.NodeIdentifier]Node),
+		db:        db,
+		nodes:     make(map[manager.NodeIdentifier]Node),
+		heartbeat: heartbeat.WithDefaults(),
 	}
 }
 
 func (nm *NodeManager) Run(ctx context.Context) {
 	nm.log.Debugf("Start NodeManager")
+	nm.resumeDraining()
 	go func() {
 		for {
 			select {
@@ -48,21 +56,103 @@ func (nm *NodeManager) Run(ctx context.Context) {
 			default:
 			}
 
-			for k, m := range nm.nodes {
-				if m.Handler != nil {
-					if err := m.Handler.Ping(); err != nil {
-						m.Status = NodeStatusDisconnected
-						m.Handler = nil
-						nm.nodes[k] = m
-					}
-				}
+			for k, m := range nm.connectedNodes() {
+				nm.checkHeartbeat(k, m)
 			}
-			time.Sleep(NodeHealthCheckInterval)
+			time.Sleep(nm.heartbeat.Interval)
 		}
 	}()
 }
 
+// connectedNodes returns a copy of the nodes with a connection, the heartbeats are sent without holding the lock
+func (nm *NodeManager) connectedNodes() map[manager.NodeIdentifier]Node {
+	nm.mutex.RLock()
+	defer nm.mutex.RUnlock()
+
+	nodes := make(map[manager.NodeIdentifier]Node)
+	for k, n := range nm.nodes {
+		if n.Handler != nil {
+			nodes[k] = n
+		}
+	}
+	return nodes
+}
+
+// updateNode applies the update only if the node is still connected with the handler, a node which reconnected
+// in the meantime keeps its new connection
+func (nm *NodeManager) updateNode(nodeIdentifier manager.NodeIdentifier, handler manager.RPCClient, update func(n *Node)) (Node, bool) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	n, ok := nm.nodes[nodeIdentifier]
+	if !ok || n.Handler != handler {
+		return n, false
+	}
+	update(&n)
+	nm.nodes[nodeIdentifier] = n
+	return n, true
+}
+
+// checkHeartbeat reports nodes as lost which missed too many heartbeats, nodes which don't support
+// heartbeats are never reported as lost
+func (nm *NodeManager) checkHeartbeat(nodeIdentifier manager.NodeIdentifier, n Node) {
+	if !n.Version.HasFeature(node.FeatureHeartbeat) {
+		return
+	}
+
+	handler := n.Handler
+	err := handler.Heartbeat(nm.heartbeat.Timeout)
+	if err == nil {
+		var metrics *manager.NodeMetrics
+		if n.Version.HasFeature(node.FeatureNodeMetrics) {
+			if metrics, err = handler.GetMetrics(nm.heartbeat.Timeout); err != nil {
+				nm.log.Warnf("unable to fetch metrics of node '%s': %v", nodeIdentifier, err)
+			}
+		}
+		nm.updateNode(nodeIdentifier, handler, func(n *Node) {
+			n.LastHeartbeat = time.Now()
+			n.MissedHeartbeat = 0
+			if metrics != nil {
+				n.Metrics = metrics
+			}
+		})
+		return
+	}
+
+	lost := false
+	n, ok := nm.updateNode(nodeIdentifier, handler, func(n *Node) {
+		n.MissedHeartbeat++
+		// a closed connection won't come back, no need to wait for further heartbeats
+		if n.MissedHeartbeat < nm.heartbeat.MaxMissed && err != rpc.ErrShutdown {
+			return
+		}
+		lost = true
+		n.Status = NodeStatusDisconnected
+		n.Handler = nil
+	})
+	if !ok {
+		return
+	}
+
+	nm.log.Warnf("node '%s' missed heartbeat %d/%d: %v", nodeIdentifier, n.MissedHeartbeat, nm.heartbeat.MaxMissed, err)
+	if !lost {
+		return
+	}
+
+	nm.log.Errorf("node '%s' lost, last heartbeat at %s", nodeIdentifier, n.LastHeartbeat.Format(time.RFC3339))
+	_ = handler.Close()
+
+	events.NodeLost.Trigger(events.NodeLostPayload{
+		NodeIdentifier: nodeIdentifier,
+		Hostname:       n.Hostname,
+		Reason:         fmt.Sprintf("no heartbeat since %s: %v", n.LastHeartbeat.Format(time.RFC3339), err),
+	})
+}
+
 func (nm *NodeManager) GetNodes() []manager.NodeIdentifier {
+	nm.mutex.RLock()
+	defer nm.mutex.RUnlock()
+
 	var nodes []manager.NodeIdentifier
 	for k, _ := range nm.nodes {
 		nodes = append(nodes, k)
@@ -71,8 +161,8 @@ func (nm *NodeManager) GetNodes() []manager.NodeIdentifier {
 }
 
 func (nm *NodeManager) GetManagers(nodeIdentifier manager.NodeIdentifier) (map[string][]device.Device, error) {
-	if n, ok := nm.nodes[nodeIdentifier]; ok && n.Handler != nil {
-		managers, err := n.Handler.GetDevices()
+	if handler, err := nm.getHandler(nodeIdentifier); err == nil {
+		managers, err := handler.GetDevices()
 		if err != nil {
 			nm.log.Errorf("fail to fetch managers from node '%s': %v", nodeIdentifier, err)
 			return nil, err
@@ -90,13 +180,19 @@ func (nm *NodeManager) IsNodeKnown(nodeIdentifier manager.NodeIdentifier) bool {
 	return true
 }
 
-func (nm *NodeManager) RegisterNode(nodeIdentifier manager.NodeIdentifier, client manager.RPCClient, hostname, address, operationSystem, environment string, port int32, managers []string) {
+func (nm *NodeManager) RegisterNode(nodeIdentifier manager.NodeIdentifier, client manager.RPCClient, hostname, address, operationSystem, environment string, port int32, managers []string, version manager.NodeVersion) {
 	/*
 		host, _, err := net.SplitHostPort(address)
 		if err != nil {
 			fmt.Println(err)
 		}
 	*/
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	if n, ok := nm.nodes[nodeIdentifier]; ok && n.Handler != nil && n.Handler != client {
+		_ = n.Handler.Close()
+	}
 	nm.nodes[nodeIdentifier] = Node{
 		Identifier:      string(nodeIdentifier),
 		Hostname:        hostname,
@@ -107,10 +203,15 @@ func (nm *NodeManager) RegisterNode(nodeIdentifier manager.NodeIdentifier, clien
 		Managers:        managers,
 		Status:          NodeStatusConnected,
 		Handler:         client,
+		LastHeartbeat:   time.Now(),
+		Version:         version,
 	}
 }
 
 func (nm *NodeManager) getNode(nodeIdentifier manager.NodeIdentifier) (error, *Node) {
+	nm.mutex.RLock()
+	defer nm.mutex.RUnlock()
+
 	if v, ok := nm.nodes[nodeIdentifier]; ok {
 		return nil, &v
 	}
@@ -130,6 +231,45 @@ func (nm *NodeManager) getHandler(nodeIdentifier manager.NodeIdentifier) (manage
 	return n.Handler, nil
 }
 
+func (nm *NodeManager) GetVersion(nodeIdentifier manager.NodeIdentifier) (manager.NodeVersion, error) {
+	err, n := nm.getNode(nodeIdentifier)
+	if err != nil {
+		return manager.NodeVersion{}, err
+	}
+
+	return n.Version, nil
+}
+
+// GetMetrics returns the last resource usage reported by the node
+func (nm *NodeManager) GetMetrics(nodeIdentifier manager.NodeIdentifier) (*manager.NodeMetrics, error) {
+	err, n := nm.getNode(nodeIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Handler == nil {
+		return nil, ErrNodeNotConnected
+	}
+	return n.Metrics, nil
+}
+
+// getFeatureHandler returns the handler of the node if the feature was negotiated with the node
+func (nm *NodeManager) getFeatureHandler(nodeIdentifier manager.NodeIdentifier, feature string) (manager.RPCClient, error) {
+	err, n := nm.getNode(nodeIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Handler == nil {
+		return nil, ErrNodeNotConnected
+	}
+
+	if !n.Version.HasFeature(feature) {
+		return nil, fmt.Errorf("node '%s' (version %s) does not support %s", nodeIdentifier, n.Version.Version, feature)
+	}
+	return n.Handler, nil
+}
+
 func (nm *NodeManager) GetStatus(nodeIdentifier manager.NodeIdentifier) (int, error) {
 	err, n := nm.getNode(nodeIdentifier)
 	if err != nil {
@@ -148,6 +288,33 @@ func (nm *NodeManager) StartDevice(nodeIdentifier manager.NodeIdentifier, device
 
 	return handler.StartDevice(deviceId)
 }
+func (nm *NodeManager) GetBundles(nodeIdentifier manager.NodeIdentifier) ([]manager.Bundle, error) {
+	handler, err := nm.getFeatureHandler(nodeIdentifier, node.FeatureBundleManagement)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.GetBundles()
+}
+
+func (nm *NodeManager) PinBundle(nodeIdentifier manager.NodeIdentifier, hash string, pinned bool) error {
+	handler, err := nm.getFeatureHandler(nodeIdentifier, node.FeatureBundleManagement)
+	if err != nil {
+		return err
+	}
+
+	return handler.PinBundle(hash, pinned)
+}
+
+func (nm *NodeManager) EvictBundles(nodeIdentifier manager.NodeIdentifier, hashes []string, policy manager.BundlePolicy) ([]manager.Bundle, error) {
+	handler, err := nm.getFeatureHandler(nodeIdentifier, node.FeatureBundleManagement)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.EvictBundles(hashes, policy)
+}
+
 func (nm *NodeManager) StopDevice(nodeIdentifier manager.NodeIdentifier, deviceId string) error {
 	handler, err := nm.getHandler(nodeIdentifier)
 	if err != nil {
@@ -231,6 +398,38 @@ func (nm *NodeManager) StopApp(nodeIdentifier manager.NodeIdentifier, deviceId s
 	return handler.StopApp(deviceId, parameter)
 }
 
+// RecoverDevice executes the recovery step on the node, nodes without device recovery only support
+// stopping the app and restarting the device
+func (nm *NodeManager) RecoverDevice(ctx context.Context, nodeIdentifier manager.NodeIdentifier, deviceId string, step device.RecoveryStep, parameter *app.Parameter) error {
+	err, n := nm.getNode(nodeIdentifier)
+	if err != nil {
+		return err
+	}
+
+	if n.Handler == nil {
+		return ErrNodeNotConnected
+	}
+
+	if n.Version.HasFeature(node.FeatureDeviceRecovery) {
+		return n.Handler.RecoverDevice(ctx, deviceId, step, parameter)
+	}
+
+	switch step {
+	case device.RecoveryForceStop:
+		return withContext(ctx, func() error {
+			return n.Handler.StopApp(deviceId, parameter)
+		})
+	case device.RecoveryReconnect, device.RecoveryReboot:
+		return withContext(ctx, func() error {
+			if err := n.Handler.StopDevice(deviceId); err != nil {
+				return err
+			}
+			return n.Handler.StartDevice(deviceId)
+		})
+	}
+	return fmt.Errorf("node '%s' (version %s) does not support %s", nodeIdentifier, n.Version.Version, step)
+}
+
 func (nm *NodeManager) IsConnected(nodeIdentifier manager.NodeIdentifier, deviceId string) bool {
 	handler, err := nm.getHandler(nodeIdentifier)
 	if err != nil {
@@ -0,0 +1,81 @@
+package hub
+
+import (
+	"fmt"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/hub/node"
+	"github.com/sirupsen/logrus"
+	"testing"
+	"time"
+)
+
+// unreachableNode answers no heartbeat
+type unreachableNode struct {
+	manager.RPCClient
+	heartbeats int
+	closed     bool
+	// onHeartbeat is called while the heartbeat is pending
+	onHeartbeat func()
+}
+
+func (n *unreachableNode) Heartbeat(timeout time.Duration) error {
+	n.heartbeats++
+	if n.onHeartbeat != nil {
+		n.onHeartbeat()
+	}
+	return fmt.Errorf("timeout")
+}
+
+func (n *unreachableNode) Close() error {
+	n.closed = true
+	return nil
+}
+
+func TestCheckHeartbeatOnlyForNegotiatedNodes(t *testing.T) {
+	nm := NewNodeManager(logrus.New(), nil, config.NodeHeartbeat{MaxMissed: 1})
+
+	legacy := &unreachableNode{}
+	nm.RegisterNode("legacy", legacy, "legacy", "", "", "", 0, nil, manager.NodeVersion{ProtocolVersion: 0})
+	current := &unreachableNode{}
+	nm.RegisterNode("current", current, "current", "", "", "", 0, nil, manager.NodeVersion{ProtocolVersion: node.ProtocolVersion, Features: []string{node.FeatureHeartbeat}})
+
+	for identifier, n := range nm.connectedNodes() {
+		nm.checkHeartbeat(identifier, n)
+	}
+
+	if legacy.heartbeats != 0 || nm.nodes["legacy"].Handler == nil {
+		t.Errorf("expected node without heartbeat feature to stay connected")
+	}
+	if current.heartbeats != 1 || nm.nodes["current"].Handler != nil {
+		t.Errorf("expected node with heartbeat feature to be lost")
+	}
+}
+
+func TestNegotiateFeaturesOfLegacyNodes(t *testing.T) {
+	if features := node.NegotiateFeatures(nil); len(features) != 0 {
+		t.Errorf("expected no features for legacy nodes got %v", features)
+	}
+}
+
+func TestCheckHeartbeatKeepsReconnectedNode(t *testing.T) {
+	nm := NewNodeManager(logrus.New(), nil, config.NodeHeartbeat{MaxMissed: 1})
+	version := manager.NodeVersion{ProtocolVersion: node.ProtocolVersion, Features: []string{node.FeatureHeartbeat}}
+
+	reconnected := &unreachableNode{}
+	lost := &unreachableNode{onHeartbeat: func() {
+		nm.RegisterNode("node", reconnected, "node", "", "", "", 0, nil, version)
+	}}
+	nm.RegisterNode("node", lost, "node", "", "", "", 0, nil, version)
+
+	for identifier, n := range nm.connectedNodes() {
+		nm.checkHeartbeat(identifier, n)
+	}
+
+	if !lost.closed {
+		t.Errorf("expected replaced connection to be closed")
+	}
+	if reconnected.closed || nm.connectedNodes()["node"].Handler != reconnected {
+		t.Errorf("expected reconnected node to stay connected")
+	}
+}
@@ -0,0 +1,56 @@
+package hub
+
+import (
+	"context"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	QuarantineCheckInterval = 5 * time.Minute
+)
+
+// QuarantineManager removes tests from the quarantine once the quarantine expired
+type QuarantineManager struct {
+	db  *gorm.DB
+	log *logrus.Entry
+}
+
+func NewQuarantineManager(logger *logrus.Logger, db *gorm.DB) *QuarantineManager {
+	return &QuarantineManager{
+		log: logger.WithFields(logrus.Fields{
+			"prefix": "quarantine",
+		}),
+		db: db,
+	}
+}
+
+func (qm *QuarantineManager) releaseExpiredQuarantines() {
+	quarantines, err := storage.ReleaseExpiredQuarantines(qm.db)
+	if err != nil {
+		qm.log.Errorf("release expired quarantines failed: %v", err)
+		return
+	}
+	for i := range quarantines {
+		qm.log.Infof("quarantine of test %d %s by %s expired", quarantines[i].TestID, quarantines[i].TestName, quarantines[i].Owner)
+	}
+}
+
+func (qm *QuarantineManager) Run(ctx context.Context) {
+	qm.log.Debugf("Start QuarantineManager")
+	go func() {
+		ticker := time.NewTicker(QuarantineCheckInterval)
+		defer ticker.Stop()
+		for {
+			qm.releaseExpiredQuarantines()
+			select {
+			case <-ctx.Done():
+				qm.log.Infof("Stop QuarantineManager")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
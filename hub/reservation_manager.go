@@ -0,0 +1,61 @@
+package hub
+
+import (
+	"context"
+	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	ReservationCheckInterval = 30 * time.Second
+)
+
+type ReservationManager struct {
+	db  *gorm.DB
+	log *logrus.Entry
+}
+
+func NewReservationManager(logger *logrus.Logger, db *gorm.DB) *ReservationManager {
+	return &ReservationManager{
+		log: logger.WithFields(logrus.Fields{
+			"prefix": "reservation",
+		}),
+		db: db,
+	}
+}
+
+func (rm *ReservationManager) releaseExpiredReservations() {
+	reservations, err := storage.ReleaseExpiredReservations(rm.db)
+	if err != nil {
+		rm.log.Errorf("release expired reservations failed: %v", err)
+		return
+	}
+	for i := range reservations {
+		rm.log.Infof("reservation of device %d by %s expired", reservations[i].DeviceID, reservations[i].Owner)
+		events.DeviceReservationChanged.Trigger(events.DeviceReservationChangedPayload{
+			DeviceID:    reservations[i].DeviceID,
+			Reserved:    false,
+			Reservation: reservations[i],
+		})
+	}
+}
+
+func (rm *ReservationManager) Run(ctx context.Context) {
+	rm.log.Debugf("Start ReservationManager")
+	go func() {
+		ticker := time.NewTicker(ReservationCheckInterval)
+		defer ticker.Stop()
+		for {
+			rm.releaseExpiredReservations()
+			select {
+			case <-ctx.Done():
+				rm.log.Infof("Stop ReservationManager")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
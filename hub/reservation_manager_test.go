@@ -0,0 +1,62 @@
+package hub
+
+import (
+	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/storage"
+	"github.com/fsuhrau/automationhub/storage/migrations"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/glebarez/sqlite"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type reservationListener chan events.DeviceReservationChangedPayload
+
+func (l reservationListener) Handle(payload events.DeviceReservationChangedPayload) {
+	l <- payload
+}
+
+func TestReservationManagerReleasesExpiredReservations(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("unable to open database: %v", err)
+	}
+	if err := migrations.InitSchema(db); err != nil {
+		t.Fatalf("unable to migrate database: %v", err)
+	}
+
+	expired := models.DeviceReservation{DeviceID: 1, Owner: "alice", ExpiresAt: time.Now().Add(-time.Minute)}
+	active := models.DeviceReservation{DeviceID: 2, Owner: "bob", ExpiresAt: time.Now().Add(time.Hour)}
+	db.Create(&expired)
+	db.Create(&active)
+
+	listener := make(reservationListener, 2)
+	events.DeviceReservationChanged.Register(listener)
+
+	NewReservationManager(logrus.New(), db).releaseExpiredReservations()
+
+	select {
+	case payload := <-listener:
+		if payload.DeviceID != 1 || payload.Reserved {
+			t.Errorf("expected release of device 1 got %+v", payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected reservation changed event")
+	}
+
+	reservations, err := storage.GetActiveReservations(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reservations) != 1 || reservations[2] == nil {
+		t.Errorf("expected only the reservation of device 2 to be active got %v", reservations)
+	}
+
+	var released models.DeviceReservation
+	db.First(&released, expired.ID)
+	if released.ReleasedAt == nil {
+		t.Errorf("expected expired reservation to be released")
+	}
+}
@@ -2,8 +2,11 @@ package hub
 
 import (
 	"github.com/fsuhrau/automationhub/device/androiddevice"
+	"github.com/fsuhrau/automationhub/device/docker"
+	"github.com/fsuhrau/automationhub/device/fake"
 	"github.com/fsuhrau/automationhub/device/iosdevice"
 	"github.com/fsuhrau/automationhub/device/iossim"
+	"github.com/fsuhrau/automationhub/device/linux"
 	"github.com/fsuhrau/automationhub/device/macos"
 	"github.com/fsuhrau/automationhub/device/unityeditor"
 	"github.com/fsuhrau/automationhub/device/web"
@@ -27,6 +30,14 @@ func (s *Service) RegisterDeviceHandler(masterUrl string) {
 		s.logger.Info("adding manager macos")
 		s.deviceManager.AddHandler(macos.NewHandler(s.sd))
 	}
+	if d, ok := s.cfg.DeviceManager[linux.Manager]; ok && d.Enabled {
+		s.logger.Info("adding manager linux")
+		s.deviceManager.AddHandler(linux.NewHandler(d, s.sd))
+	}
+	if d, ok := s.cfg.DeviceManager[docker.Manager]; ok && d.Enabled {
+		s.logger.Info("adding manager docker")
+		s.deviceManager.AddHandler(docker.NewHandler(d, s.sd))
+	}
 	if d, ok := s.cfg.DeviceManager[unityeditor.Manager]; ok && d.Enabled {
 		s.logger.Info("adding manager unity_editor")
 		s.deviceManager.AddHandler(unityeditor.NewHandler(d, s.sd))
@@ -35,4 +46,8 @@ func (s *Service) RegisterDeviceHandler(masterUrl string) {
 		s.logger.Info("adding manager web")
 		s.deviceManager.AddHandler(web.NewHandler(d, s.sd))
 	}
+	if d, ok := s.cfg.DeviceManager[fake.Manager]; ok && d.Enabled {
+		s.logger.Info("adding manager fake")
+		s.deviceManager.AddHandler(fake.NewHandler(d, s.sd))
+	}
 }
@@ -3,6 +3,7 @@ package hub
 import (
 	"context"
 	"fmt"
+	"github.com/fsuhrau/automationhub/authentication/mtls"
 	"github.com/fsuhrau/automationhub/device/node"
 	"net/http"
 
@@ -10,7 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (s *Service) RunMaster(nodeManager *NodeManager, sessionManager *SessionManager) error {
+func (s *Service) RunMaster(nodeManager *NodeManager, sessionManager *SessionManager, ca *mtls.CA) error {
 
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -32,6 +33,9 @@ func (s *Service) RunMaster(nodeManager *NodeManager, sessionManager *SessionMan
 		nodeManager.Run(ctx)
 	}
 
+	NewReservationManager(s.logger, s.db).Run(ctx)
+	NewQuarantineManager(s.logger, s.db).Run(ctx)
+
 	s.publicRouter.GET("/ping", func(c *gin.Context) {
 		c.String(http.StatusOK, "pong")
 	})
@@ -41,7 +45,12 @@ func (s *Service) RunMaster(nodeManager *NodeManager, sessionManager *SessionMan
 	}
 
 	runOn := fmt.Sprintf(":%d", s.cfg.Port)
-	err := s.publicRouter.Run(runOn)
+	var err error
+	if ca != nil {
+		err = s.runTLS(runOn, ca)
+	} else {
+		err = s.publicRouter.Run(runOn)
+	}
 	logrus.Infof("Stopping Server")
 	if err != nil {
 		return err
@@ -49,3 +58,24 @@ func (s *Service) RunMaster(nodeManager *NodeManager, sessionManager *SessionMan
 
 	return nil
 }
+
+// runTLS serves the router with a certificate of the built-in ca, nodes authenticate with their client certificates
+func (s *Service) runTLS(addr string, ca *mtls.CA) error {
+	hosts := append([]string{"localhost", "127.0.0.1"}, s.cfg.TLS.Hosts...)
+	if len(s.cfg.HostIP) > 0 {
+		hosts = append(hosts, s.cfg.HostIP)
+	}
+
+	tlsConfig, err := mtls.ServerConfig(ca, hosts)
+	if err != nil {
+		return err
+	}
+
+	s.server = &http.Server{
+		Addr:      addr,
+		Handler:   s.publicRouter,
+		TLSConfig: tlsConfig,
+	}
+	s.logger.Infof("Listening and serving HTTPS on %s", addr)
+	return s.server.ListenAndServeTLS("", "")
+}
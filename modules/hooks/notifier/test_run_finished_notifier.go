@@ -20,7 +20,11 @@ func RegisterEventTestRunFinishedListener(hooks []hooks.Hook) {
 }
 
 func buildMessage(payload events.TestRunFinishedPayload) string {
-	return fmt.Sprintf("Results:\n- Successful: %d\n- Unstable: %d\n- Failed: %d ", payload.Succeeded, payload.Unstable, payload.Failed)
+	message := fmt.Sprintf("Results:\n- Successful: %d\n- Unstable: %d\n- Failed: %d ", payload.Succeeded, payload.Unstable, payload.Failed)
+	if payload.Quarantined > 0 {
+		message += fmt.Sprintf("\n- Failed in quarantine: %d ", payload.Quarantined)
+	}
+	return message
 }
 
 func buildTitle(payload events.TestRunFinishedPayload) string {
@@ -75,6 +75,186 @@ func GetDB(database config.Database) (*gorm.DB, error) {
 				return nil
 			},
 		},
+		{
+			ID: "AddDeviceSelector",
+			Migrate: func(g *gorm.DB) error {
+
+				type Device struct {
+					Model
+					Labels string `json:"labels"`
+				}
+
+				type TestConfig struct {
+					Model
+					DeviceSelector string `json:"deviceSelector"`
+				}
+
+				if err := g.AutoMigrate(&Device{}); err != nil {
+					return err
+				}
+				if err := g.AutoMigrate(&TestConfig{}); err != nil {
+					return err
+				}
+				return nil
+			},
+		},
+		{
+			ID: "AddDeviceReservation",
+			Migrate: func(g *gorm.DB) error {
+
+				type DeviceReservation struct {
+					Model
+					DeviceID   uint       `json:"deviceId"`
+					Owner      string     `json:"owner"`
+					Reason     string     `json:"reason"`
+					ExpiresAt  time.Time  `json:"expiresAt"`
+					ReleasedAt *time.Time `json:"releasedAt"`
+				}
+
+				return g.AutoMigrate(&DeviceReservation{})
+			},
+		},
+		{
+			ID: "AddStartupFailed",
+			Migrate: func(g *gorm.DB) error {
+
+				type TestRunDeviceStatus struct {
+					Model
+					StartupFailed bool `json:"startupFailed"`
+				}
+
+				return g.AutoMigrate(&TestRunDeviceStatus{})
+			},
+		},
+		{
+			ID: "AddNodeMaintenance",
+			Migrate: func(g *gorm.DB) error {
+
+				type Node struct {
+					Model
+					Maintenance       string     `json:"maintenance"`
+					MaintenanceReason string     `json:"maintenanceReason"`
+					DrainDeadline     *time.Time `json:"drainDeadline"`
+				}
+
+				return g.AutoMigrate(&Node{})
+			},
+		},
+		{
+			ID: "AddExecutableParameter",
+			Migrate: func(g *gorm.DB) error {
+
+				type Executable struct {
+					Arguments   string
+					Environment string
+				}
+
+				type AppBinary struct {
+					Model
+					Executable Executable `json:"executable" db:"executable" gorm:"embedded"`
+				}
+
+				return g.AutoMigrate(&AppBinary{})
+			},
+		},
+		{
+			ID: "AddTestTimeouts",
+			Migrate: func(g *gorm.DB) error {
+
+				type TestConfig struct {
+					Model
+					TestTimeout uint `json:"testTimeout"`
+					RunTimeout  uint `json:"runTimeout"`
+				}
+
+				type UnityTestFunction struct {
+					Model
+					Timeout int64 `json:"timeout"`
+				}
+
+				if err := g.AutoMigrate(&TestConfig{}); err != nil {
+					return err
+				}
+				return g.AutoMigrate(&UnityTestFunction{})
+			},
+		},
+		{
+			ID: "AddTestQuarantine",
+			Migrate: func(g *gorm.DB) error {
+
+				type TestQuarantine struct {
+					Model
+					TestID              uint       `json:"testId"`
+					UnityTestFunctionID *uint      `json:"unityTestFunctionId,omitempty"`
+					TestName            string     `json:"testName"`
+					Owner               string     `json:"owner"`
+					Reason              string     `json:"reason"`
+					ExpiresAt           time.Time  `json:"expiresAt"`
+					ReleasedAt          *time.Time `json:"releasedAt"`
+				}
+
+				type TestProtocol struct {
+					Model
+					Quarantined bool `json:"quarantined,omitempty"`
+				}
+
+				if err := g.AutoMigrate(&TestQuarantine{}); err != nil {
+					return err
+				}
+				return g.AutoMigrate(&TestProtocol{})
+			},
+		},
+		{
+			ID: "AddTestRunRerun",
+			Migrate: func(g *gorm.DB) error {
+
+				type TestRun struct {
+					Model
+					RerunOfID *uint `json:"rerunOfId,omitempty"`
+				}
+
+				return g.AutoMigrate(&TestRun{})
+			},
+		},
+		{
+			ID: "AddTestMatrix",
+			Migrate: func(g *gorm.DB) error {
+
+				type TestMatrix struct {
+					Model
+					TestID      uint       `json:"testId"`
+					AppBinaryID uint       `json:"appBinaryId"`
+					StartURL    string     `json:"startUrl"`
+					Parameter   string     `json:"parameter"`
+					Dimensions  string     `json:"dimensions"`
+					FinishedAt  *time.Time `json:"finishedAt"`
+				}
+
+				type TestRun struct {
+					Model
+					MatrixID          *uint  `json:"matrixId,omitempty"`
+					MatrixCombination string `json:"matrixCombination,omitempty"`
+				}
+
+				return g.AutoMigrate(&TestMatrix{}, &TestRun{})
+			},
+		},
+		{
+			ID: "AddTestRunFinishedAt",
+			Migrate: func(g *gorm.DB) error {
+
+				type TestRun struct {
+					Model
+					FinishedAt *time.Time `json:"finishedAt,omitempty"`
+				}
+
+				if err := g.AutoMigrate(&TestRun{}); err != nil {
+					return err
+				}
+				// no run is executed during the migration, existing runs are finished
+				return g.Model(&TestRun{}).Where("finished_at is null").Update("finished_at", gorm.Expr("updated_at")).Error
+			},
+		},
 	})
 	m.InitSchema(migrations.InitSchema)
 
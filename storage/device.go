@@ -145,7 +145,7 @@ func (d *deviceStore) Update(manager string, dev device.Device) error {
 		log := models.DeviceLog{
 			DeviceID: deviceData.ID,
 			Status:   dev.DeviceState(),
-			Payload:  "",
+			Payload:  dev.QuarantineReason(),
 		}
 		d.db.Create(&log)
 		events.DeviceStatusChanged.Trigger(events.DeviceStatusChangedPayload{
@@ -48,6 +48,9 @@ func InitSchema(tx *gorm.DB) error {
 	if err := tx.AutoMigrate(&models.DeviceLog{}); err != nil {
 		return err
 	}
+	if err := tx.AutoMigrate(&models.DeviceReservation{}); err != nil {
+		return err
+	}
 	if err := tx.AutoMigrate(&models.Test{}); err != nil {
 		return err
 	}
@@ -87,5 +90,11 @@ func InitSchema(tx *gorm.DB) error {
 	if err := tx.AutoMigrate(&models.TestRunDeviceStatus{}); err != nil {
 		return err
 	}
+	if err := tx.AutoMigrate(&models.TestQuarantine{}); err != nil {
+		return err
+	}
+	if err := tx.AutoMigrate(&models.TestMatrix{}); err != nil {
+		return err
+	}
 	return nil
 }
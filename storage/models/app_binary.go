@@ -3,13 +3,40 @@ package models
 import (
 	"github.com/fsuhrau/automationhub/events"
 	"gorm.io/gorm"
+	"strings"
 )
 
 type Android struct {
 	LaunchActivity string
 }
 type Executable struct {
-	Executable string
+	Executable  string
+	Arguments   string
+	Environment string
+}
+
+// ArgumentList returns the launch arguments, one argument per line
+func (e Executable) ArgumentList() []string {
+	var args []string
+	for _, line := range strings.Split(e.Arguments, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			args = append(args, line)
+		}
+	}
+	return args
+}
+
+// EnvironmentMap returns the launch environment, one KEY=VALUE pair per line
+func (e Executable) EnvironmentMap() map[string]string {
+	env := make(map[string]string)
+	for _, line := range strings.Split(e.Environment, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || len(key) == 0 {
+			continue
+		}
+		env[key] = value
+	}
+	return env
 }
 
 type AppBinary struct {
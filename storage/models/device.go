@@ -2,6 +2,7 @@ package models
 
 import (
 	"github.com/fsuhrau/automationhub/device"
+	"strings"
 )
 
 type DeviceType int
@@ -62,6 +63,8 @@ type Device struct {
 	Connection          interface{}          `json:"connection" gorm:"-"`
 	PlatformType        PlatformType         `json:"platformType"`
 	CustomParameter     []CustomParameter    `json:"customParameter"`
+	Labels              string               `json:"labels"`
+	Reservation         *DeviceReservation   `json:"reservation" gorm:"-"`
 }
 
 type ConnectionParameter struct {
@@ -97,3 +100,23 @@ func (d *Device) GetAttribute(key string) string {
 	}
 	return ""
 }
+
+func (d *Device) GetLabels() []string {
+	var labels []string
+	for _, l := range strings.Split(d.Labels, ",") {
+		l = strings.TrimSpace(l)
+		if len(l) > 0 {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+func (d *Device) HasLabel(label string) bool {
+	for _, l := range d.GetLabels() {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type DeviceReservation struct {
+	Model
+	DeviceID   uint       `json:"deviceId"`
+	Device     *Device    `json:"device"`
+	Owner      string     `json:"owner"`
+	Reason     string     `json:"reason"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	ReleasedAt *time.Time `json:"releasedAt"`
+}
+
+func (r *DeviceReservation) IsActive() bool {
+	return r.ReleasedAt == nil && time.Now().Before(r.ExpiresAt)
+}
@@ -0,0 +1,261 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type SelectorOperator string
+
+const (
+	SelectorEqual          SelectorOperator = "="
+	SelectorNotEqual       SelectorOperator = "!="
+	SelectorGreater        SelectorOperator = ">"
+	SelectorGreaterOrEqual SelectorOperator = ">="
+	SelectorLess           SelectorOperator = "<"
+	SelectorLessOrEqual    SelectorOperator = "<="
+)
+
+// operators ordered so that two character operators are matched first
+var selectorOperators = []SelectorOperator{
+	SelectorNotEqual,
+	SelectorGreaterOrEqual,
+	SelectorLessOrEqual,
+	SelectorEqual,
+	SelectorGreater,
+	SelectorLess,
+}
+
+type SelectorCondition struct {
+	Key      string
+	Operator SelectorOperator
+	Value    string
+}
+
+// DeviceSelector describes devices by their capabilities instead of their ids
+// e.g. "platform=android, os_version>=12, label=low-end, count=3"
+type DeviceSelector struct {
+	Conditions []SelectorCondition
+	Count      int
+}
+
+func ParseDeviceSelector(selector string) (*DeviceSelector, error) {
+	s := &DeviceSelector{}
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+
+		condition, err := parseSelectorCondition(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if condition.Key == "count" {
+			if condition.Operator != SelectorEqual {
+				return nil, fmt.Errorf("invalid operator for count: %s", condition.Operator)
+			}
+			count, err := strconv.Atoi(condition.Value)
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("invalid count: %s", condition.Value)
+			}
+			s.Count = count
+			continue
+		}
+
+		s.Conditions = append(s.Conditions, condition)
+	}
+	return s, nil
+}
+
+func parseSelectorCondition(part string) (SelectorCondition, error) {
+	for _, op := range selectorOperators {
+		if idx := strings.Index(part, string(op)); idx > 0 {
+			key := strings.ToLower(strings.TrimSpace(part[:idx]))
+			value := strings.TrimSpace(part[idx+len(op):])
+			if len(key) == 0 || len(value) == 0 {
+				break
+			}
+			return SelectorCondition{Key: key, Operator: op, Value: value}, nil
+		}
+	}
+	return SelectorCondition{}, fmt.Errorf("invalid selector condition: %s", part)
+}
+
+// Matches checks the static properties of the device, it doesn't check if the device is available
+func (s *DeviceSelector) Matches(d *Device) bool {
+	for _, c := range s.Conditions {
+		if !c.matches(d) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns all matching devices
+func (s *DeviceSelector) Filter(devices []Device) []Device {
+	var matching []Device
+	for i := range devices {
+		if s.Matches(&devices[i]) {
+			matching = append(matching, devices[i])
+		}
+	}
+	return matching
+}
+
+// Select returns the matching devices limited by the count of the selector
+func (s *DeviceSelector) Select(devices []Device) []Device {
+	var selected []Device
+	for i := range devices {
+		if s.Count > 0 && len(selected) >= s.Count {
+			break
+		}
+		if s.Matches(&devices[i]) {
+			selected = append(selected, devices[i])
+		}
+	}
+	return selected
+}
+
+func (c SelectorCondition) matches(d *Device) bool {
+	switch c.Key {
+	case "platform":
+		return c.compareString(PlatformTypeToString(d.PlatformType))
+	case "type", "device_type":
+		return c.compareString(DeviceTypeToString(d.DeviceType))
+	case "os":
+		return c.compareString(d.OS)
+	case "os_version":
+		return c.compareVersion(d.OSVersion)
+	case "target_version":
+		return c.compareVersion(d.TargetVersion)
+	case "manager":
+		return c.compareString(d.Manager)
+	case "name":
+		return c.compareString(d.Name)
+	case "alias":
+		return c.compareString(d.Alias)
+	case "label":
+		switch c.Operator {
+		case SelectorEqual:
+			return d.HasLabel(c.Value)
+		case SelectorNotEqual:
+			return !d.HasLabel(c.Value)
+		}
+		return false
+	}
+
+	// fallback to custom and device parameters
+	for i := range d.CustomParameter {
+		if strings.EqualFold(d.CustomParameter[i].Key, c.Key) {
+			return c.compareValue(d.CustomParameter[i].Value)
+		}
+	}
+	for i := range d.DeviceParameter {
+		if strings.EqualFold(d.DeviceParameter[i].Key, c.Key) {
+			return c.compareValue(d.DeviceParameter[i].Value)
+		}
+	}
+	return c.Operator == SelectorNotEqual
+}
+
+func (c SelectorCondition) compareValue(value string) bool {
+	if _, err := strconv.Atoi(strings.Split(c.Value, ".")[0]); err == nil {
+		return c.compareVersion(value)
+	}
+	return c.compareString(value)
+}
+
+func (c SelectorCondition) compareString(value string) bool {
+	switch c.Operator {
+	case SelectorEqual:
+		return strings.EqualFold(value, c.Value)
+	case SelectorNotEqual:
+		return !strings.EqualFold(value, c.Value)
+	}
+	return false
+}
+
+func (c SelectorCondition) compareVersion(value string) bool {
+	if len(value) == 0 {
+		return c.Operator == SelectorNotEqual
+	}
+	result := CompareVersion(value, c.Value)
+	switch c.Operator {
+	case SelectorEqual:
+		return result == 0
+	case SelectorNotEqual:
+		return result != 0
+	case SelectorGreater:
+		return result > 0
+	case SelectorGreaterOrEqual:
+		return result >= 0
+	case SelectorLess:
+		return result < 0
+	case SelectorLessOrEqual:
+		return result <= 0
+	}
+	return false
+}
+
+// CompareVersion compares dot separated versions like 12.1.3 segment by segment
+func CompareVersion(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var va, vb int
+		if i < len(partsA) {
+			va, _ = strconv.Atoi(strings.TrimSpace(partsA[i]))
+		}
+		if i < len(partsB) {
+			vb, _ = strconv.Atoi(strings.TrimSpace(partsB[i]))
+		}
+		if va < vb {
+			return -1
+		}
+		if va > vb {
+			return 1
+		}
+	}
+	return 0
+}
+
+func PlatformTypeToString(t PlatformType) string {
+	switch t {
+	case PlatformTypeiOS:
+		return "ios"
+	case PlatformTypeAndroid:
+		return "android"
+	case PlatformTypeMac:
+		return "mac"
+	case PlatformTypeWindows:
+		return "windows"
+	case PlatformTypeLinux:
+		return "linux"
+	case PlatformTypeWeb:
+		return "web"
+	case PlatformTypeEditor:
+		return "editor"
+	case PlatformTypeiOSSimulator:
+		return "ios_simulator"
+	}
+	return ""
+}
+
+func DeviceTypeToString(t DeviceType) string {
+	switch t {
+	case DeviceTypePhone:
+		return "phone"
+	case DeviceTypeTablet:
+		return "tablet"
+	case DeviceTypeDesktop:
+		return "desktop"
+	case DeviceTypeUnityEditor:
+		return "unity_editor"
+	case DeviceTypeBrowser:
+		return "browser"
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestDeviceSelector(t *testing.T) {
+	selector, err := ParseDeviceSelector("platform=android, os_version>=12, label=low-end, count=2")
+	if err != nil {
+		t.Fatalf("parse selector failed: %v", err)
+	}
+
+	if selector.Count != 2 {
+		t.Errorf("count mismatch expected 2 parsed %d", selector.Count)
+	}
+
+	devices := []Device{
+		{PlatformType: PlatformTypeAndroid, OSVersion: "13", Labels: "low-end, cn"},
+		{PlatformType: PlatformTypeAndroid, OSVersion: "11.0.1", Labels: "low-end"},
+		{PlatformType: PlatformTypeiOS, OSVersion: "16.2", Labels: "low-end"},
+		{PlatformType: PlatformTypeAndroid, OSVersion: "12.1", Labels: "Low-End"},
+		{PlatformType: PlatformTypeAndroid, OSVersion: "14", Labels: "low-end"},
+	}
+
+	selected := selector.Select(devices)
+	if len(selected) != 2 {
+		t.Fatalf("selected devices mismatch expected 2 got %d", len(selected))
+	}
+	if selected[0].OSVersion != "13" || selected[1].OSVersion != "12.1" {
+		t.Errorf("unexpected devices selected: %s, %s", selected[0].OSVersion, selected[1].OSVersion)
+	}
+
+	custom, err := ParseDeviceSelector("gpu=mali")
+	if err != nil {
+		t.Fatalf("parse selector failed: %v", err)
+	}
+	dev := Device{CustomParameter: []CustomParameter{{Key: "GPU", Value: "Mali"}}}
+	if !custom.Matches(&dev) {
+		t.Errorf("custom parameter selector did not match")
+	}
+
+	if _, err := ParseDeviceSelector("platform"); err == nil {
+		t.Errorf("expected error for invalid condition")
+	}
+}
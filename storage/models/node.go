@@ -1,8 +1,34 @@
 package models
 
+import (
+	"github.com/fsuhrau/automationhub/hub/manager"
+	"time"
+)
+
+type NodeMaintenanceState string
+
+const (
+	NodeMaintenanceNone     NodeMaintenanceState = ""
+	NodeMaintenanceDraining NodeMaintenanceState = "draining"
+	NodeMaintenanceDrained  NodeMaintenanceState = "drained"
+)
+
 type Node struct {
 	Model
-	Identifier string `json:"identifier" gorm:"unique"`
-	Name       string `json:"name"`
-	Status     int    `json:"status" db:"-"`
+	Identifier        string               `json:"identifier" gorm:"unique"`
+	Name              string               `json:"name"`
+	Status            int                  `json:"status" db:"-"`
+	Maintenance       NodeMaintenanceState `json:"maintenance"`
+	MaintenanceReason string               `json:"maintenanceReason"`
+	DrainDeadline     *time.Time           `json:"drainDeadline"`
+	RunningTests      int64                `json:"runningTests" gorm:"-"`
+	Version           string               `json:"version" gorm:"-"`
+	ProtocolVersion   int32                `json:"protocolVersion" gorm:"-"`
+	Features          []string             `json:"features" gorm:"-"`
+	Metrics           *manager.NodeMetrics `json:"metrics,omitempty" gorm:"-"`
+}
+
+// AcceptsLocks reports if devices of the node can be locked for new test runs
+func (n *Node) AcceptsLocks() bool {
+	return n.Maintenance == NodeMaintenanceNone
 }
@@ -14,6 +14,7 @@ type ExecutionType uint
 const (
 	ConcurrentExecutionType     ExecutionType = iota // each test on a different free device ( faster results )
 	SimultaneouslyExecutionType                      // every test on every device ( more accuracy )
+	ShardedExecutionType                             // tests balanced by their previous durations over the devices
 )
 
 type UnityTestCategoryType uint
@@ -26,13 +27,16 @@ const (
 
 type TestConfig struct {
 	Model
-	TestID        uint               `json:"testId"`
-	Test          *Test              `json:"test"`
-	ExecutionType ExecutionType      `json:"executionType"`
-	Type          TestType           `json:"type"`
-	AllDevices    bool               `json:"allDevices"`
-	Devices       []TestConfigDevice `json:"devices"`
-	Unity         *TestConfigUnity   `json:"unity"`
+	TestID         uint               `json:"testId"`
+	Test           *Test              `json:"test"`
+	ExecutionType  ExecutionType      `json:"executionType"`
+	Type           TestType           `json:"type"`
+	AllDevices     bool               `json:"allDevices"`
+	Devices        []TestConfigDevice `json:"devices"`
+	DeviceSelector string             `json:"deviceSelector"`
+	TestTimeout    uint               `json:"testTimeout"` // seconds a single test may take, 0 uses the default
+	RunTimeout     uint               `json:"runTimeout"`  // seconds after which the whole run gets cancelled, 0 disables it
+	Unity          *TestConfigUnity   `json:"unity"`
 	// Cocos 	*CocosTestConfig
 	// Serenity *SerenityTestConfig
 	Scenario *TestConfigScenario `json:"scenario"`
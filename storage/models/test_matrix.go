@@ -0,0 +1,242 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxMatrixCombinations limits the number of runs a single matrix can start
+const MaxMatrixCombinations = 100
+
+// TestMatrix executes a test once for every combination of the values of its dimensions
+type TestMatrix struct {
+	Model
+	TestID      uint       `json:"testId"`
+	AppBinaryID uint       `json:"appBinaryId"`
+	StartURL    string     `json:"startUrl"`
+	Parameter   string     `json:"parameter"`  // parameters shared by all combinations e.g. server=staging
+	Dimensions  string     `json:"dimensions"` // e.g. graphics=[low,high];locale=[en,de,ja]
+	FinishedAt  *time.Time `json:"finishedAt"`
+	Runs        []TestRun  `json:"runs,omitempty" gorm:"foreignKey:MatrixID"`
+}
+
+type MatrixDimension struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// MatrixCombination holds one value of every dimension in the order of the dimensions
+type MatrixCombination []string
+
+type MatrixDimensions []MatrixDimension
+
+// ParseMatrixDimensions parses dimensions separated by ; or new lines
+// e.g. "graphics=[low,high];locale=[en,de,ja]", the brackets are optional
+func ParseMatrixDimensions(matrix string) (MatrixDimensions, error) {
+	var dimensions MatrixDimensions
+	known := make(map[string]bool)
+	for _, part := range strings.FieldsFunc(matrix, func(r rune) bool { return r == ';' || r == '\n' }) {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+
+		name, values, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid matrix dimension: %s", part)
+		}
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			return nil, fmt.Errorf("missing name of matrix dimension: %s", part)
+		}
+		if known[name] {
+			return nil, fmt.Errorf("duplicate matrix dimension: %s", name)
+		}
+		known[name] = true
+
+		values = strings.TrimSpace(values)
+		values = strings.TrimSuffix(strings.TrimPrefix(values, "["), "]")
+		dimension := MatrixDimension{Name: name}
+		knownValues := make(map[string]bool)
+		for _, value := range strings.Split(values, ",") {
+			value = strings.TrimSpace(value)
+			if len(value) == 0 {
+				continue
+			}
+			if knownValues[value] {
+				return nil, fmt.Errorf("duplicate value %s of matrix dimension %s", value, name)
+			}
+			knownValues[value] = true
+			dimension.Values = append(dimension.Values, value)
+		}
+		if len(dimension.Values) == 0 {
+			return nil, fmt.Errorf("matrix dimension %s has no values", name)
+		}
+		dimensions = append(dimensions, dimension)
+	}
+
+	if len(dimensions) == 0 {
+		return nil, fmt.Errorf("matrix has no dimensions")
+	}
+	if _, err := dimensions.Count(); err != nil {
+		return nil, err
+	}
+	return dimensions, nil
+}
+
+func (d MatrixDimensions) String() string {
+	var parts []string
+	for _, dimension := range d {
+		parts = append(parts, fmt.Sprintf("%s=[%s]", dimension.Name, strings.Join(dimension.Values, ",")))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Count returns the number of combinations of the dimensions, it stops as soon as the
+// combinations exceed MaxMatrixCombinations so that large matrices can't overflow
+func (d MatrixDimensions) Count() (int, error) {
+	count := 1
+	for _, dimension := range d {
+		count *= len(dimension.Values)
+		if count > MaxMatrixCombinations {
+			return 0, fmt.Errorf("matrix expands to more than %d combinations", MaxMatrixCombinations)
+		}
+	}
+	return count, nil
+}
+
+// Combinations expands the dimensions into all combinations, the first dimension changes slowest
+func (d MatrixDimensions) Combinations() []MatrixCombination {
+	combinations := []MatrixCombination{{}}
+	for _, dimension := range d {
+		var expanded []MatrixCombination
+		for _, combination := range combinations {
+			for _, value := range dimension.Values {
+				next := make(MatrixCombination, len(combination), len(d))
+				copy(next, combination)
+				expanded = append(expanded, append(next, value))
+			}
+		}
+		combinations = expanded
+	}
+	return combinations
+}
+
+// Parameter returns the combination as run parameters
+func (d MatrixDimensions) Parameter(combination MatrixCombination) map[string]string {
+	params := make(map[string]string)
+	for i, dimension := range d {
+		params[dimension.Name] = combination[i]
+	}
+	return params
+}
+
+// Key identifies the combination in the runs of the matrix e.g. graphics=low;locale=en
+func (d MatrixDimensions) Key(combination MatrixCombination) string {
+	var parts []string
+	for i, dimension := range d {
+		parts = append(parts, fmt.Sprintf("%s=%s", dimension.Name, combination[i]))
+	}
+	return strings.Join(parts, ";")
+}
+
+type TestMatrixCell struct {
+	Combination map[string]string `json:"combination"`
+	TestRunID   *uint             `json:"testRunId,omitempty"`
+	Result      TestResultState   `json:"result"`
+	Succeeded   int               `json:"succeeded"`
+	Unstable    int               `json:"unstable"`
+	Failed      int               `json:"failed"`
+	Quarantined int               `json:"quarantined"`
+}
+
+// TestMatrixSummary lays out the runs of a matrix as grid, the rows are the values of the first
+// dimension and the columns the combinations of the remaining ones
+type TestMatrixSummary struct {
+	Matrix     *TestMatrix        `json:"matrix"`
+	Dimensions MatrixDimensions   `json:"dimensions"`
+	Rows       []string           `json:"rows"`
+	Columns    []string           `json:"columns"`
+	Grid       [][]TestMatrixCell `json:"grid"`
+}
+
+// NewTestMatrixSummary builds the grid of the matrix from the runs of its combinations with their protocols
+func NewTestMatrixSummary(matrix *TestMatrix) (*TestMatrixSummary, error) {
+	dimensions, err := ParseMatrixDimensions(matrix.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make(map[string]*TestRun)
+	for i := range matrix.Runs {
+		runs[matrix.Runs[i].MatrixCombination] = &matrix.Runs[i]
+	}
+
+	summary := &TestMatrixSummary{
+		Matrix:     matrix,
+		Dimensions: dimensions,
+		Rows:       dimensions[0].Values,
+	}
+	columns := dimensions[1:].Combinations()
+	for _, column := range columns {
+		summary.Columns = append(summary.Columns, dimensions[1:].Key(column))
+	}
+
+	for _, row := range dimensions[0].Values {
+		var cells []TestMatrixCell
+		for _, column := range columns {
+			combination := append(MatrixCombination{row}, column...)
+			cell := TestMatrixCell{
+				Combination: dimensions.Parameter(combination),
+				Result:      TestResultOpen,
+			}
+			if run, ok := runs[dimensions.Key(combination)]; ok {
+				cell.TestRunID = &run.ID
+				cell.collect(run.Protocols, run.FinishedAt != nil || matrix.FinishedAt != nil)
+			}
+			cells = append(cells, cell)
+		}
+		summary.Grid = append(summary.Grid, cells)
+	}
+	return summary, nil
+}
+
+// collect counts the results of the protocols, the cell stays open while the run is not finished and
+// a protocol is running, a finished run without protocols or with open protocols failed
+func (c *TestMatrixCell) collect(protocols []TestProtocol, finished bool) {
+	running := !finished && len(protocols) == 0
+	for _, p := range protocols {
+		if p.EndedAt == nil {
+			// the protocol was interrupted, e.g. by a restart of the master
+			if finished {
+				c.Failed++
+				continue
+			}
+			running = true
+		}
+		switch p.TestResult {
+		case TestResultSuccess:
+			c.Succeeded++
+		case TestResultUnstable:
+			c.Unstable++
+		case TestResultFailed, TestResultNodeLost, TestResultTimedOut:
+			if p.Quarantined {
+				c.Quarantined++
+			} else {
+				c.Failed++
+			}
+		}
+	}
+
+	switch {
+	case running:
+		c.Result = TestResultOpen
+	case c.Failed > 0 || len(protocols) == 0:
+		c.Result = TestResultFailed
+	case c.Unstable > 0:
+		c.Result = TestResultUnstable
+	default:
+		c.Result = TestResultSuccess
+	}
+}
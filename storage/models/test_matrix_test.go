@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMatrixDimensions(t *testing.T) {
+	dimensions, err := ParseMatrixDimensions("graphics=[low,high]; locale=[en, de, ja]\nserver=staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := MatrixDimensions{
+		{Name: "graphics", Values: []string{"low", "high"}},
+		{Name: "locale", Values: []string{"en", "de", "ja"}},
+		{Name: "server", Values: []string{"staging"}},
+	}
+	if !reflect.DeepEqual(dimensions, expected) {
+		t.Errorf("expected %v got %v", expected, dimensions)
+	}
+	if dimensions.String() != "graphics=[low,high];locale=[en,de,ja];server=[staging]" {
+		t.Errorf("unexpected string %s", dimensions.String())
+	}
+
+	for _, invalid := range []string{"", "graphics", "=[low]", "graphics=[]", "graphics=[low];graphics=[high]", "graphics=[low,low]"} {
+		if _, err := ParseMatrixDimensions(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+
+	if _, err := ParseMatrixDimensions("a=[1,2,3,4,5];b=[1,2,3,4,5];c=[1,2,3,4,5]"); err == nil {
+		t.Errorf("expected error for too many combinations")
+	}
+
+	// the product of 64 dimensions with two values each overflows an int
+	var overflowing []string
+	for i := 0; i < 64; i++ {
+		overflowing = append(overflowing, fmt.Sprintf("d%d=[a,b]", i))
+	}
+	if _, err := ParseMatrixDimensions(strings.Join(overflowing, ";")); err == nil {
+		t.Errorf("expected error for overflowing combinations")
+	}
+}
+
+func TestMatrixCombinations(t *testing.T) {
+	dimensions, _ := ParseMatrixDimensions("graphics=[low,high];locale=[en,de,ja]")
+	combinations := dimensions.Combinations()
+	if count, err := dimensions.Count(); err != nil || len(combinations) != count || len(combinations) != 6 {
+		t.Fatalf("expected 6 combinations got %d", len(combinations))
+	}
+	if dimensions.Key(combinations[0]) != "graphics=low;locale=en" || dimensions.Key(combinations[5]) != "graphics=high;locale=ja" {
+		t.Errorf("unexpected order %v", combinations)
+	}
+	if params := dimensions.Parameter(combinations[4]); params["graphics"] != "high" || params["locale"] != "de" {
+		t.Errorf("unexpected parameter %v", params)
+	}
+}
+
+func TestTestMatrixSummary(t *testing.T) {
+	now := time.Now()
+	matrix := &TestMatrix{
+		Dimensions: "graphics=[low,high];locale=[en,de]",
+		Runs: []TestRun{
+			{Model: Model{ID: 1}, MatrixCombination: "graphics=low;locale=en", Protocols: []TestProtocol{
+				{TestResult: TestResultSuccess, EndedAt: &now},
+				{TestResult: TestResultFailed, EndedAt: &now, Quarantined: true},
+			}},
+			{Model: Model{ID: 2}, MatrixCombination: "graphics=low;locale=de", Protocols: []TestProtocol{
+				{TestResult: TestResultFailed, EndedAt: &now},
+			}},
+			{Model: Model{ID: 3}, MatrixCombination: "graphics=high;locale=en", Protocols: []TestProtocol{
+				{TestResult: TestResultOpen},
+			}},
+		},
+	}
+	started := TestRun{Model: Model{ID: 4}, MatrixCombination: "graphics=high;locale=de"}
+
+	summary, err := NewTestMatrixSummary(matrix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(summary.Rows, []string{"low", "high"}) || !reflect.DeepEqual(summary.Columns, []string{"locale=en", "locale=de"}) {
+		t.Errorf("unexpected layout %v %v", summary.Rows, summary.Columns)
+	}
+
+	expected := [][]TestResultState{
+		{TestResultSuccess, TestResultFailed},
+		{TestResultOpen, TestResultOpen},
+	}
+	for r, row := range summary.Grid {
+		for c, cell := range row {
+			if cell.Result != expected[r][c] {
+				t.Errorf("cell %d/%d expected %v got %v", r, c, expected[r][c], cell.Result)
+			}
+		}
+	}
+	if cell := summary.Grid[0][0]; cell.Succeeded != 1 || cell.Quarantined != 1 || *cell.TestRunID != 1 {
+		t.Errorf("unexpected cell %+v", cell)
+	}
+	if summary.Grid[1][1].TestRunID != nil || summary.Grid[1][1].Combination["locale"] != "de" {
+		t.Errorf("expected pending combination %+v", summary.Grid[1][1])
+	}
+
+	// a run without protocols is open until it finished
+	matrix.Runs = append(matrix.Runs, started)
+	summary, _ = NewTestMatrixSummary(matrix)
+	if cell := summary.Grid[1][1]; cell.Result != TestResultOpen || *cell.TestRunID != 4 {
+		t.Errorf("expected started combination to be open %+v", cell)
+	}
+	matrix.Runs[3].FinishedAt = &now
+	summary, _ = NewTestMatrixSummary(matrix)
+	if cell := summary.Grid[1][1]; cell.Result != TestResultFailed {
+		t.Errorf("expected finished combination without protocols to fail %+v", cell)
+	}
+
+	// open protocols of a finished matrix are not running anymore
+	matrix.Runs[3].FinishedAt = nil
+	matrix.FinishedAt = &now
+	summary, _ = NewTestMatrixSummary(matrix)
+	if summary.Grid[1][0].Result == TestResultOpen || summary.Grid[1][1].Result != TestResultFailed {
+		t.Errorf("expected no open cells in a finished matrix %+v", summary.Grid[1])
+	}
+}
@@ -16,6 +16,7 @@ type TestProtocol struct {
 	EndedAt              *time.Time                 `json:"endedAt,omitempty"`
 	Entries              []ProtocolEntry            `json:"entries,omitempty"`
 	TestResult           TestResultState            `json:"testResult,omitempty"`
+	Quarantined          bool                       `json:"quarantined,omitempty"`
 	Performance          []ProtocolPerformanceEntry `json:"performance,omitempty"`
 	AvgFPS               float64                    `sql:"type:decimal(10,2);" json:"avgFps,omitempty"`
 	AvgMEM               float64                    `sql:"type:decimal(10,2);" json:"avgMem,omitempty"`
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// TestQuarantine marks a known broken test, quarantined tests still run but their failures don't fail the run
+type TestQuarantine struct {
+	Model
+	TestID              uint       `json:"testId"`
+	UnityTestFunctionID *uint      `json:"unityTestFunctionId,omitempty"`
+	TestName            string     `json:"testName"` // Class/Method of a unity test, empty quarantines the whole test e.g. a scenario
+	Owner               string     `json:"owner"`
+	Reason              string     `json:"reason"`
+	ExpiresAt           time.Time  `json:"expiresAt"`
+	ReleasedAt          *time.Time `json:"releasedAt"`
+}
+
+func (q *TestQuarantine) IsActive() bool {
+	return q.ReleasedAt == nil && time.Now().Before(q.ExpiresAt)
+}
+
+// Matches checks if the protocol of the given name is covered by the quarantine
+func (q *TestQuarantine) Matches(testName string) bool {
+	if len(q.TestName) == 0 || q.TestName == testName {
+		return true
+	}
+	// tests reported by the unity test runner are named Class.Method
+	if idx := strings.LastIndex(testName, "."); idx > 0 {
+		return q.TestName == testName[:idx]+"/"+testName[idx+1:]
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTestQuarantine(t *testing.T) {
+	quarantine := TestQuarantine{TestName: "Game.Tests.Inventory/Add", ExpiresAt: time.Now().Add(time.Hour)}
+	if !quarantine.IsActive() {
+		t.Errorf("expected active quarantine")
+	}
+	for name, expected := range map[string]bool{
+		"Game.Tests.Inventory/Add":    true,
+		"Game.Tests.Inventory.Add":    true,
+		"Game.Tests.Inventory/Remove": false,
+		"Game.Tests.Shop.Add":         false,
+	} {
+		if quarantine.Matches(name) != expected {
+			t.Errorf("match of %s expected %v", name, expected)
+		}
+	}
+
+	whole := TestQuarantine{ExpiresAt: time.Now().Add(-time.Minute)}
+	if whole.IsActive() || !whole.Matches("Scenario") {
+		t.Errorf("expected expired quarantine of the whole test")
+	}
+}
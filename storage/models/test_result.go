@@ -7,4 +7,14 @@ const (
 	TestResultUnstable
 	TestResultFailed
 	TestResultSuccess
+	TestResultNodeLost
+	TestResultTimedOut
 )
+
+// FailedTestResults are the results of protocols which count as failure
+var FailedTestResults = []TestResultState{TestResultFailed, TestResultNodeLost, TestResultTimedOut}
+
+// Failed reports if the result counts as failure, lost nodes and timeouts fail a test too
+func (s TestResultState) Failed() bool {
+	return s == TestResultFailed || s == TestResultNodeLost || s == TestResultTimedOut
+}
@@ -1,15 +1,23 @@
 package models
 
+import (
+	"time"
+)
+
 type TestRun struct {
 	Model
-	TestID       uint                  `json:"testId"`
-	Test         *Test                 `json:"test"`
-	AppBinaryID  uint                  `json:"appBinaryId"`
-	AppBinary    *AppBinary            `json:"appBinary"`
-	StartURL     string                `json:"startUrl"`
-	SessionID    string                `json:"sessionId"`
-	Parameter    string                `json:"parameter"`
-	Protocols    []TestProtocol        `json:"protocols"`
-	Log          []TestRunLogEntry     `json:"log"`
-	DeviceStatus []TestRunDeviceStatus `json:"deviceStatus"`
+	TestID            uint                  `json:"testId"`
+	Test              *Test                 `json:"test"`
+	AppBinaryID       uint                  `json:"appBinaryId"`
+	AppBinary         *AppBinary            `json:"appBinary"`
+	StartURL          string                `json:"startUrl"`
+	SessionID         string                `json:"sessionId"`
+	Parameter         string                `json:"parameter"`
+	RerunOfID         *uint                 `json:"rerunOfId,omitempty"` // run whose failed tests were executed again
+	MatrixID          *uint                 `json:"matrixId,omitempty"`
+	MatrixCombination string                `json:"matrixCombination,omitempty"` // e.g. graphics=low;locale=en
+	FinishedAt        *time.Time            `json:"finishedAt,omitempty"`
+	Protocols         []TestProtocol        `json:"protocols"`
+	Log               []TestRunLogEntry     `json:"log"`
+	DeviceStatus      []TestRunDeviceStatus `json:"deviceStatus"`
 }
@@ -2,10 +2,11 @@ package models
 
 type TestRunDeviceStatus struct {
 	Model
-	TestRunID   uint    `json:"testRunId"`
-	DeviceID    uint    `json:"deviceId"`
-	Device      *Device `json:"device"`
-	StartupTime uint    `json:"startupTime"`
+	TestRunID     uint    `json:"testRunId"`
+	DeviceID      uint    `json:"deviceId"`
+	Device        *Device `json:"device"`
+	StartupTime   uint    `json:"startupTime"`
+	StartupFailed bool    `json:"startupFailed"`
 
 	// calculated fields
 	HistAvgStartupTime uint `db:"-" gorm:"-:all" json:"histAvgStartupTime"`
@@ -6,4 +6,5 @@ type UnityTestFunction struct {
 	Assembly          string `json:"assembly"`
 	Class             string `json:"class"`
 	Method            string `json:"method"`
+	Timeout           int64  `json:"timeout"` // milliseconds of the [Timeout] attribute, 0 uses the timeout of the config
 }
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"github.com/fsuhrau/automationhub/storage/models"
+	"gorm.io/gorm"
+)
+
+// GetMaintenanceNodes returns the ids of all nodes which are draining or drained
+func GetMaintenanceNodes(db *gorm.DB) (map[uint]bool, error) {
+	var nodes []models.Node
+	if err := db.Where("maintenance <> ?", models.NodeMaintenanceNone).Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint]bool)
+	for i := range nodes {
+		result[nodes[i].ID] = true
+	}
+	return result, nil
+}
+
+// IsNodeInMaintenance reports if devices of the node shouldn't be locked for new test runs
+func IsNodeInMaintenance(db *gorm.DB, nodeID uint) (bool, error) {
+	var node models.Node
+	if err := db.First(&node, nodeID).Error; err != nil {
+		return false, err
+	}
+	return !node.AcceptsLocks(), nil
+}
+
+// CountRunningProtocols returns the number of protocols of unfinished runs which are still running on devices of the node
+func CountRunningProtocols(db *gorm.DB, nodeID uint) (int64, error) {
+	var count int64
+	err := db.Model(&models.TestProtocol{}).
+		Joins("join devices on devices.id = test_protocols.device_id").
+		Joins("join test_runs on test_runs.id = test_protocols.test_run_id").
+		Where("devices.node_id = ? and test_protocols.ended_at is null and test_runs.finished_at is null", nodeID).
+		Count(&count).Error
+	return count, err
+}
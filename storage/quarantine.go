@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"github.com/fsuhrau/automationhub/storage/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetActiveQuarantines returns the quarantined tests of the test which are not expired or released
+func GetActiveQuarantines(db *gorm.DB, testID uint) ([]models.TestQuarantine, error) {
+	var quarantines []models.TestQuarantine
+	if err := db.Where("test_id = ? and released_at is null and expires_at > ?", testID, time.Now()).Find(&quarantines).Error; err != nil {
+		return nil, err
+	}
+	return quarantines, nil
+}
+
+// ReleaseExpiredQuarantines marks all expired quarantines as released and returns them
+func ReleaseExpiredQuarantines(db *gorm.DB) ([]models.TestQuarantine, error) {
+	now := time.Now()
+	var quarantines []models.TestQuarantine
+	if err := db.Where("released_at is null and expires_at <= ?", now).Find(&quarantines).Error; err != nil {
+		return nil, err
+	}
+	for i := range quarantines {
+		quarantines[i].ReleasedAt = &now
+		if err := db.Model(&quarantines[i]).Update("released_at", now).Error; err != nil {
+			return nil, err
+		}
+	}
+	return quarantines, nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"github.com/fsuhrau/automationhub/storage/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetActiveReservation returns the reservation currently holding the device or nil
+func GetActiveReservation(db *gorm.DB, deviceID uint) (*models.DeviceReservation, error) {
+	var reservations []models.DeviceReservation
+	if err := db.Where("device_id = ? and released_at is null and expires_at > ?", deviceID, time.Now()).Order("id desc").Limit(1).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	if len(reservations) == 0 {
+		return nil, nil
+	}
+	return &reservations[0], nil
+}
+
+// GetActiveReservations returns all active reservations mapped by device id
+func GetActiveReservations(db *gorm.DB) (map[uint]*models.DeviceReservation, error) {
+	var reservations []models.DeviceReservation
+	if err := db.Where("released_at is null and expires_at > ?", time.Now()).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[uint]*models.DeviceReservation)
+	for i := range reservations {
+		result[reservations[i].DeviceID] = &reservations[i]
+	}
+	return result, nil
+}
+
+// ReleaseExpiredReservations marks all expired reservations as released and returns them
+func ReleaseExpiredReservations(db *gorm.DB) ([]models.DeviceReservation, error) {
+	now := time.Now()
+	var reservations []models.DeviceReservation
+	if err := db.Where("released_at is null and expires_at <= ?", now).Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	for i := range reservations {
+		reservations[i].ReleasedAt = &now
+		if err := db.Model(&reservations[i]).Update("released_at", now).Error; err != nil {
+			return nil, err
+		}
+	}
+	return reservations, nil
+}
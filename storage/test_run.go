@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"github.com/fsuhrau/automationhub/storage/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+// FinishInterruptedRuns finishes the runs and closes the protocols which were still running when the master stopped,
+// open protocols are reported as failed
+func FinishInterruptedRuns(db *gorm.DB) (int64, error) {
+	now := time.Now()
+	if err := db.Model(&models.TestProtocol{}).
+		Where("ended_at is null and test_run_id in (?)", db.Model(&models.TestRun{}).Select("id").Where("finished_at is null")).
+		Updates(map[string]interface{}{"ended_at": now, "test_result": models.TestResultFailed}).Error; err != nil {
+		return 0, err
+	}
+	result := db.Model(&models.TestRun{}).Where("finished_at is null").Update("finished_at", now)
+	return result.RowsAffected, result.Error
+}
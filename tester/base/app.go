@@ -91,9 +91,42 @@ func (tr *TestRunner) StartApp(ctx context.Context, params app.Parameter, device
 		connectedDevices = append(connectedDevices, d)
 	}
 
+	// a cancelled run doesn't count as startup failure
+	if ctx.Err() == nil {
+		recoveries := sync.NewExtendedWaitGroup(ctx)
+		for _, d := range devices {
+			connected := false
+			for _, c := range connectedDevices {
+				if c.Device == d.Device {
+					connected = true
+					break
+				}
+			}
+			if !connected {
+				tr.ProtocolWriter.TrackStartupFailure(d.Model.ID)
+				recoveries.Add(1)
+				go func(d DeviceMap, group sync.ExtendedWaitGroup) {
+					defer group.Done()
+					tr.recoverDevice(ctx, d, params)
+				}(d, recoveries)
+			} else {
+				tr.DeviceManager.ResetRecovery(d.Device)
+			}
+		}
+		// the recovery steps are limited by the recovery timeout, a hanging device doesn't stall the others
+		_ = recoveries.Wait()
+	}
+
 	return connectedDevices, err
 }
 
+func (tr *TestRunner) recoverDevice(ctx context.Context, d DeviceMap, params app.Parameter) {
+	tr.LogInfo("App on device '%s' didn't connect, starting recovery", d.Device.DeviceID())
+	if err := tr.DeviceManager.RecoverDevice(ctx, d.Device, &params); err != nil {
+		tr.LogError("recovery of device '%s' failed: %v", d.Device.DeviceID(), err)
+	}
+}
+
 func (tr *TestRunner) startApp(context context.Context, group sync.ExtendedWaitGroup, deviceChan chan DeviceMap, appp app.Parameter, d DeviceMap, sessionId string, appStartedFunc DeviceFunc, connectedFunc DeviceFunc) {
 	waitTime := 500 * time.Millisecond
 	waitTrigger := time.NewTimer(waitTime)
@@ -2,11 +2,14 @@ package base
 
 import (
 	"context"
+	"fmt"
 	"github.com/fsuhrau/automationhub/device"
 	"github.com/fsuhrau/automationhub/hub/manager"
+	"github.com/fsuhrau/automationhub/storage"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/fsuhrau/automationhub/utils/sync"
 	"github.com/sirupsen/logrus"
+	"time"
 )
 
 func (tr *TestRunner) LockDevices(devs []models.Device) []DeviceMap {
@@ -16,12 +19,26 @@ func (tr *TestRunner) LockDevices(devs []models.Device) []DeviceMap {
 			continue
 		}
 		dev := d.Dev.(device.Device)
+		if dev.DeviceState() == device.StateQuarantined {
+			tr.LogInfo("skip device %s quarantined: %s", dev.DeviceID(), dev.QuarantineReason())
+			continue
+		}
+		if maintenance, err := storage.IsNodeInMaintenance(tr.DB, d.NodeID); err == nil && maintenance {
+			tr.LogInfo("skip device %s node in maintenance", dev.DeviceID())
+			continue
+		}
+		if reservation, err := storage.GetActiveReservation(tr.DB, d.ID); err == nil && reservation != nil {
+			tr.LogInfo("skip device %s reserved by %s until %s", dev.DeviceID(), reservation.Owner, reservation.ExpiresAt.Format(time.RFC3339))
+			continue
+		}
 		tr.LogInfo("locking device: %s", dev.DeviceID())
 		if err := dev.Lock(); err == nil {
+			tr.logDeviceStatus(d.ID, device.StateLocked, fmt.Sprintf("locked by test run %d", tr.TestRun.ID))
 			devices = append(devices, DeviceMap{
 				Device: dev,
 				Model:  d,
 			})
+			tr.trackLockedDevice(devices[len(devices)-1])
 		} else {
 			tr.LogError("locking device %s failed: %v", dev.DeviceID(), err)
 		}
@@ -33,6 +50,20 @@ func (tr *TestRunner) UnlockDevices(devices []DeviceMap) {
 	for i := range devices {
 		tr.DeviceManager.Stop(devices[i].Device)
 		_ = devices[i].Device.Unlock()
+		tr.logDeviceStatus(devices[i].Model.ID, devices[i].Device.DeviceState(), fmt.Sprintf("unlocked by test run %d", tr.TestRun.ID))
+	}
+}
+
+// logDeviceStatus keeps track of lock times in the device log, used for utilization reports
+func (tr *TestRunner) logDeviceStatus(deviceID uint, state device.State, payload string) {
+	entry := models.DeviceLog{
+		DeviceID: deviceID,
+		Time:     time.Now(),
+		Status:   state,
+		Payload:  payload,
+	}
+	if err := tr.DB.Create(&entry).Error; err != nil {
+		logrus.Errorf("unable to write device log: %v", err)
 	}
 }
 
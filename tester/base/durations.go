@@ -0,0 +1,44 @@
+package base
+
+import (
+	"github.com/fsuhrau/automationhub/storage/models"
+	"time"
+)
+
+const (
+	// DurationHistory is the number of recent executions per test which are averaged to estimate its duration
+	DurationHistory = 10
+	// maxDurationSamples limits the number of protocols loaded to estimate the durations
+	maxDurationSamples = 5000
+)
+
+// TestDurations returns the average duration of the recent executions of the test per protocol name,
+// protocols of aborted executions or lost nodes are not taken into account
+func (tr *TestRunner) TestDurations() (map[string]time.Duration, error) {
+	var protocols []models.TestProtocol
+	if err := tr.DB.Select("test_protocols.test_name, test_protocols.started_at, test_protocols.ended_at").
+		Joins("JOIN test_runs ON test_runs.id = test_protocols.test_run_id").
+		Where("test_runs.test_id = ? AND test_protocols.parent_test_protocol_id IS NULL AND test_protocols.ended_at IS NOT NULL", tr.Test.ID).
+		Where("test_protocols.test_result IN ?", []models.TestResultState{models.TestResultSuccess, models.TestResultUnstable, models.TestResultFailed}).
+		Order("test_protocols.id DESC").
+		Limit(maxDurationSamples).
+		Find(&protocols).Error; err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int)
+	for _, p := range protocols {
+		if counts[p.TestName] >= DurationHistory || p.EndedAt.Before(p.StartedAt) {
+			continue
+		}
+		totals[p.TestName] += p.EndedAt.Sub(p.StartedAt)
+		counts[p.TestName]++
+	}
+
+	durations := make(map[string]time.Duration, len(totals))
+	for name, total := range totals {
+		durations[name] = total / time.Duration(counts[name])
+	}
+	return durations, nil
+}
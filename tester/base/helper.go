@@ -23,7 +23,9 @@ func GetParams(binary *models.AppBinary, startupUrl string) app.Parameter {
 		var executable *app.ExecutableParams
 		if binary.Executable.Executable != "" {
 			executable = &app.ExecutableParams{
-				Executable: binary.Executable.Executable,
+				Executable:  binary.Executable.Executable,
+				Arguments:   binary.Executable.ArgumentList(),
+				Environment: binary.Executable.EnvironmentMap(),
 			}
 		}
 		params.App = &app.AppParams{
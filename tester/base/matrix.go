@@ -0,0 +1,7 @@
+package base
+
+// MatrixRun marks the run as the execution of a combination of a matrix
+func (tr *TestRunner) MatrixRun(matrixID uint, combination string) {
+	tr.MatrixID = &matrixID
+	tr.MatrixCombination = combination
+}
@@ -0,0 +1,84 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/hub/manager"
+)
+
+type nodeDevice interface {
+	GetNodeID() manager.NodeIdentifier
+}
+
+func (tr *TestRunner) trackLockedDevice(d DeviceMap) {
+	tr.devicesMutex.Lock()
+	defer tr.devicesMutex.Unlock()
+	tr.lockedDevices = append(tr.lockedDevices, d)
+}
+
+// DeviceContext returns a context which gets canceled when the node of the device got lost
+func (tr *TestRunner) DeviceContext(ctx context.Context, d DeviceMap) (context.Context, context.CancelFunc) {
+	tr.devicesMutex.Lock()
+	defer tr.devicesMutex.Unlock()
+
+	deviceCtx, cancel := context.WithCancel(ctx)
+	if tr.lostDevices[d.Device.DeviceID()] {
+		cancel()
+		return deviceCtx, cancel
+	}
+	if tr.deviceCancel == nil {
+		tr.deviceCancel = make(map[string]context.CancelFunc)
+	}
+	tr.deviceCancel[d.Device.DeviceID()] = cancel
+	return deviceCtx, cancel
+}
+
+func (tr *TestRunner) IsDeviceLost(d DeviceMap) bool {
+	tr.devicesMutex.Lock()
+	defer tr.devicesMutex.Unlock()
+	return tr.lostDevices[d.Device.DeviceID()]
+}
+
+// ActiveDevices returns the number of locked devices which are still reachable
+func (tr *TestRunner) ActiveDevices() int {
+	tr.devicesMutex.Lock()
+	defer tr.devicesMutex.Unlock()
+	return len(tr.lockedDevices) - len(tr.lostDevices)
+}
+
+// RescheduleLostTests reports if tests interrupted by a lost node should be moved to the remaining devices
+func (tr *TestRunner) RescheduleLostTests() bool {
+	tr.devicesMutex.Lock()
+	defer tr.devicesMutex.Unlock()
+	return tr.reschedule && len(tr.lockedDevices) > len(tr.lostDevices)
+}
+
+// NodeLost marks all locked devices of the node as lost, closes their running protocols
+// and cancels the device contexts so that running tests don't wait for their timeouts
+func (tr *TestRunner) NodeLost(node manager.NodeIdentifier, reason string, reschedule bool) {
+	tr.devicesMutex.Lock()
+	tr.reschedule = reschedule
+	if tr.lostDevices == nil {
+		tr.lostDevices = make(map[string]bool)
+	}
+	var lost []DeviceMap
+	for _, d := range tr.lockedDevices {
+		nd, ok := d.Device.(nodeDevice)
+		if !ok || nd.GetNodeID() != node || tr.lostDevices[d.Device.DeviceID()] {
+			continue
+		}
+		tr.lostDevices[d.Device.DeviceID()] = true
+		if cancel, ok := tr.deviceCancel[d.Device.DeviceID()]; ok {
+			cancel()
+		}
+		lost = append(lost, d)
+	}
+	tr.devicesMutex.Unlock()
+
+	for _, d := range lost {
+		tr.LogError("device %s lost with node %s: %s", d.Device.DeviceID(), node, reason)
+		tr.ProtocolWriter.MarkDeviceLost(d.Model.ID, fmt.Sprintf("node %s: %s", node, reason))
+		tr.logDeviceStatus(d.Model.ID, device.StateNodeDisconnected, fmt.Sprintf("node lost during test run %d", tr.TestRun.ID))
+	}
+}
@@ -0,0 +1,48 @@
+package base
+
+import (
+	"sort"
+	"strings"
+)
+
+// rerunName normalizes the protocol names of tests, the unity test runner reports tests as Class.Method
+// while the hub names its protocols Class/Method
+func rerunName(testName string) string {
+	if strings.Contains(testName, "/") {
+		return testName
+	}
+	if idx := strings.LastIndex(testName, "."); idx > 0 {
+		return testName[:idx] + "/" + testName[idx+1:]
+	}
+	return testName
+}
+
+// RerunOf limits the run to the tests of the given protocol names of a previous run
+func (tr *TestRunner) RerunOf(runID uint, testNames []string) {
+	tr.RerunOfID = &runID
+	tr.rerunTests = make(map[string]bool)
+	for _, name := range testNames {
+		tr.rerunTests[rerunName(name)] = true
+	}
+}
+
+func (tr *TestRunner) IsRerun() bool {
+	return tr.RerunOfID != nil
+}
+
+// IsSelected reports if the test of the protocol name is part of the run
+func (tr *TestRunner) IsSelected(testName string) bool {
+	return !tr.IsRerun() || tr.rerunTests[rerunName(testName)]
+}
+
+// RerunTests returns the Class/Method names of the tests to execute again
+func (tr *TestRunner) RerunTests() []string {
+	var names []string
+	for name := range tr.rerunTests {
+		if strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -15,11 +15,14 @@ func (tr *TestRunner) NewSessionID() string {
 func (tr *TestRunner) InitNewTestSession(appBinaryId uint, startURL, params string) error {
 	sessionID := tr.NewSessionID()
 	tr.TestRun = models.TestRun{
-		TestID:      tr.Test.ID,
-		AppBinaryID: appBinaryId,
-		SessionID:   sessionID,
-		StartURL:    startURL,
-		Parameter:   params,
+		TestID:            tr.Test.ID,
+		AppBinaryID:       appBinaryId,
+		SessionID:         sessionID,
+		StartURL:          startURL,
+		Parameter:         params,
+		RerunOfID:         tr.RerunOfID,
+		MatrixID:          tr.MatrixID,
+		MatrixCombination: tr.MatrixCombination,
 	}
 	if err := tr.DB.Create(&tr.TestRun).Error; err != nil {
 		return err
@@ -1,11 +1,13 @@
 package base
 
 import (
+	"context"
 	"github.com/fsuhrau/automationhub/device"
 	"github.com/fsuhrau/automationhub/hub/manager"
 	"github.com/fsuhrau/automationhub/storage/models"
 	"github.com/fsuhrau/automationhub/tester/protocol"
 	"gorm.io/gorm"
+	"sync"
 )
 
 type DeviceMap struct {
@@ -26,6 +28,20 @@ type TestRunner struct {
 
 	ProjectId string
 	AppId     uint
+
+	// RerunOfID references the run whose failed tests are executed again
+	RerunOfID  *uint
+	rerunTests map[string]bool
+
+	// MatrixID references the matrix the run executes the MatrixCombination of
+	MatrixID          *uint
+	MatrixCombination string
+
+	devicesMutex  sync.Mutex
+	lockedDevices []DeviceMap
+	deviceCancel  map[string]context.CancelFunc
+	lostDevices   map[string]bool
+	reschedule    bool
 }
 
 func (t *TestRunner) Init(deviceManager manager.Devices, nodeUrl string, db *gorm.DB, projectId string, appId uint) {
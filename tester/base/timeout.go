@@ -0,0 +1,38 @@
+package base
+
+import (
+	"fmt"
+	"time"
+)
+
+// TestTimeout returns the timeout of a single test configured for the test or fallback if none is configured
+func (tr *TestRunner) TestTimeout(fallback time.Duration) time.Duration {
+	if tr.Config.TestTimeout > 0 {
+		return time.Duration(tr.Config.TestTimeout) * time.Second
+	}
+	return fallback
+}
+
+// RunTimeout returns the maximum duration of a run, 0 if runs have no deadline
+func (tr *TestRunner) RunTimeout() time.Duration {
+	return time.Duration(tr.Config.RunTimeout) * time.Second
+}
+
+// WatchRunTimeout cancels the run with cancel the same way as a user would once it exceeds the maximum duration,
+// the protocols still running at that time are closed as timed out while finished ones are kept.
+// the returned function stops the watch
+func (tr *TestRunner) WatchRunTimeout(cancel func(runId string) error) func() bool {
+	timeout := tr.RunTimeout()
+	if timeout == 0 {
+		return func() bool { return false }
+	}
+
+	timer := time.AfterFunc(timeout, func() {
+		tr.LogError("Run exceeded the maximum duration of %s, cancel run", timeout)
+		tr.ProtocolWriter.MarkTimedOut(fmt.Sprintf("run exceeded the maximum duration of %s", timeout))
+		if err := cancel(fmt.Sprint(tr.TestRun.ID)); err != nil {
+			tr.LogError("Unable to cancel run: %v", err)
+		}
+	})
+	return timer.Stop
+}
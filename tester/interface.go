@@ -1,6 +1,7 @@
 package tester
 
 import (
+	"github.com/fsuhrau/automationhub/hub/manager"
 	"github.com/fsuhrau/automationhub/storage/models"
 )
 
@@ -9,3 +10,18 @@ type Interface interface {
 	Run(devs []models.Device, appData *models.AppBinary, startURL string) (*models.TestRun, error)
 	Cancel(runId string) error
 }
+
+// Rerunner is implemented by runners which can execute only the failed tests of a previous run
+type Rerunner interface {
+	RerunOf(runID uint, testNames []string)
+}
+
+// MatrixRunner is implemented by runners which can execute a combination of a matrix run
+type MatrixRunner interface {
+	MatrixRun(matrixID uint, combination string)
+}
+
+// NodeLostHandler is implemented by runners which can react to nodes lost in the middle of a run
+type NodeLostHandler interface {
+	NodeLost(node manager.NodeIdentifier, reason string, reschedule bool)
+}
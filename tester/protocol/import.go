@@ -0,0 +1,99 @@
+package protocol
+
+import (
+	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/fsuhrau/automationhub/tools/testresults"
+	"strings"
+	"time"
+)
+
+func importedTestName(c testresults.TestCase) string {
+	if len(c.ClassName) == 0 {
+		return c.Name
+	}
+	return c.ClassName + "/" + c.Name
+}
+
+func importedEntries(c testresults.TestCase, start, end time.Time) []models.ProtocolEntry {
+	var entries []models.ProtocolEntry
+	if len(c.Output) > 0 {
+		entries = append(entries, models.ProtocolEntry{Timestamp: start, Source: "test", Level: "log", Message: c.Output})
+	}
+	switch c.Result {
+	case testresults.ResultFailed:
+		message := c.Message
+		if len(c.StackTrace) > 0 {
+			message += "\n" + c.StackTrace
+		}
+		entries = append(entries, models.ProtocolEntry{Timestamp: end, Source: "test", Level: "error", Message: message, Runtime: c.Duration.Seconds()})
+	case testresults.ResultSkipped, testresults.ResultInconclusive:
+		entries = append(entries, models.ProtocolEntry{Timestamp: start, Source: "test", Level: "log", Message: strings.ToLower(c.Result) + ": " + c.Message})
+	}
+	return entries
+}
+
+// Import stores the test cases of a result file as protocols of the run, timings and results are taken from
+// the report so imported runs look like runs executed by the hub, dev is optional
+func (w *ProtocolWriter) Import(report *testresults.Report, dev *models.Device) error {
+	var (
+		failedCount      int
+		successCount     int
+		quarantinedCount int
+	)
+
+	// results without timestamps are placed one after the other, ending at the time of the import
+	offset := report.StartTime
+	if offset.IsZero() {
+		var total time.Duration
+		for _, c := range report.Cases {
+			total += c.Duration
+		}
+		offset = time.Now().Add(-total)
+	}
+
+	for _, c := range report.Cases {
+		start, end := c.StartTime, c.EndTime
+		if start.IsZero() {
+			start = offset
+		}
+		if end.IsZero() || end.Before(start) {
+			end = start.Add(c.Duration)
+		}
+		offset = end
+
+		name := importedTestName(c)
+		protocol := &models.TestProtocol{
+			TestRunID:   w.run.ID,
+			TestName:    name,
+			Quarantined: w.isQuarantined(name, nil),
+			StartedAt:   start,
+			EndedAt:     &end,
+			Entries:     importedEntries(c, start, end),
+			TestResult:  models.TestResultSuccess,
+		}
+		if dev != nil {
+			protocol.DeviceID = &dev.ID
+		}
+		if !c.Passed() {
+			protocol.TestResult = models.TestResultFailed
+			if protocol.Quarantined {
+				quarantinedCount++
+			} else {
+				failedCount++
+			}
+		} else {
+			successCount++
+		}
+
+		if err := w.db.Create(protocol).Error; err != nil {
+			return err
+		}
+		protocol.Device = dev
+		w.run.Protocols = append(w.run.Protocols, *protocol)
+		events.NewTestProtocol.Trigger(events.NewTestProtocolPayload{TestRunID: w.run.ID, Protocol: protocol})
+	}
+
+	w.runFinished(successCount, 0, failedCount, quarantinedCount)
+	return nil
+}
@@ -3,21 +3,47 @@ package protocol
 import (
 	"github.com/fsuhrau/automationhub/device"
 	"github.com/fsuhrau/automationhub/events"
+	"github.com/fsuhrau/automationhub/storage"
 	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"sync"
 	"time"
 )
 
 type logProtocol struct {
-	db     *gorm.DB
-	p      *models.TestProtocol
-	Writer *LogWriter
+	mutex    sync.Mutex
+	db       *gorm.DB
+	p        *models.TestProtocol
+	Writer   *LogWriter
+	lost     bool
+	timedOut bool
 }
 
 func (p *logProtocol) Close() {
+	p.close(nil)
+}
+
+// close ends the protocol once, mark is executed before the result is determined and only if the
+// protocol is still open, a protocol closed by a lost node or a timeout is closed again by its runner
+func (p *logProtocol) close(mark func()) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.p.EndedAt != nil {
+		return
+	}
+	if mark != nil {
+		mark()
+	}
+
 	var state models.TestResultState
 
-	if p.Writer.passed {
+	if p.lost {
+		state = models.TestResultNodeLost
+	} else if p.timedOut {
+		state = models.TestResultTimedOut
+	} else if p.Writer.passed {
 		state = models.TestResultSuccess
 	} else {
 		state = models.TestResultFailed
@@ -31,6 +57,13 @@ func (p *logProtocol) Close() {
 	events.NewTestProtocol.Trigger(events.NewTestProtocolPayload{TestRunID: p.p.TestRunID, Protocol: p.p})
 }
 
+func (p *logProtocol) markTimedOut(reason string) {
+	p.close(func() {
+		p.Writer.Error("testrunner", "timed out: %s", reason)
+		p.timedOut = true
+	})
+}
+
 func (p *logProtocol) Errors() []error {
 	return p.Writer.errs
 }
@@ -40,24 +73,34 @@ func (p *logProtocol) TestProtocolId() *uint {
 }
 
 type ProtocolWriter struct {
-	db        *gorm.DB
-	run       *models.TestRun
-	testName  string
-	protocols []*logProtocol
-	projectId string
-	appId     uint
+	db          *gorm.DB
+	run         *models.TestRun
+	testName    string
+	protocols   []*logProtocol
+	projectId   string
+	appId       uint
+	quarantines []models.TestQuarantine
+	mutex       sync.Mutex
 }
 
 func NewProtocolWriter(db *gorm.DB, projectId string, appId uint, testName string, run *models.TestRun) *ProtocolWriter {
-	return &ProtocolWriter{db: db, projectId: projectId, appId: appId, testName: testName, run: run}
+	quarantines, err := storage.GetActiveQuarantines(db, run.TestID)
+	if err != nil {
+		logrus.Errorf("unable to load quarantined tests of test %d: %v", run.TestID, err)
+	}
+	return &ProtocolWriter{db: db, projectId: projectId, appId: appId, testName: testName, run: run, quarantines: quarantines}
 }
 
 func (w *ProtocolWriter) NewProtocol(dev models.Device, testname string) (*logProtocol, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
 	protocol := &models.TestProtocol{
-		TestRunID: w.run.ID,
-		DeviceID:  &dev.ID,
-		TestName:  testname,
-		StartedAt: time.Now(),
+		TestRunID:   w.run.ID,
+		DeviceID:    &dev.ID,
+		TestName:    testname,
+		StartedAt:   time.Now(),
+		Quarantined: w.isQuarantined(testname, nil),
 	}
 
 	if err := w.db.Create(protocol).Error; err != nil {
@@ -67,7 +110,7 @@ func (w *ProtocolWriter) NewProtocol(dev models.Device, testname string) (*logPr
 
 	writer := NewLogWriter(w.db, protocol.ID, &dev, nil)
 
-	p := &logProtocol{w.db, protocol, writer}
+	p := &logProtocol{db: w.db, p: protocol, Writer: writer}
 	w.protocols = append(w.protocols, p)
 
 	events.NewTestProtocol.Trigger(events.NewTestProtocolPayload{TestRunID: w.run.ID, Protocol: protocol})
@@ -76,6 +119,8 @@ func (w *ProtocolWriter) NewProtocol(dev models.Device, testname string) (*logPr
 }
 
 func (w *ProtocolWriter) NewSubProtocol(testname string, pw device.LogWriter) (*logProtocol, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
 
 	dev := pw.Device().(*models.Device)
 
@@ -85,6 +130,7 @@ func (w *ProtocolWriter) NewSubProtocol(testname string, pw device.LogWriter) (*
 		DeviceID:             &dev.ID,
 		TestName:             testname,
 		StartedAt:            time.Now(),
+		Quarantined:          w.isQuarantined(testname, pw.TestProtocolId()),
 	}
 
 	if err := w.db.Create(protocol).Error; err != nil {
@@ -94,7 +140,7 @@ func (w *ProtocolWriter) NewSubProtocol(testname string, pw device.LogWriter) (*
 
 	writer := NewLogWriter(w.db, protocol.ID, dev, pw)
 
-	p := &logProtocol{w.db, protocol, writer}
+	p := &logProtocol{db: w.db, p: protocol, Writer: writer}
 	w.protocols = append(w.protocols, p)
 
 	events.NewTestProtocol.Trigger(events.NewTestProtocolPayload{TestRunID: w.run.ID, Protocol: protocol})
@@ -112,14 +158,21 @@ func (w *ProtocolWriter) SessionID() string {
 
 func (w *ProtocolWriter) Close() {
 	var (
-		failedCount   int
-		successCount  int
-		unstableCount int
+		failedCount      int
+		successCount     int
+		unstableCount    int
+		quarantinedCount int
 	)
 
-	for i := len(w.protocols) - 1; i > 0; i-- {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for i := len(w.protocols) - 1; i >= 0; i-- {
 		w.protocols[i].Close()
+	}
+	w.quarantineParents()
 
+	for i := len(w.protocols) - 1; i > 0; i-- {
 		switch w.protocols[i].p.TestResult {
 		case models.TestResultOpen:
 			fallthrough
@@ -128,26 +181,101 @@ func (w *ProtocolWriter) Close() {
 		case models.TestResultUnstable:
 			unstableCount++
 		case models.TestResultFailed:
-			failedCount++
+			fallthrough
+		case models.TestResultNodeLost:
+			fallthrough
+		case models.TestResultTimedOut:
+			// failures of quarantined tests are reported separately and don't fail the run
+			if w.protocols[i].p.Quarantined {
+				quarantinedCount++
+			} else {
+				failedCount++
+			}
 		default:
 			panic("unhandled default case")
 		}
 	}
 
+	w.runFinished(successCount, unstableCount, failedCount, quarantinedCount)
+}
+
+func (w *ProtocolWriter) runFinished(successCount, unstableCount, failedCount, quarantinedCount int) {
+	finishedAt := time.Now()
+	w.run.FinishedAt = &finishedAt
+	if err := w.db.Model(w.run).Update("finished_at", &finishedAt).Error; err != nil {
+		logrus.Errorf("unable to finish test run %d: %v", w.run.ID, err)
+	}
+
 	events.TestRunFinished.Trigger(events.TestRunFinishedPayload{
-		TestRunID: w.run.ID,
-		TestRun:   w.run,
-		TestName:  w.testName,
-		ProjectID: w.projectId,
-		AppID:     w.appId,
-		Success:   failedCount == 0 && unstableCount == 0,
-		Succeeded: successCount,
-		Unstable:  unstableCount,
-		Failed:    failedCount,
+		TestRunID:   w.run.ID,
+		TestRun:     w.run,
+		TestName:    w.testName,
+		ProjectID:   w.projectId,
+		AppID:       w.appId,
+		Success:     failedCount == 0 && unstableCount == 0,
+		Succeeded:   successCount,
+		Unstable:    unstableCount,
+		Failed:      failedCount,
+		Quarantined: quarantinedCount,
 	})
 }
 
+// MarkDeviceLost closes all open protocols of the device with the node lost result
+func (w *ProtocolWriter) MarkDeviceLost(deviceID uint, reason string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, p := range w.protocols {
+		if p.p.DeviceID == nil || *p.p.DeviceID != deviceID {
+			continue
+		}
+		p.close(func() {
+			p.Writer.Error("node", "device lost: %s", reason)
+			p.lost = true
+		})
+	}
+}
+
+// MarkDeviceTimedOut closes all open protocols of the device with the timed out result
+func (w *ProtocolWriter) MarkDeviceTimedOut(deviceID uint, reason string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, p := range w.protocols {
+		if p.p.DeviceID == nil || *p.p.DeviceID != deviceID {
+			continue
+		}
+		p.markTimedOut(reason)
+	}
+}
+
+// MarkTimedOut closes all open protocols of the run with the timed out result
+func (w *ProtocolWriter) MarkTimedOut(reason string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, p := range w.protocols {
+		p.markTimedOut(reason)
+	}
+}
+
+func (w *ProtocolWriter) TrackStartupFailure(deviceID uint) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	entry := models.TestRunDeviceStatus{
+		TestRunID:     w.RunID(),
+		DeviceID:      deviceID,
+		StartupFailed: true,
+	}
+	w.db.Create(&entry)
+	w.run.DeviceStatus = append(w.run.DeviceStatus, entry)
+}
+
 func (w *ProtocolWriter) TrackStartupTime(deviceID uint, milliseconds int64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
 	entry := models.TestRunDeviceStatus{
 		TestRunID:   w.RunID(),
 		DeviceID:    deviceID,
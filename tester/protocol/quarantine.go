@@ -0,0 +1,48 @@
+package protocol
+
+func (p *logProtocol) failed() bool {
+	return p.p.TestResult.Failed()
+}
+
+// isQuarantined reports if a protocol of the name belongs to a quarantined test, sub protocols of
+// quarantined protocols are quarantined too
+func (w *ProtocolWriter) isQuarantined(testName string, parentID *uint) bool {
+	for i := range w.quarantines {
+		if w.quarantines[i].Matches(testName) {
+			return true
+		}
+	}
+	if parentID != nil {
+		for _, p := range w.protocols {
+			if p.p.ID == *parentID {
+				return p.p.Quarantined
+			}
+		}
+	}
+	return false
+}
+
+// quarantineParents marks failed protocols as quarantined if all their failed sub protocols are quarantined,
+// e.g. the protocol of a unity batchmode run which only failed because of quarantined tests
+func (w *ProtocolWriter) quarantineParents() {
+	failed := make(map[uint]bool)
+	quarantined := make(map[uint]bool)
+	for _, p := range w.protocols {
+		if p.p.ParentTestProtocolID == nil || !p.failed() {
+			continue
+		}
+		if p.p.Quarantined {
+			quarantined[*p.p.ParentTestProtocolID] = true
+		} else {
+			failed[*p.p.ParentTestProtocolID] = true
+		}
+	}
+
+	for _, p := range w.protocols {
+		if p.p.Quarantined || !p.failed() || !quarantined[p.p.ID] || failed[p.p.ID] {
+			continue
+		}
+		p.p.Quarantined = true
+		w.db.Model(p.p).Update("quarantined", true)
+	}
+}
@@ -58,6 +58,9 @@ func (tr *testsRunner) Cancel(runId string) error {
 func (tr *testsRunner) exec(devs []models.Device, appData *models.AppBinary, startupUrl string) {
 	defer tr.TestSessionFinished()
 
+	stopRunTimeout := tr.WatchRunTimeout(tr.Cancel)
+	defer stopRunTimeout()
+
 	// lock devices
 	devices := tr.LockDevices(devs)
 	if len(devices) == 0 {
@@ -144,7 +147,7 @@ func (tr *testsRunner) exec(devs []models.Device, appData *models.AppBinary, sta
 			close(workers[i])
 		}
 
-	case models.ConcurrentExecutionType:
+	case models.ConcurrentExecutionType, models.ShardedExecutionType:
 
 		parallelWorker := make(workerChannel, len(testList))
 		for _, d := range connectedDevices {
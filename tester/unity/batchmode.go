@@ -0,0 +1,141 @@
+package unity
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/device/unityeditor"
+	"github.com/fsuhrau/automationhub/hub/action"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/fsuhrau/automationhub/tester/base"
+	"github.com/fsuhrau/automationhub/utils/sync"
+	"strings"
+)
+
+// splitBatchmodeDevices separates unity editors running the unity test runner from devices with a connected app
+func splitBatchmodeDevices(devices []base.DeviceMap) ([]base.DeviceMap, []base.DeviceMap) {
+	var batch, apps []base.DeviceMap
+	for _, d := range devices {
+		if d.Device.HasFeature(unityeditor.BatchmodeFeature) {
+			batch = append(batch, d)
+		} else {
+			apps = append(apps, d)
+		}
+	}
+	return batch, apps
+}
+
+func (tr *testsRunner) testPlatform() string {
+	if tr.Config.Unity.PlayMode {
+		return unityeditor.TestPlatformPlayMode
+	}
+	return unityeditor.TestPlatformEditMode
+}
+
+// batchmodeSelection returns the categories and full test names the editors have to run, no names select all tests
+func (tr *testsRunner) batchmodeSelection() ([]string, []string) {
+	if tr.IsRerun() {
+		var names []string
+		for _, name := range tr.RerunTests() {
+			names = append(names, strings.Replace(name, "/", ".", 1))
+		}
+		return nil, names
+	}
+
+	var categories []string
+	switch tr.Config.Unity.UnityTestCategoryType {
+	case models.AllTest:
+		return nil, nil
+	case models.AllOfCategory:
+		for _, c := range strings.Split(tr.Config.Unity.Categories, ",") {
+			if c = strings.TrimSpace(c); len(c) > 0 {
+				categories = append(categories, c)
+			}
+		}
+		return categories, nil
+	}
+
+	var testList []models.UnityTestFunction
+	tr.DB.Where("test_config_unity_id = ?", tr.Config.Unity.ID).Find(&testList)
+	names := make([]string, 0, len(testList))
+	for _, t := range testList {
+		names = append(names, t.Class+"."+t.Method)
+	}
+	return nil, names
+}
+
+// runBatchmode runs the tests with one editor session per device, a concurrent run splits the selected
+// tests between the devices, a run of all tests can't be split and is executed by the first device
+func (tr *testsRunner) runBatchmode(devices []base.DeviceMap) {
+	categories, names := tr.batchmodeSelection()
+	if (tr.Config.Unity.UnityTestCategoryType == models.SelectedTestsOnly || tr.IsRerun()) && len(names) == 0 {
+		tr.LogInfo("No Tests")
+		return
+	}
+
+	selections := make([][]string, len(devices))
+	switch tr.Config.ExecutionType {
+	case models.ConcurrentExecutionType, models.ShardedExecutionType:
+		if len(names) == 0 {
+			devices = devices[:1]
+			break
+		}
+		for i, name := range names {
+			selections[i%len(devices)] = append(selections[i%len(devices)], name)
+		}
+	default:
+		for i := range devices {
+			selections[i] = names
+		}
+	}
+
+	ctx, cancelFunc := context.WithCancel(tr.ctx)
+	defer cancelFunc()
+	group := sync.NewExtendedWaitGroup(ctx)
+
+	for i, d := range devices {
+		if len(names) > 0 && len(selections[i]) == 0 {
+			continue
+		}
+		task := action.TestStart{
+			Method:     strings.Join(selections[i], ";"),
+			Env:        tr.env,
+			Categories: categories,
+			Platform:   tr.testPlatform(),
+		}
+		group.Add(1)
+		go func(dev base.DeviceMap, task action.TestStart) {
+			defer group.Done()
+			deviceCtx, cancel := tr.DeviceContext(ctx, dev)
+			defer cancel()
+			tr.runBatchmodeTests(deviceCtx, dev, task)
+		}(d, task)
+	}
+
+	_ = group.Wait()
+}
+
+func (tr *testsRunner) runBatchmodeTests(ctx context.Context, dev base.DeviceMap, task action.TestStart) {
+	prot, err := tr.ProtocolWriter.NewProtocol(dev.Model, fmt.Sprintf("Unity %s Tests", task.Platform))
+	if err != nil {
+		tr.LogError("Unable to create LogWriter for %s: %v", dev.Device.DeviceID(), err)
+		return
+	}
+	dev.Device.SetLogWriter(prot.Writer)
+	defer func() {
+		dev.Device.SetLogWriter(nil)
+		prot.Close()
+	}()
+
+	tr.LogInfo("Run %s tests on device '%s'", task.Platform, dev.Device.DeviceID())
+	executor := NewExecutor(tr.DeviceManager, tr.ProtocolWriter)
+	if err := executor.Execute(ctx, dev.Device, task, tr.TestTimeout(DefaultTestTimeout)); err != nil {
+		tr.LogError("Test execution failed on device '%s': %v", dev.Device.DeviceID(), err)
+		return
+	}
+
+	if !prot.Writer.HasPassed() {
+		tr.LogError("Test execution failed on device '%s'", dev.Device.DeviceID())
+		return
+	}
+	tr.LogInfo("Test execution finished successful")
+}
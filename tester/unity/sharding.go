@@ -0,0 +1,228 @@
+package unity
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsuhrau/automationhub/hub/action"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/fsuhrau/automationhub/tester/base"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultShardEstimate is used for all tests if none of them has been executed before
+const defaultShardEstimate = time.Minute
+
+type shardTask struct {
+	task     action.TestStart
+	method   string
+	estimate time.Duration
+}
+
+func (t shardTask) name() string {
+	return fmt.Sprintf("%s/%s", t.task.Class, t.method)
+}
+
+type shard struct {
+	queue     []shardTask
+	remaining time.Duration
+}
+
+// shardScheduler distributes the tests over one shard per device, every shard is processed slowest test first.
+// a device which runs out of tests takes over the slowest queued test of the shard with the most remaining work
+type shardScheduler struct {
+	mutex    sync.Mutex
+	cond     *sync.Cond
+	shards   []shard
+	inFlight int
+	canceled bool
+}
+
+// newShardScheduler assigns the tests longest first to the shard with the smallest estimated duration
+func newShardScheduler(tasks []shardTask, shards int) *shardScheduler {
+	sorted := make([]shardTask, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].estimate > sorted[j].estimate
+	})
+
+	s := &shardScheduler{
+		shards: make([]shard, shards),
+	}
+	s.cond = sync.NewCond(&s.mutex)
+	for _, t := range sorted {
+		smallest := 0
+		for i := range s.shards {
+			if s.shards[i].remaining < s.shards[smallest].remaining {
+				smallest = i
+			}
+		}
+		s.shards[smallest].queue = append(s.shards[smallest].queue, t)
+		s.shards[smallest].remaining += t.estimate
+	}
+	return s
+}
+
+func (s *shardScheduler) pop(index int) shardTask {
+	t := s.shards[index].queue[0]
+	s.shards[index].queue = s.shards[index].queue[1:]
+	s.shards[index].remaining -= t.estimate
+	return t
+}
+
+// next returns the next test for the device of the shard and the shard it was taken from,
+// it blocks while other devices are still running tests which could be rescheduled
+func (s *shardScheduler) next(index int) (shardTask, int, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for !s.canceled {
+		from := index
+		if len(s.shards[index].queue) == 0 {
+			from = -1
+			for i := range s.shards {
+				if len(s.shards[i].queue) > 0 && (from < 0 || s.shards[i].remaining > s.shards[from].remaining) {
+					from = i
+				}
+			}
+		}
+		if from >= 0 {
+			s.inFlight++
+			return s.pop(from), from, true
+		}
+		if s.inFlight == 0 {
+			break
+		}
+		s.cond.Wait()
+	}
+	return shardTask{}, -1, false
+}
+
+// done marks a test returned by next as processed
+func (s *shardScheduler) done() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.inFlight--
+	s.cond.Broadcast()
+}
+
+// requeue puts an interrupted test back to the front of the shard so that another device picks it up
+func (s *shardScheduler) requeue(index int, t shardTask) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.inFlight--
+	s.shards[index].queue = append([]shardTask{t}, s.shards[index].queue...)
+	s.shards[index].remaining += t.estimate
+	s.cond.Broadcast()
+}
+
+func (s *shardScheduler) cancel() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.canceled = true
+	s.cond.Broadcast()
+}
+
+// shardTasks estimates the duration of the tests by their previous runs, unknown tests get the average
+// duration of the known ones
+func (tr *testsRunner) shardTasks(testList []models.UnityTestFunction) []shardTask {
+	durations, err := tr.TestDurations()
+	if err != nil {
+		tr.LogError("Unable to load test durations: %v", err)
+	}
+
+	tasks := make([]shardTask, 0, len(testList))
+	var (
+		known   time.Duration
+		count   int
+		unknown []int
+	)
+	for _, t := range testList {
+		task := shardTask{
+			task: action.TestStart{
+				Assembly: t.Assembly,
+				Class:    t.Class,
+				Method:   t.Method,
+				Env:      tr.env,
+			},
+			method: testMethod(t.Method),
+		}
+		if d, ok := durations[task.name()]; ok {
+			task.estimate = d
+			known += d
+			count++
+		} else {
+			unknown = append(unknown, len(tasks))
+		}
+		tasks = append(tasks, task)
+	}
+
+	estimate := defaultShardEstimate
+	if count > 0 {
+		estimate = known / time.Duration(count)
+	}
+	for _, i := range unknown {
+		tasks[i].estimate = estimate
+	}
+	return tasks
+}
+
+// runShards executes the tests balanced by their historical durations on the devices
+func (tr *testsRunner) runShards(ctx context.Context, connectedDevices []base.DeviceMap, testList []models.UnityTestFunction) {
+	scheduler := newShardScheduler(tr.shardTasks(testList), len(connectedDevices))
+	for i, d := range connectedDevices {
+		var names []string
+		for _, t := range scheduler.shards[i].queue {
+			names = append(names, t.name())
+		}
+		tr.LogInfo("Shard %d on device '%s' estimated %s: %s", i, d.Device.DeviceID(), scheduler.shards[i].remaining, strings.Join(names, ", "))
+	}
+
+	go func() {
+		<-ctx.Done()
+		scheduler.cancel()
+	}()
+
+	var workers sync.WaitGroup
+	for i, d := range connectedDevices {
+		workers.Add(1)
+		go func(index int, dev base.DeviceMap) {
+			defer workers.Done()
+			tr.shardWorker(ctx, scheduler, index, dev)
+		}(i, d)
+	}
+	workers.Wait()
+}
+
+// shardWorker executes the tests of the shard on the device, if the node of the device gets lost
+// the interrupted test is put back into the shard so that another device can take it over
+func (tr *testsRunner) shardWorker(ctx context.Context, scheduler *shardScheduler, index int, dev base.DeviceMap) {
+	deviceCtx, cancel := tr.DeviceContext(ctx, dev)
+	defer cancel()
+
+	for {
+		t, from, ok := scheduler.next(index)
+		if !ok {
+			return
+		}
+		if from != index {
+			tr.LogInfo("Rebalance test '%s' estimated %s from shard %d to device '%s'", t.name(), t.estimate, from, dev.Device.DeviceID())
+		}
+		if deviceCtx.Err() == nil {
+			tr.runTest(deviceCtx, dev, t.task, t.method)
+		}
+		if ctx.Err() == nil && tr.IsDeviceLost(dev) {
+			if tr.ActiveDevices() > 0 && tr.RescheduleLostTests() {
+				tr.LogInfo("Reschedule test '%s' of lost device '%s'", t.name(), dev.Device.DeviceID())
+				scheduler.requeue(index, t)
+				return
+			}
+			tr.LogError("Test '%s' aborted device '%s' lost", t.name(), dev.Device.DeviceID())
+			scheduler.done()
+			return
+		}
+		scheduler.done()
+	}
+}
@@ -0,0 +1,58 @@
+package unity
+
+import (
+	"github.com/fsuhrau/automationhub/hub/action"
+	"testing"
+	"time"
+)
+
+func newTask(method string, estimate time.Duration) shardTask {
+	return shardTask{task: action.TestStart{Class: "Tests", Method: method}, method: method, estimate: estimate}
+}
+
+func TestShardScheduler(t *testing.T) {
+	tasks := []shardTask{
+		newTask("A", 1*time.Minute),
+		newTask("B", 5*time.Minute),
+		newTask("C", 2*time.Minute),
+		newTask("D", 4*time.Minute),
+		newTask("E", 3*time.Minute),
+	}
+	s := newShardScheduler(tasks, 2)
+	if s.shards[0].remaining != 8*time.Minute || s.shards[1].remaining != 7*time.Minute {
+		t.Fatalf("unbalanced shards %s %s", s.shards[0].remaining, s.shards[1].remaining)
+	}
+
+	// slowest tests start first
+	first, from, ok := s.next(0)
+	if !ok || from != 0 || first.method != "B" {
+		t.Fatalf("expected B from own shard got %s from %d", first.method, from)
+	}
+
+	// the second device finishes its shard early and takes over the remaining test of the first shard
+	for _, expected := range []string{"D", "E"} {
+		task, _, _ := s.next(1)
+		if task.method != expected {
+			t.Fatalf("expected %s got %s", expected, task.method)
+		}
+		s.done()
+	}
+	task, from, _ := s.next(1)
+	if task.method != "C" || from != 0 {
+		t.Fatalf("expected C rebalanced from shard 0 got %s from %d", task.method, from)
+	}
+
+	// the test interrupted by a lost device is picked up after the own shard is finished
+	s.requeue(1, task)
+	s.done()
+	for _, expected := range []string{"A", "C"} {
+		task, _, _ = s.next(0)
+		if task.method != expected {
+			t.Fatalf("expected %s got %s", expected, task.method)
+		}
+		s.done()
+	}
+	if _, _, ok := s.next(0); ok {
+		t.Fatalf("expected no remaining tests")
+	}
+}
@@ -17,6 +17,7 @@ type testExecutor struct {
 	wg             sync.ExtendedWaitGroup
 	actionHandler  map[action.ActionType]func(device.Device, *action.Response)
 	protocolWriter *protocol.ProtocolWriter
+	timeout        time.Duration
 }
 
 func NewExecutor(devices manager.Devices, protocolWriter *protocol.ProtocolWriter) *testExecutor {
@@ -38,6 +39,7 @@ func NewExecutor(devices manager.Devices, protocolWriter *protocol.ProtocolWrite
 }
 
 func (e *testExecutor) Execute(ctx context.Context, dev device.Device, test action.TestStart, timeout time.Duration) error {
+	e.timeout = timeout
 	dev.AddActionHandler(e)
 	defer func() {
 		dev.RemoveActionHandler(e)
@@ -110,7 +112,7 @@ func (tr *testExecutor) handleTestMethodStart(dev device.Device, response *actio
 		dev.Log("testrunner", fmt.Sprintf("Timeout: %d", response.Payload.TestDetails.Timeout))
 		dev.Log("testrunner", fmt.Sprintf("Categories: %v", response.Payload.TestDetails.Categories))
 
-		timeout := DefaultTestTimeout
+		timeout := tr.timeout
 		if response.Payload.TestDetails.Timeout > 0 {
 			timeout = time.Duration(response.Payload.TestDetails.Timeout) * time.Millisecond
 		}
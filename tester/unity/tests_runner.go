@@ -37,6 +37,7 @@ type testsRunner struct {
 	appParams app.Parameter
 	projectId string
 	appId     uint
+	timeouts  map[string]time.Duration
 
 	ctx        context.Context
 	cancelFunc context.CancelFunc
@@ -73,6 +74,7 @@ func (tr *testsRunner) exec(devs []models.Device, appData *models.AppBinary, sta
 	devices := tr.LockDevices(devs)
 	if len(devices) == 0 {
 		tr.LogError("no lockable devices available")
+		tr.TestSessionFinished()
 		return
 	}
 
@@ -84,6 +86,9 @@ func (tr *testsRunner) exec(devs []models.Device, appData *models.AppBinary, sta
 		tr.TestSessionFinished()
 	}()
 
+	stopRunTimeout := tr.WatchRunTimeout(tr.Cancel)
+	defer stopRunTimeout()
+
 	tr.LogInfo("Starting devices")
 	if err := tr.StartDevices(tr.ctx, devices); err != nil {
 		tr.LogError("unable to start devices")
@@ -113,21 +118,29 @@ func (tr *testsRunner) exec(devs []models.Device, appData *models.AppBinary, sta
 		return
 	}
 
-	tr.LogInfo("Get test list")
-	testList, err := tr.getTestList(connectedDevices)
-	if err != nil {
-		tr.LogError("Get tests failed: %v", err)
-	} else {
-		if len(testList) == 0 {
-			tr.LogInfo("No Tests")
-		}
-		tr.LogInfo("Execute Tests")
-		tr.scheduleTests(connectedDevices, testList)
+	batchDevices, appDevices := splitBatchmodeDevices(connectedDevices)
+	if len(batchDevices) > 0 {
+		tr.LogInfo("Execute Tests in batchmode")
+		tr.runBatchmode(batchDevices)
+	}
 
-		tr.LogInfo("Stop Worker")
-		tr.cancelFunc()
+	if len(appDevices) > 0 {
+		tr.LogInfo("Get test list")
+		testList, err := tr.getTestList(appDevices)
+		if err != nil {
+			tr.LogError("Get tests failed: %v", err)
+		} else {
+			if len(testList) == 0 {
+				tr.LogInfo("No Tests")
+			}
+			tr.LogInfo("Execute Tests")
+			tr.scheduleTests(appDevices, testList)
+		}
 	}
 
+	tr.LogInfo("Stop Worker")
+	tr.cancelFunc()
+
 	tr.LogInfo("Stop apps")
 	for _, d := range connectedDevices {
 		if err := d.Device.StopApp(&tr.appParams); err != nil {
@@ -142,6 +155,13 @@ func (tr *testsRunner) scheduleTests(connectedDevices []base.DeviceMap, testList
 
 	defer cancelFunc()
 
+	tr.timeouts = make(map[string]time.Duration)
+	for _, t := range testList {
+		if t.Timeout > 0 {
+			tr.timeouts[testKey(t.Assembly, t.Class, t.Method)] = time.Duration(t.Timeout) * time.Millisecond
+		}
+	}
+
 	switch tr.Config.ExecutionType {
 	case models.SimultaneouslyExecutionType:
 
@@ -158,7 +178,7 @@ func (tr *testsRunner) scheduleTests(connectedDevices []base.DeviceMap, testList
 		for _, d := range connectedDevices {
 			channel := make(workerChannel, len(testList))
 			workers = append(workers, channel)
-			go tr.workerFunction(ctx, channel, d, group)
+			go tr.workerFunction(ctx, channel, nil, d, group)
 		}
 
 		for _, t := range testList {
@@ -187,7 +207,7 @@ func (tr *testsRunner) scheduleTests(connectedDevices []base.DeviceMap, testList
 			}
 		}()
 		for _, d := range connectedDevices {
-			go tr.workerFunction(ctx, parallelWorker, d, group)
+			go tr.workerFunction(ctx, parallelWorker, parallelWorker, d, group)
 		}
 		for _, t := range testList {
 			a := action.TestStart{
@@ -203,9 +223,33 @@ func (tr *testsRunner) scheduleTests(connectedDevices []base.DeviceMap, testList
 		_ = group.Wait()
 
 		return
+
+	case models.ShardedExecutionType:
+		tr.runShards(ctx, connectedDevices, testList)
 	}
 }
 
+func testKey(assembly, class, method string) string {
+	return fmt.Sprintf("%s/%s/%s", assembly, class, method)
+}
+
+// testTimeout returns the timeout of the [Timeout] attribute of the test, otherwise the configured or default one
+func (tr *testsRunner) testTimeout(task action.TestStart) time.Duration {
+	if timeout, ok := tr.timeouts[testKey(task.Assembly, task.Class, task.Method)]; ok {
+		return timeout
+	}
+	return tr.TestTimeout(DefaultTestTimeout)
+}
+
+// testMethod returns the method name of a test which might be prefixed with its return type
+func testMethod(method string) string {
+	methodParts := strings.Split(method, " ")
+	if len(methodParts) > 1 {
+		return methodParts[1]
+	}
+	return method
+}
+
 func (tr *testsRunner) getTestList(connectedDevices []base.DeviceMap) ([]models.UnityTestFunction, error) {
 	var testList []models.UnityTestFunction
 	if tr.Config.Unity.UnityTestCategoryType == models.AllTest || tr.Config.Unity.UnityTestCategoryType == models.AllOfCategory {
@@ -234,12 +278,24 @@ func (tr *testsRunner) getTestList(connectedDevices []base.DeviceMap) ([]models.
 					Assembly: t.Assembly,
 					Class:    t.Class,
 					Method:   t.Method,
+					Timeout:  t.Timeout,
 				})
 			}
 		}
 	} else {
 		tr.DB.Where("test_config_unity_id = ?", tr.Config.Unity.ID).Find(&testList)
 	}
+
+	if tr.IsRerun() {
+		var rerunList []models.UnityTestFunction
+		for _, t := range testList {
+			if tr.IsSelected(fmt.Sprintf("%s/%s", t.Class, testMethod(t.Method))) {
+				rerunList = append(rerunList, t)
+			}
+		}
+		tr.LogInfo("Rerun %d of %d tests", len(rerunList), len(testList))
+		testList = rerunList
+	}
 	return testList, nil
 }
 
@@ -262,19 +318,36 @@ func (tr *testsRunner) Run(devs []models.Device, binary *models.AppBinary, start
 	return &tr.TestRun, nil
 }
 
-func (tr *testsRunner) workerFunction(ctx context.Context, channel workerChannel, dev base.DeviceMap, group sync.ExtendedWaitGroup) {
+// workerFunction executes the tests of the channel on the device, if the node of the device gets lost
+// the interrupted test is put back to the reschedule channel so that another device can pick it up
+func (tr *testsRunner) workerFunction(ctx context.Context, channel workerChannel, reschedule workerChannel, dev base.DeviceMap, group sync.ExtendedWaitGroup) {
+	deviceCtx, cancel := tr.DeviceContext(ctx, dev)
+	defer cancel()
+
 	for {
 		select {
 		case task, ok := <-channel:
 			if !ok {
 				return
 			}
-			method := task.Method
-			methodParts := strings.Split(method, " ")
-			if len(methodParts) > 1 {
-				method = methodParts[1]
+			method := testMethod(task.Method)
+			if deviceCtx.Err() == nil {
+				tr.runTest(deviceCtx, dev, task, method)
+			}
+			if ctx.Err() == nil && tr.IsDeviceLost(dev) {
+				// the remaining devices of a concurrent run keep processing the shared channel
+				if reschedule != nil && tr.ActiveDevices() > 0 {
+					if tr.RescheduleLostTests() {
+						tr.LogInfo("Reschedule test '%s/%s' of lost device '%s'", task.Class, method, dev.Device.DeviceID())
+						reschedule <- task
+						return
+					}
+					tr.LogError("Test '%s/%s' aborted device '%s' lost", task.Class, method, dev.Device.DeviceID())
+					group.Done()
+					return
+				}
+				tr.LogError("Test '%s/%s' aborted device '%s' lost", task.Class, method, dev.Device.DeviceID())
 			}
-			tr.runTest(ctx, dev, task, method)
 			if !group.IsCanceled() {
 				group.Done()
 			} else {
@@ -306,8 +379,12 @@ func (tr *testsRunner) runTest(ctx context.Context, dev base.DeviceMap, task act
 		}
 	*/
 	tr.LogInfo("Run test '%s/%s' on device '%s'", task.Class, method, dev.Device.DeviceID())
+	timeout := tr.testTimeout(task)
 	executor := NewExecutor(tr.DeviceManager, tr.ProtocolWriter)
-	err = executor.Execute(ctx, dev.Device, task, DefaultTestTimeout)
+	err = executor.Execute(ctx, dev.Device, task, timeout)
+	if errors.Is(err, sync.TimeoutError) {
+		tr.ProtocolWriter.MarkDeviceTimedOut(dev.Model.ID, fmt.Sprintf("test exceeded the timeout of %s", timeout))
+	}
 
 	passed := prot.Writer.HasPassed()
 	finished := "finished successful"
@@ -0,0 +1,129 @@
+package unity
+
+import (
+	"context"
+	"github.com/fsuhrau/automationhub/config"
+	"github.com/fsuhrau/automationhub/device"
+	"github.com/fsuhrau/automationhub/device/fake"
+	"github.com/fsuhrau/automationhub/hub"
+	"github.com/fsuhrau/automationhub/storage/migrations"
+	"github.com/fsuhrau/automationhub/storage/models"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// deviceStore keeps the fake devices out of the database, the test creates the device models itself
+type deviceStore struct{}
+
+func (deviceStore) GetDevices(manager string) (models.Devices, error) {
+	return nil, nil
+}
+
+func (deviceStore) NewDevice(manager string, dev models.Device) error {
+	return nil
+}
+
+func (deviceStore) GetDevice(manager, deviceId string) (*models.Device, error) {
+	return nil, nil
+}
+
+func (deviceStore) Update(manager string, dev device.Device) error {
+	return nil
+}
+
+func TestRunAgainstFakeDevices(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "hub.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("unable to open database: %v", err)
+	}
+	if err := migrations.InitSchema(db); err != nil {
+		t.Fatalf("unable to migrate database: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dm := hub.NewDeviceManager(logrus.New(), config.Service{Identifier: "test"}, db, deviceStore{})
+	dm.AddHandler(fake.NewHandler(config.Manager{Fake: &config.Fake{
+		Devices: 2,
+		Tests: []config.FakeTest{
+			{Assembly: "Fake.Tests", Class: "Tests", Method: "Passing", Categories: []string{"smoke"}, Duration: 10 * time.Millisecond},
+			{Assembly: "Fake.Tests", Class: "Tests", Method: "Failing", Categories: []string{"smoke"}, Duration: 10 * time.Millisecond, Result: fake.ResultFailed},
+			{Assembly: "Fake.Tests", Class: "Tests", Method: "Other", Categories: []string{"gameplay"}, Duration: 10 * time.Millisecond},
+		},
+	}}, deviceStore{}))
+	if err := dm.Run(ctx, false); err != nil {
+		t.Fatalf("unable to run device manager: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := dm.RegisterRoutes(router, router.Group("/")); err != nil {
+		t.Fatalf("unable to register routes: %v", err)
+	}
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	test := models.Test{Name: "fake", TestConfig: models.TestConfig{
+		Type:          models.TestTypeUnity,
+		ExecutionType: models.ConcurrentExecutionType,
+		Unity:         &models.TestConfigUnity{UnityTestCategoryType: models.AllOfCategory, Categories: "smoke"},
+	}}
+	db.Create(&test)
+
+	var devs []models.Device
+	for _, id := range []string{"fake-test-0", "fake-test-1"} {
+		dev, _ := dm.GetDevice(id)
+		if dev == nil {
+			t.Fatalf("fake device %s not found", id)
+		}
+		model := models.Device{DeviceIdentifier: id, Manager: fake.Manager}
+		db.Create(&model)
+		model.Dev = dev
+		devs = append(devs, model)
+	}
+
+	runner := New(db, server.URL, dm, nil, "project", 0)
+	if err := runner.Initialize(test, nil); err != nil {
+		t.Fatalf("unable to initialize runner: %v", err)
+	}
+	run, err := runner.Run(devs, nil, "")
+	if err != nil {
+		t.Fatalf("unable to run tests: %v", err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		var finished models.TestRun
+		db.First(&finished, run.ID)
+		if finished.FinishedAt != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("test run didn't finish")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	var protocols []models.TestProtocol
+	db.Where("test_run_id = ? and parent_test_protocol_id is null", run.ID).Find(&protocols)
+	results := make(map[string]models.TestResultState)
+	for _, p := range protocols {
+		results[p.TestName] = p.TestResult
+	}
+	if len(results) != 2 || results["Tests/Passing"] != models.TestResultSuccess || results["Tests/Failing"] != models.TestResultFailed {
+		t.Errorf("unexpected test results %v", results)
+	}
+
+	for _, d := range devs {
+		if d.Dev.(device.Device).IsLocked() {
+			t.Errorf("expected device %s to be unlocked after the run", d.DeviceIdentifier)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"context"
 	"github.com/sirupsen/logrus"
 	"os/exec"
 	"strings"
@@ -15,6 +16,17 @@ func (w *CommandLogWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// NewCommandContext creates a command which gets killed when the context is done
+func NewCommandContext(ctx context.Context, executable string, params ...string) *exec.Cmd {
+	writer := &CommandLogWriter{
+		Tag: executable + " " + strings.Join(params, " "),
+	}
+	logrus.Debugf("New Command: %s %v", executable, params)
+	cmd := exec.CommandContext(ctx, executable, params...)
+	cmd.Stderr = writer
+	return cmd
+}
+
 func NewCommand(executable string, params ...string) *exec.Cmd {
 	writer := &CommandLogWriter{
 		Tag: executable + " " + strings.Join(params, " "),
@@ -0,0 +1,189 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const StopTimeout = 10 * time.Second
+
+// Process is an executable started by a Shell, the shell prints its pid before it execs the binary
+// so the process tree can be observed and stopped on remote hosts and in containers as well
+type Process struct {
+	sh   Shell
+	cmd  *exec.Cmd
+	pid  int
+	done chan struct{}
+	err  error
+}
+
+// Start launches the executable in dir with the additional environment and forwards its output line by line
+func Start(sh Shell, dir string, env map[string]string, executable string, args []string, stdout, stderr func(string)) (*Process, error) {
+	var script strings.Builder
+	script.WriteString("echo $$; ")
+	if len(dir) > 0 {
+		script.WriteString("cd " + Quote(dir) + " && ")
+	}
+	script.WriteString("exec env")
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		script.WriteString(" " + Quote(key+"="+env[key]))
+	}
+	script.WriteString(" " + Quote(executable))
+	for _, arg := range args {
+		script.WriteString(" " + Quote(arg))
+	}
+
+	p := &Process{sh: sh, cmd: sh.Command(script.String()), done: make(chan struct{})}
+	outPipe, err := p.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	errPipe, err := p.cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	out := bufio.NewReader(outPipe)
+	line, err := out.ReadString('\n')
+	if err == nil {
+		p.pid, err = strconv.Atoi(strings.TrimSpace(line))
+	}
+	if err != nil {
+		p.cmd.Process.Kill()
+		p.cmd.Wait()
+		return nil, fmt.Errorf("%s: unable to start %s: %v", sh, executable, err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		forwardLines(out, stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		forwardLines(errPipe, stderr)
+	}()
+	go func() {
+		wg.Wait()
+		p.err = p.cmd.Wait()
+		close(p.done)
+	}()
+
+	return p, nil
+}
+
+func forwardLines(r io.Reader, handler func(string)) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if handler != nil {
+			handler(scanner.Text())
+		}
+	}
+}
+
+// Pid is the process id on the host running the process
+func (p *Process) Pid() int {
+	return p.pid
+}
+
+// Done is closed when the process exited
+func (p *Process) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err returns the exit error once the process is done
+func (p *Process) Err() error {
+	return p.err
+}
+
+func (p *Process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *Process) signal(sig string) error {
+	_, err := Run(p.sh, fmt.Sprintf("killtree() { for c in $(pgrep -P $1); do killtree $c; done; kill -%s $1 2>/dev/null; }; killtree %d; true", sig, p.pid))
+	return err
+}
+
+// Stop terminates the process tree and kills it if it does not exit within the StopTimeout
+func (p *Process) Stop() error {
+	if p.exited() {
+		return nil
+	}
+	if err := p.signal("TERM"); err != nil {
+		return err
+	}
+	select {
+	case <-p.done:
+		return nil
+	case <-time.After(StopTimeout):
+	}
+	p.signal("KILL")
+	p.cmd.Process.Kill()
+	<-p.done
+	return nil
+}
+
+// Usage sums the cpu in percent and the resident memory in MB of the process tree
+func (p *Process) Usage() (float64, float64, error) {
+	out, err := Run(p.sh, "ps -eo pid=,ppid=,pcpu=,rss=")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	type entry struct {
+		cpu float64
+		rss float64
+	}
+	entries := make(map[int]entry)
+	children := make(map[int][]int)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			continue
+		}
+		pid, _ := strconv.Atoi(fields[0])
+		ppid, _ := strconv.Atoi(fields[1])
+		cpu, _ := strconv.ParseFloat(fields[2], 64)
+		rss, _ := strconv.ParseFloat(fields[3], 64)
+		entries[pid] = entry{cpu: cpu, rss: rss}
+		children[ppid] = append(children[ppid], pid)
+	}
+
+	if _, ok := entries[p.pid]; !ok {
+		return 0, 0, fmt.Errorf("process %d not running", p.pid)
+	}
+
+	var cpu, rss float64
+	queue := []int{p.pid}
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		cpu += entries[pid].cpu
+		rss += entries[pid].rss
+		queue = append(queue, children[pid]...)
+	}
+	return cpu, rss / 1024, nil
+}
@@ -0,0 +1,68 @@
+package shell
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Shell executes scripts with a posix shell, locally, on a remote host or inside a container
+type Shell interface {
+	fmt.Stringer
+	Command(script string) *exec.Cmd
+}
+
+// Local runs scripts on this machine
+type Local struct{}
+
+func (Local) String() string {
+	return "localhost"
+}
+
+func (Local) Command(script string) *exec.Cmd {
+	return exec.Command("sh", "-c", script)
+}
+
+// SSH runs scripts on a remote host with key based authentication
+type SSH struct {
+	Address string
+}
+
+func (s SSH) String() string {
+	return s.Address
+}
+
+func (s SSH) Command(script string) *exec.Cmd {
+	return exec.Command("ssh", "-T", "-o", "BatchMode=yes", s.Address, script)
+}
+
+// Run executes the script and returns its output
+func Run(sh Shell, script string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := sh.Command(script)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %v %s", sh, err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// Test executes the script and reports an exit code of 1 as false
+func Test(sh Shell, script string) (bool, error) {
+	if err := sh.Command(script).Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s: %v", sh, err)
+	}
+	return true, nil
+}
+
+// Quote escapes s for the use as a single shell word
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,127 @@
+package testresults
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitCase struct {
+	Name      string          `xml:"name,attr"`
+	ClassName string          `xml:"classname,attr"`
+	Time      string          `xml:"time,attr"`
+	Failures  []junitMessage  `xml:"failure"`
+	Errors    []junitMessage  `xml:"error"`
+	Skipped   *junitMessage   `xml:"skipped"`
+	Output    string          `xml:"system-out"`
+	ErrOutput string          `xml:"system-err"`
+	Props     []nunitProperty `xml:"properties>property"`
+}
+
+type junitSuite struct {
+	Name      string       `xml:"name,attr"`
+	Timestamp string       `xml:"timestamp,attr"`
+	Time      string       `xml:"time,attr"`
+	Suites    []junitSuite `xml:"testsuite"`
+	Cases     []junitCase  `xml:"testcase"`
+}
+
+// collect flattens the suite, test cases without own timestamps are placed one after the other
+// starting at the timestamp of their suite
+func (s *junitSuite) collect(parentStart time.Time, report *Report) {
+	start := parseTime(s.Timestamp)
+	if start.IsZero() {
+		start = parentStart
+	}
+	if !start.IsZero() && (report.StartTime.IsZero() || start.Before(report.StartTime)) {
+		report.StartTime = start
+	}
+
+	offset := start
+	for _, c := range s.Cases {
+		tc := TestCase{
+			Name:       c.Name,
+			FullName:   c.Name,
+			ClassName:  c.ClassName,
+			MethodName: c.Name,
+			Result:     ResultPassed,
+			Categories: categories(c.Props),
+			Duration:   parseSeconds(c.Time),
+			Output:     strings.TrimSpace(strings.Join([]string{strings.TrimSpace(c.Output), strings.TrimSpace(c.ErrOutput)}, "\n")),
+		}
+		if len(tc.ClassName) == 0 {
+			tc.ClassName = s.Name
+		}
+		if len(tc.ClassName) > 0 {
+			tc.FullName = tc.ClassName + "." + c.Name
+		}
+		if !offset.IsZero() {
+			tc.StartTime = offset
+			tc.EndTime = offset.Add(tc.Duration)
+			offset = tc.EndTime
+		}
+
+		problems := append(append([]junitMessage{}, c.Failures...), c.Errors...)
+		switch {
+		case len(problems) > 0:
+			tc.Result = ResultFailed
+			tc.Message = strings.TrimSpace(problems[0].Message)
+			if len(tc.Message) == 0 {
+				tc.Message = strings.TrimSpace(problems[0].Type)
+			}
+			tc.StackTrace = strings.TrimSpace(problems[0].Text)
+		case c.Skipped != nil:
+			tc.Result = ResultSkipped
+			tc.Message = strings.TrimSpace(c.Skipped.Message)
+		}
+		report.Cases = append(report.Cases, tc)
+		report.Duration += tc.Duration
+	}
+
+	for i := range s.Suites {
+		s.Suites[i].collect(start, report)
+	}
+}
+
+// ParseJUnit reads a JUnit result file with either a testsuites or a single testsuite root
+func ParseJUnit(r io.Reader) (*Report, error) {
+	var root struct {
+		XMLName xml.Name
+		junitSuite
+	}
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid junit results: %v", err)
+	}
+
+	report := &Report{}
+	switch root.XMLName.Local {
+	case "testsuites":
+		for i := range root.Suites {
+			root.Suites[i].collect(time.Time{}, report)
+		}
+	case "testsuite":
+		root.junitSuite.collect(time.Time{}, report)
+	default:
+		return nil, fmt.Errorf("invalid junit results: unexpected root element %s", root.XMLName.Local)
+	}
+
+	if total := parseSeconds(root.Time); total > 0 {
+		report.Duration = total
+	}
+	report.Result = ResultPassed
+	if !report.Passed() {
+		report.Result = ResultFailed
+	}
+	if !report.StartTime.IsZero() {
+		report.EndTime = report.StartTime.Add(report.Duration)
+	}
+	return report, nil
+}
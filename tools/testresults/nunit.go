@@ -0,0 +1,215 @@
+package testresults
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ResultPassed       = "Passed"
+	ResultFailed       = "Failed"
+	ResultSkipped      = "Skipped"
+	ResultInconclusive = "Inconclusive"
+)
+
+var timeLayouts = []string{
+	"2006-01-02 15:04:05Z",
+	"2006-01-02 15:04:05.0000000Z",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+}
+
+// TestCase is a single test of a result file
+type TestCase struct {
+	Name       string
+	FullName   string
+	ClassName  string
+	MethodName string
+	Result     string
+	Label      string
+	Categories []string
+	StartTime  time.Time
+	EndTime    time.Time
+	Duration   time.Duration
+	Output     string
+	Message    string
+	StackTrace string
+}
+
+// Passed reports if the test didn't fail, skipped tests don't fail a run
+func (c TestCase) Passed() bool {
+	return c.Result != ResultFailed
+}
+
+// Report is the flattened content of a result file
+type Report struct {
+	Result    string
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	Cases     []TestCase
+}
+
+// Passed reports if none of the test cases failed
+func (r *Report) Passed() bool {
+	for _, c := range r.Cases {
+		if !c.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of test cases with the given result
+func (r *Report) Count(result string) int {
+	count := 0
+	for _, c := range r.Cases {
+		if c.Result == result {
+			count++
+		}
+	}
+	return count
+}
+
+type nunitMessage struct {
+	Message    string `xml:"message"`
+	StackTrace string `xml:"stack-trace"`
+}
+
+type nunitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type nunitCase struct {
+	Name       string          `xml:"name,attr"`
+	FullName   string          `xml:"fullname,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	MethodName string          `xml:"methodname,attr"`
+	Result     string          `xml:"result,attr"`
+	Label      string          `xml:"label,attr"`
+	StartTime  string          `xml:"start-time,attr"`
+	EndTime    string          `xml:"end-time,attr"`
+	Duration   string          `xml:"duration,attr"`
+	Properties []nunitProperty `xml:"properties>property"`
+	Output     string          `xml:"output"`
+	Failure    *nunitMessage   `xml:"failure"`
+	Reason     *nunitMessage   `xml:"reason"`
+}
+
+type nunitSuite struct {
+	Type       string          `xml:"type,attr"`
+	Name       string          `xml:"name,attr"`
+	FullName   string          `xml:"fullname,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Properties []nunitProperty `xml:"properties>property"`
+	Suites     []nunitSuite    `xml:"test-suite"`
+	Cases      []nunitCase     `xml:"test-case"`
+}
+
+type nunitRun struct {
+	XMLName   xml.Name     `xml:"test-run"`
+	Result    string       `xml:"result,attr"`
+	StartTime string       `xml:"start-time,attr"`
+	EndTime   string       `xml:"end-time,attr"`
+	Duration  string       `xml:"duration,attr"`
+	Suites    []nunitSuite `xml:"test-suite"`
+}
+
+func parseTime(value string) time.Time {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseSeconds parses durations like 0.0123 given in seconds
+func parseSeconds(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func categories(properties []nunitProperty) []string {
+	var result []string
+	for _, p := range properties {
+		if p.Name == "Category" {
+			result = append(result, p.Value)
+		}
+	}
+	return result
+}
+
+func (s *nunitSuite) collect(inherited []string, cases []TestCase) []TestCase {
+	cats := append(append([]string{}, inherited...), categories(s.Properties)...)
+	for _, c := range s.Cases {
+		tc := TestCase{
+			Name:       c.Name,
+			FullName:   c.FullName,
+			ClassName:  c.ClassName,
+			MethodName: c.MethodName,
+			Result:     c.Result,
+			Label:      c.Label,
+			Categories: append(append([]string{}, cats...), categories(c.Properties)...),
+			StartTime:  parseTime(c.StartTime),
+			EndTime:    parseTime(c.EndTime),
+			Duration:   parseSeconds(c.Duration),
+			Output:     strings.TrimSpace(c.Output),
+		}
+		if len(tc.ClassName) == 0 {
+			tc.ClassName = s.ClassName
+		}
+		if len(tc.MethodName) == 0 {
+			tc.MethodName = c.Name
+		}
+		if c.Failure != nil {
+			tc.Message = strings.TrimSpace(c.Failure.Message)
+			tc.StackTrace = strings.TrimSpace(c.Failure.StackTrace)
+		} else if c.Reason != nil {
+			tc.Message = strings.TrimSpace(c.Reason.Message)
+		}
+		cases = append(cases, tc)
+	}
+	for i := range s.Suites {
+		cases = s.Suites[i].collect(cats, cases)
+	}
+	return cases
+}
+
+// ParseNUnit reads a NUnit 3 result file as written by the unity test runner
+func ParseNUnit(r io.Reader) (*Report, error) {
+	var run nunitRun
+	if err := xml.NewDecoder(r).Decode(&run); err != nil {
+		return nil, fmt.Errorf("invalid nunit results: %v", err)
+	}
+
+	report := &Report{
+		Result:    run.Result,
+		StartTime: parseTime(run.StartTime),
+		EndTime:   parseTime(run.EndTime),
+		Duration:  parseSeconds(run.Duration),
+	}
+	for i := range run.Suites {
+		report.Cases = run.Suites[i].collect(nil, report.Cases)
+	}
+	return report, nil
+}
+
+// ParseNUnitFile reads a NUnit 3 result file from disk
+func ParseNUnitFile(path string) (*Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseNUnit(file)
+}
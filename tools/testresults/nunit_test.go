@@ -0,0 +1,70 @@
+package testresults
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNUnit(t *testing.T) {
+	results := `<?xml version="1.0" encoding="utf-8"?>
+<test-run id="2" testcasecount="3" result="Failed(Child)" total="3" passed="1" failed="1" skipped="1" start-time="2024-03-01 10:00:00Z" end-time="2024-03-01 10:00:05Z" duration="5.25">
+  <test-suite type="TestSuite" name="Game" fullname="Game">
+    <test-suite type="Assembly" name="Game.Tests.dll" fullname="Game.Tests.dll">
+      <test-suite type="TestFixture" name="PlayerTests" fullname="Game.PlayerTests" classname="Game.PlayerTests">
+        <properties><property name="Category" value="smoke" /></properties>
+        <test-case name="Spawns" fullname="Game.PlayerTests.Spawns" methodname="Spawns" classname="Game.PlayerTests" result="Passed" start-time="2024-03-01 10:00:01Z" end-time="2024-03-01 10:00:02Z" duration="1.5">
+          <output><![CDATA[spawned player]]></output>
+        </test-case>
+        <test-case name="Dies" fullname="Game.PlayerTests.Dies" methodname="Dies" classname="Game.PlayerTests" result="Failed" duration="0.25">
+          <properties><property name="Category" value="combat" /></properties>
+          <failure>
+            <message><![CDATA[Expected: 0 But was: 10]]></message>
+            <stack-trace><![CDATA[at Game.PlayerTests.Dies () [0x00001] in PlayerTests.cs:20]]></stack-trace>
+          </failure>
+        </test-case>
+        <test-case name="Respawns" fullname="Game.PlayerTests.Respawns" methodname="Respawns" classname="Game.PlayerTests" result="Skipped" label="Ignored">
+          <reason><message><![CDATA[not implemented]]></message></reason>
+        </test-case>
+      </test-suite>
+    </test-suite>
+  </test-suite>
+</test-run>`
+
+	report, err := ParseNUnit(strings.NewReader(results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cases) != 3 {
+		t.Fatalf("cases mismatch expected 3 got %d", len(report.Cases))
+	}
+	if report.Passed() {
+		t.Errorf("report with failed test case passed")
+	}
+	if report.Duration != 5250*time.Millisecond {
+		t.Errorf("duration mismatch expected 5.25s got %s", report.Duration)
+	}
+	if report.Count(ResultSkipped) != 1 {
+		t.Errorf("skipped mismatch expected 1 got %d", report.Count(ResultSkipped))
+	}
+
+	spawns := report.Cases[0]
+	if spawns.ClassName != "Game.PlayerTests" || spawns.MethodName != "Spawns" || spawns.Output != "spawned player" || !spawns.Passed() {
+		t.Errorf("unexpected test case %+v", spawns)
+	}
+	if spawns.EndTime.Sub(spawns.StartTime) != time.Second {
+		t.Errorf("unexpected start and end time %s %s", spawns.StartTime, spawns.EndTime)
+	}
+
+	dies := report.Cases[1]
+	if dies.Passed() || dies.Message != "Expected: 0 But was: 10" || !strings.HasPrefix(dies.StackTrace, "at Game.PlayerTests.Dies") {
+		t.Errorf("unexpected test case %+v", dies)
+	}
+	if strings.Join(dies.Categories, ",") != "smoke,combat" {
+		t.Errorf("categories mismatch expected smoke,combat got %v", dies.Categories)
+	}
+
+	if respawns := report.Cases[2]; !respawns.Passed() || respawns.Message != "not implemented" {
+		t.Errorf("unexpected test case %+v", respawns)
+	}
+}
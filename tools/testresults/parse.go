@@ -0,0 +1,55 @@
+package testresults
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+const (
+	FormatNUnit = "nunit"
+	FormatXUnit = "xunit"
+	FormatJUnit = "junit"
+)
+
+// DetectFormat determines the format of a result file by its root element
+func DetectFormat(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("unable to detect result format: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			switch start.Name.Local {
+			case "test-run":
+				return FormatNUnit, nil
+			case "assemblies", "assembly":
+				return FormatXUnit, nil
+			case "testsuites", "testsuite":
+				return FormatJUnit, nil
+			}
+			return "", fmt.Errorf("unknown result format with root element %s", start.Name.Local)
+		}
+	}
+}
+
+// Parse reads a result file of the given format, an empty format is detected from the content
+func Parse(format string, data []byte) (*Report, error) {
+	if len(format) == 0 {
+		var err error
+		if format, err = DetectFormat(data); err != nil {
+			return nil, err
+		}
+	}
+
+	switch format {
+	case FormatNUnit:
+		return ParseNUnit(bytes.NewReader(data))
+	case FormatXUnit:
+		return ParseXUnit(bytes.NewReader(data))
+	case FormatJUnit:
+		return ParseJUnit(bytes.NewReader(data))
+	}
+	return nil, fmt.Errorf("unsupported result format %s", format)
+}
@@ -0,0 +1,80 @@
+package testresults
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseJUnit(t *testing.T) {
+	results := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites time="0.75">
+  <testsuite name="github.com/game/server" tests="3" failures="1" skipped="1" time="0.75" timestamp="2024-03-01T10:00:00">
+    <testcase name="TestLogin" classname="github.com/game/server" time="0.5">
+      <system-out>connected</system-out>
+    </testcase>
+    <testcase name="TestLogout" classname="github.com/game/server" time="0.25">
+      <failure message="logout failed" type="">server_test.go:42: expected 200 got 500</failure>
+    </testcase>
+    <testcase name="TestBan" classname="github.com/game/server" time="0">
+      <skipped message="flaky"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+	format, err := DetectFormat([]byte(results))
+	if err != nil || format != FormatJUnit {
+		t.Fatalf("format mismatch expected junit got %s: %v", format, err)
+	}
+	report, err := Parse("", []byte(results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cases) != 3 || report.Passed() || report.Count(ResultSkipped) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	logout := report.Cases[1]
+	if logout.Message != "logout failed" || logout.StackTrace != "server_test.go:42: expected 200 got 500" {
+		t.Errorf("unexpected test case %+v", logout)
+	}
+	if !logout.StartTime.Equal(time.Date(2024, 3, 1, 10, 0, 0, 500*int(time.Millisecond), time.UTC)) {
+		t.Errorf("start time mismatch got %s", logout.StartTime)
+	}
+	if report.Cases[0].Output != "connected" {
+		t.Errorf("output mismatch got %s", report.Cases[0].Output)
+	}
+}
+
+func TestParseXUnit(t *testing.T) {
+	results := `<?xml version="1.0" encoding="utf-8"?>
+<assemblies timestamp="03/01/2024 10:00:00">
+  <assembly name="Game.Tests.dll" run-date="2024-03-01" run-time="10:00:00" time="1.5" total="2" passed="1" failed="1" skipped="0">
+    <collection name="Test collection for Game.Tests.Inventory" time="1.5">
+      <test name="Game.Tests.Inventory.Add(count: 2)" type="Game.Tests.Inventory" method="Add" time="1" result="Pass">
+        <traits><trait name="Category" value="smoke" /></traits>
+      </test>
+      <test name="Game.Tests.Inventory.Remove" type="Game.Tests.Inventory" method="Remove" time="0.5" result="Fail">
+        <failure exception-type="Xunit.Sdk.EqualException">
+          <message><![CDATA[Assert.Equal() Failure]]></message>
+          <stack-trace><![CDATA[at Game.Tests.Inventory.Remove()]]></stack-trace>
+        </failure>
+      </test>
+    </collection>
+  </assembly>
+</assemblies>`
+
+	report, err := Parse("", []byte(results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cases) != 2 || report.Passed() || report.Duration != 1500*time.Millisecond {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	add := report.Cases[0]
+	if add.Name != "Add(count: 2)" || add.ClassName != "Game.Tests.Inventory" || add.Result != ResultPassed || len(add.Categories) != 1 {
+		t.Errorf("unexpected test case %+v", add)
+	}
+	remove := report.Cases[1]
+	if remove.Result != ResultFailed || remove.Message != "Assert.Equal() Failure" || remove.StartTime.Sub(report.StartTime) != time.Second {
+		t.Errorf("unexpected test case %+v", remove)
+	}
+}
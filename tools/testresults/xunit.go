@@ -0,0 +1,133 @@
+package testresults
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var xunitResults = map[string]string{
+	"Pass":       ResultPassed,
+	"Fail":       ResultFailed,
+	"Skip":       ResultSkipped,
+	"NotRun":     ResultSkipped,
+	"Incomplete": ResultInconclusive,
+}
+
+type xunitTest struct {
+	Name    string          `xml:"name,attr"`
+	Type    string          `xml:"type,attr"`
+	Method  string          `xml:"method,attr"`
+	Time    string          `xml:"time,attr"`
+	Result  string          `xml:"result,attr"`
+	Traits  []nunitProperty `xml:"traits>trait"`
+	Failure *nunitMessage   `xml:"failure"`
+	Reason  string          `xml:"reason"`
+	Output  string          `xml:"output"`
+}
+
+type xunitCollection struct {
+	Tests []xunitTest `xml:"test"`
+}
+
+type xunitAssembly struct {
+	Name        string            `xml:"name,attr"`
+	RunDate     string            `xml:"run-date,attr"`
+	RunTime     string            `xml:"run-time,attr"`
+	Time        string            `xml:"time,attr"`
+	Collections []xunitCollection `xml:"collection"`
+}
+
+func (a *xunitAssembly) startTime() time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05", a.RunDate+" "+a.RunTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func traitCategories(traits []nunitProperty) []string {
+	var result []string
+	for _, t := range traits {
+		if strings.EqualFold(t.Name, "Category") {
+			result = append(result, t.Value)
+		}
+	}
+	return result
+}
+
+// ParseXUnit reads a xUnit v2 result file, a single assembly root is accepted as well
+func ParseXUnit(r io.Reader) (*Report, error) {
+	var root struct {
+		XMLName    xml.Name
+		Assemblies []xunitAssembly `xml:"assembly"`
+		xunitAssembly
+	}
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid xunit results: %v", err)
+	}
+
+	assemblies := root.Assemblies
+	switch root.XMLName.Local {
+	case "assemblies":
+	case "assembly":
+		assemblies = []xunitAssembly{root.xunitAssembly}
+	default:
+		return nil, fmt.Errorf("invalid xunit results: unexpected root element %s", root.XMLName.Local)
+	}
+
+	report := &Report{}
+	for _, a := range assemblies {
+		start := a.startTime()
+		if !start.IsZero() && (report.StartTime.IsZero() || start.Before(report.StartTime)) {
+			report.StartTime = start
+		}
+		report.Duration += parseSeconds(a.Time)
+
+		offset := start
+		for _, collection := range a.Collections {
+			for _, t := range collection.Tests {
+				tc := TestCase{
+					Name:       t.Name,
+					FullName:   t.Name,
+					ClassName:  t.Type,
+					MethodName: t.Method,
+					Result:     xunitResults[t.Result],
+					Categories: traitCategories(t.Traits),
+					Duration:   parseSeconds(t.Time),
+					Output:     strings.TrimSpace(t.Output),
+				}
+				// the display name contains the type, parameterized tests keep their arguments
+				if len(t.Type) > 0 && strings.HasPrefix(t.Name, t.Type+".") {
+					tc.Name = strings.TrimPrefix(t.Name, t.Type+".")
+				}
+				if len(tc.Result) == 0 {
+					tc.Result = t.Result
+				}
+				if !offset.IsZero() {
+					tc.StartTime = offset
+					tc.EndTime = offset.Add(tc.Duration)
+					offset = tc.EndTime
+				}
+				if t.Failure != nil {
+					tc.Message = strings.TrimSpace(t.Failure.Message)
+					tc.StackTrace = strings.TrimSpace(t.Failure.StackTrace)
+				} else if len(t.Reason) > 0 {
+					tc.Message = strings.TrimSpace(t.Reason)
+				}
+				report.Cases = append(report.Cases, tc)
+			}
+		}
+	}
+
+	report.Result = ResultPassed
+	if !report.Passed() {
+		report.Result = ResultFailed
+	}
+	if !report.StartTime.IsZero() {
+		report.EndTime = report.StartTime.Add(report.Duration)
+	}
+	return report, nil
+}
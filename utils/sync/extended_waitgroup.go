@@ -27,12 +27,31 @@ type ExtendedWaitGroup interface {
 
 type extendedWaitGroup struct {
 	group    sync.WaitGroup
+	mutex    sync.Mutex
 	until    time.Time
 	ctx      context.Context
 	canceled bool
 	id       string
 }
 
+func (wg *extendedWaitGroup) setUntil(waitUntil time.Time) {
+	wg.mutex.Lock()
+	defer wg.mutex.Unlock()
+	wg.until = waitUntil
+}
+
+func (wg *extendedWaitGroup) isExpired() bool {
+	wg.mutex.Lock()
+	defer wg.mutex.Unlock()
+	return time.Now().After(wg.until)
+}
+
+func (wg *extendedWaitGroup) cancel() {
+	wg.mutex.Lock()
+	defer wg.mutex.Unlock()
+	wg.canceled = true
+}
+
 func (wg *extendedWaitGroup) logAction(action string) {
 	if false { // for debugging purposes
 		log.Printf("ExtendedWaitGroup [%s]: %s", wg.id, action)
@@ -41,6 +60,8 @@ func (wg *extendedWaitGroup) logAction(action string) {
 
 func (wg *extendedWaitGroup) IsCanceled() bool {
 	wg.logAction("Checked if canceled")
+	wg.mutex.Lock()
+	defer wg.mutex.Unlock()
 	return wg.canceled
 }
 
@@ -56,7 +77,7 @@ func (wg *extendedWaitGroup) Add(delta int) {
 
 func (wg *extendedWaitGroup) UpdateUntil(waitUntil time.Time) {
 	wg.logAction(fmt.Sprintf("UpdateUntil called with time: %s", waitUntil))
-	wg.until = waitUntil
+	wg.setUntil(waitUntil)
 }
 
 func (wg *extendedWaitGroup) WaitWithTimeout(duration time.Duration) error {
@@ -70,7 +91,7 @@ func (wg *extendedWaitGroup) WaitWithTimeout(duration time.Duration) error {
 
 	go func() {
 		wg.group.Wait()
-		if wg.canceled {
+		if wg.IsCanceled() {
 			return
 		}
 		if wait != nil {
@@ -81,7 +102,7 @@ func (wg *extendedWaitGroup) WaitWithTimeout(duration time.Duration) error {
 	select {
 	case <-ctx.Done():
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-			wg.canceled = true
+			wg.cancel()
 			wg.logAction("WaitWithTimeout timed out")
 			return TimeoutError
 		}
@@ -95,24 +116,22 @@ func (wg *extendedWaitGroup) WaitWithTimeout(duration time.Duration) error {
 
 func (wg *extendedWaitGroup) WaitUntil(waitUntil time.Time) error {
 	wg.logAction(fmt.Sprintf("WaitUntil called with time: %s", waitUntil))
-	wg.until = waitUntil
+	wg.setUntil(waitUntil)
 
 	timeout := make(chan bool, 1)
 	done := make(chan struct{})
-	defer close(timeout)
 	defer close(done)
 
-	ticker := time.NewTicker(50 * time.Minute)
+	ticker := time.NewTicker(50 * time.Millisecond)
 	defer ticker.Stop()
 
 	go func() {
 		for {
 			select {
-			case _, ok := <-ticker.C:
-				if !ok {
-					return
-				}
-				if time.Now().After(wg.until) {
+			case <-done:
+				return
+			case <-ticker.C:
+				if wg.isExpired() {
 					timeout <- true
 					return
 				}
@@ -128,14 +147,14 @@ func (wg *extendedWaitGroup) WaitUntil(waitUntil time.Time) error {
 
 	select {
 	case <-timeout:
-		wg.canceled = true
+		wg.cancel()
 		wg.logAction("WaitUntil timed out")
 		return TimeoutError
 	case <-wait:
 		wg.logAction("WaitUntil completed")
 		return nil
 	case <-wg.ctx.Done():
-		wg.canceled = true
+		wg.cancel()
 		wg.logAction("WaitUntil canceled by context")
 		return wg.ctx.Err()
 	}
@@ -155,7 +174,7 @@ func (wg *extendedWaitGroup) Wait() error {
 		wg.logAction("Wait completed")
 		return nil
 	case <-wg.ctx.Done():
-		wg.canceled = true
+		wg.cancel()
 		wg.logAction("Wait canceled by context")
 		return wg.ctx.Err()
 	}